@@ -0,0 +1,42 @@
+//Command vin is a command-line tool for support engineers, to decode and troubleshoot VINs.
+//
+//	vin repl
+//
+//Starts an interactive prompt, it has to run from a directory with the db folder.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+const usage = `usage: vin <command>
+
+commands:
+	repl	interactive decode, explain and suggest prompt
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "repl":
+		core.CreateContext()
+		defer core.Close()
+
+		err := repl(os.Stdin, os.Stdout)
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
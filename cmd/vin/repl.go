@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+const help = `commands:
+	decode <vin>	decode the VIN with the reference data
+	explain <vin>	show the segments of the VIN and what they mean
+	suggest <vin>	find the rule the VIN fails, and possible corrections
+	help		show this list
+	quit		leave
+press tab to complete known WMIs
+`
+
+var commands = []string{"decode", "explain", "suggest", "help", "quit"}
+
+//repl reads commands until quit or the end of the input
+func repl(in *os.File, out io.Writer) error {
+	term := newTerminal(in, out, complete(core.KnownWMIs()))
+	defer term.Restore()
+
+	colors := useColor()
+	fmt.Fprint(out, help)
+
+	for {
+		line, err := term.ReadLine("vin> ")
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		cmd, args := fields[0], fields[1:]
+
+		if cmd == "quit" || cmd == "exit" {
+			return nil
+		}
+
+		if cmd == "help" {
+			fmt.Fprint(out, help)
+			continue
+		}
+
+		if len(args) != 1 {
+			fmt.Fprintf(out, "%s needs a VIN\n", cmd)
+			continue
+		}
+
+		vin := strings.ToUpper(args[0])
+
+		switch cmd {
+		case "decode":
+			decode(out, vin)
+		case "explain":
+			explain(out, vin, colors)
+		case "suggest":
+			suggest(out, vin)
+		default:
+			fmt.Fprintf(out, "unknown command %s, try help\n", cmd)
+		}
+	}
+}
+
+func decode(out io.Writer, vin string) {
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		fmt.Fprintln(out, "invalid:", err)
+		return
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		fmt.Fprintln(out, "unable to decode:", err)
+		return
+	}
+
+	bits, err := json.MarshalIndent(obj, "", "  ")
+
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return
+	}
+
+	fmt.Fprintln(out, string(bits))
+}
+
+//segment colours, in the order of the VIN
+var segmentColors = []string{"\x1b[36m", "\x1b[33m", "\x1b[35m", "\x1b[32m", "\x1b[34m", "\x1b[37m"}
+
+const resetColor = "\x1b[0m"
+
+func explain(out io.Writer, vin string, colors bool) {
+	if len(vin) != 17 {
+		fmt.Fprintln(out, "a VIN has 17 characters, this one has", len(vin))
+		return
+	}
+
+	seg := vinmath.Segment(vin)
+	parts := []string{seg.WMI, seg.VDS, seg.CheckDigit, seg.YearCode, seg.PlantCode, seg.Sequence}
+
+	for i, p := range parts {
+		if colors {
+			fmt.Fprint(out, segmentColors[i], p, resetColor)
+		} else {
+			fmt.Fprint(out, p, " ")
+		}
+	}
+
+	fmt.Fprintln(out)
+
+	label := func(i int, name, detail string) {
+		if colors {
+			name = segmentColors[i] + name + resetColor
+		}
+
+		fmt.Fprintf(out, "  %-12s %s\n", name, detail)
+	}
+
+	wmi := seg.WMI + " " + vinmath.Region(seg.WMI[:1])
+
+	if info, err := core.FindWMInfo(vin[:11]); err == nil && len(info.Manufacturer) > 0 {
+		wmi += ", " + info.Country + ", " + info.Manufacturer
+	}
+
+	label(0, "WMI", wmi)
+	label(1, "VDS", seg.VDS)
+
+	check := "can't be calculated, the VIN has illegal characters"
+
+	if vinmath.ValidateBatch([]string{vin})[0].Rule != vinmath.RuleCharacter {
+		check = seg.CheckDigit + ", calculated " + vinmath.CheckDigit(vin)
+	}
+
+	label(2, "Check digit", check)
+
+	years, err := vinmath.Years(seg.YearCode)
+
+	if err != nil {
+		label(3, "Year", err.Error())
+	} else {
+		label(3, "Year", fmt.Sprint(years))
+	}
+
+	label(4, "Plant", seg.PlantCode)
+	label(5, "Serial", seg.Sequence)
+}
+
+func suggest(out io.Writer, vin string) {
+	result := vinmath.ValidateBatch([]string{vin})[0]
+
+	if result.Valid {
+		fmt.Fprintln(out, "valid")
+	} else {
+		fmt.Fprintf(out, "fails %s", result.Rule)
+
+		if result.Position > 0 {
+			fmt.Fprintf(out, " at position %d", result.Position)
+		}
+
+		fmt.Fprintln(out)
+
+		if len(result.Suggestion) > 0 {
+			fmt.Fprintln(out, "did you mean", result.Suggestion)
+		}
+	}
+
+	if len(vin) < 3 {
+		return
+	}
+
+	if _, err := core.FindManufacturer(vin); err != nil {
+		if similar := core.SuggestWMI(vin); len(similar) > 0 {
+			fmt.Fprintln(out, "unknown WMI, similar WMIs", strings.Join(similar, ", "))
+		} else {
+			fmt.Fprintln(out, "unknown WMI")
+		}
+	}
+}
+
+//complete returns the completer for the line, commands are completed first and then WMIs.
+func complete(wmis []string) func(line string) []string {
+	return func(line string) []string {
+		fields := strings.Fields(line)
+		endsWithSpace := strings.HasSuffix(line, " ")
+
+		var options []string
+		prefix := ""
+
+		switch {
+		case len(fields) == 0:
+			options = commands
+		case len(fields) == 1 && !endsWithSpace:
+			options = commands
+			prefix = fields[0]
+		case len(fields) == 1 && endsWithSpace:
+			options = wmis
+		case len(fields) == 2 && !endsWithSpace:
+			options = wmis
+			prefix = strings.ToUpper(fields[1])
+		}
+
+		var result []string
+
+		for _, o := range options {
+			if strings.HasPrefix(o, prefix) {
+				result = append(result, o)
+			}
+		}
+
+		sort.Strings(result)
+
+		return result
+	}
+}
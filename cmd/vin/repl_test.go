@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	fn := complete([]string{"AAV", "AHT", "WVW"})
+
+	cases := map[string][]string{
+		"de":          {"decode"},
+		"decode ":     {"AAV", "AHT", "WVW"},
+		"decode a":    {"AAV", "AHT"},
+		"decode WV":   {"WVW"},
+		"decode WVW1": nil,
+	}
+
+	for line, expected := range cases {
+		if got := fn(line); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%q expected %v, got %v", line, expected, got)
+		}
+	}
+}
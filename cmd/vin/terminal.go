@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//terminal reads lines, with tab completion when the input is a terminal which can be put in raw mode.
+type terminal struct {
+	in       *os.File
+	out      io.Writer
+	complete func(line string) []string
+	restore  func()
+	lines    *bufio.Reader
+}
+
+func newTerminal(in *os.File, out io.Writer, complete func(line string) []string) *terminal {
+	t := &terminal{in: in, out: out, complete: complete}
+	restore, err := makeRaw(in)
+
+	if err != nil {
+		t.lines = bufio.NewReader(in)
+	} else {
+		t.restore = restore
+	}
+
+	return t
+}
+
+//Restore returns the terminal to the mode it was in
+func (t *terminal) Restore() {
+	if t.restore != nil {
+		t.restore()
+	}
+}
+
+//useColor returns true when the output is a terminal, and NO_COLOR isn't set
+func useColor() bool {
+	return isTerminal(os.Stdout) && len(os.Getenv("NO_COLOR")) == 0
+}
+
+//ReadLine shows the prompt and returns the line without the newline
+func (t *terminal) ReadLine(prompt string) (string, error) {
+	fmt.Fprint(t.out, prompt)
+
+	if t.lines != nil {
+		line, err := t.lines.ReadString('\n')
+
+		if err == io.EOF && len(line) > 0 {
+			err = nil
+		}
+
+		return strings.TrimSpace(line), err
+	}
+
+	var buf []byte
+	b := make([]byte, 1)
+
+	for {
+		_, err := t.in.Read(b)
+
+		if err != nil {
+			return "", err
+		}
+
+		switch c := b[0]; {
+		case c == '\r' || c == '\n':
+			fmt.Fprint(t.out, "\n")
+			return string(buf), nil
+		case c == 3: //Ctrl-C discards the line
+			fmt.Fprint(t.out, "^C\n")
+			return "", nil
+		case c == 4: //Ctrl-D on an empty line leaves
+			if len(buf) == 0 {
+				fmt.Fprint(t.out, "\n")
+				return "", io.EOF
+			}
+		case c == 127 || c == 8:
+			if len(buf) > 0 {
+				buf = buf[:len(buf)-1]
+				fmt.Fprint(t.out, "\b \b")
+			}
+		case c == '\t':
+			buf = t.tab(prompt, buf)
+		case c == 27: //Arrow keys and other escape sequences aren't supported
+			t.in.Read(b)
+			t.in.Read(b)
+		case c >= 32 && c < 127:
+			buf = append(buf, c)
+			t.out.Write(b)
+		}
+	}
+}
+
+//tab completes the last word of the line. When there are several options, the common prefix is completed
+//and the options are listed.
+func (t *terminal) tab(prompt string, buf []byte) []byte {
+	line := string(buf)
+	options := t.complete(line)
+
+	if len(options) == 0 {
+		return buf
+	}
+
+	word := ""
+
+	if !strings.HasSuffix(line, " ") {
+		fields := strings.Fields(line)
+
+		if len(fields) > 0 {
+			word = fields[len(fields)-1]
+		}
+	}
+
+	common := options[0]
+
+	for _, o := range options[1:] {
+		for !strings.HasPrefix(o, common) {
+			common = common[:len(common)-1]
+		}
+	}
+
+	if len(options) == 1 {
+		common += " "
+	}
+
+	if len(common) > len(word) {
+		add := common[len(word):]
+		buf = append(buf, add...)
+		fmt.Fprint(t.out, add)
+
+		return buf
+	}
+
+	fmt.Fprintf(t.out, "\n%s\n%s%s", strings.Join(options, "  "), prompt, buf)
+
+	return buf
+}
@@ -0,0 +1,62 @@
+//go:build linux
+// +build linux
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func getTermios(f *os.File) (syscall.Termios, error) {
+	var result syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&result)))
+
+	if errno != 0 {
+		return result, errno
+	}
+
+	return result, nil
+}
+
+func setTermios(f *os.File, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(t)))
+
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+func isTerminal(f *os.File) bool {
+	_, err := getTermios(f)
+
+	return err == nil
+}
+
+//makeRaw turns off line buffering and echo, so key presses like tab can be handled.
+//The returned func restores the previous mode.
+func makeRaw(f *os.File) (func(), error) {
+	old, err := getTermios(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO | syscall.ISIG
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+
+	err = setTermios(f, &raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		setTermios(f, &old)
+	}, nil
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+//isTerminal isn't supported, so colours are off
+func isTerminal(f *os.File) bool {
+	return false
+}
+
+//makeRaw isn't supported, lines are read without tab completion
+func makeRaw(f *os.File) (func(), error) {
+	return nil, errors.New("raw mode isn't supported")
+}
@@ -0,0 +1,52 @@
+package colourcodes
+
+//colours are the paint codes of each brand, by code
+var colours = map[string]map[string]string{
+	"BMW": {
+		"300": "Alpine White",
+		"416": "Carbon Black Metallic",
+		"475": "Black Sapphire Metallic",
+		"668": "Jet Black",
+		"A52": "Space Grey Metallic",
+		"A75": "Melbourne Red Metallic",
+		"A83": "Glacier Silver Metallic",
+		"A96": "Mineral White Metallic",
+		"B39": "Mineral Grey Metallic",
+		"C10": "Mediterranean Blue Metallic",
+	},
+	"Mercedes-Benz": {
+		"040": "Black",
+		"149": "Polar White",
+		"197": "Obsidian Black Metallic",
+		"755": "Tenorite Grey Metallic",
+		"775": "Iridium Silver Metallic",
+		"792": "Palladium Silver Metallic",
+		"799": "Diamond White Bright",
+	},
+	"Toyota": {
+		"040": "Super White",
+		"070": "Blizzard Pearl",
+		"1F7": "Classic Silver Metallic",
+		"1G3": "Magnetic Gray Metallic",
+		"202": "Black",
+		"218": "Attitude Black Mica",
+		"3R3": "Barcelona Red Metallic",
+	},
+	"Volkswagen": {
+		"L041": "Brilliant Black",
+		"LA7W": "Reflex Silver Metallic",
+		"LB9A": "Candy White",
+		"LC9X": "Deep Black Pearl",
+	},
+	"Ford": {
+		"UA": "Ebony Black",
+		"YZ": "Oxford White",
+	},
+	"Tesla": {
+		"PBSB": "Solid Black",
+		"PMNG": "Midnight Silver Metallic",
+		"PPMR": "Red Multi-Coat",
+		"PPSB": "Deep Blue Metallic",
+		"PPSW": "Pearl White Multi-Coat",
+	},
+}
@@ -0,0 +1,114 @@
+//Package colourcodes resolves the paint codes printed on a manufacturer's paint code plate.
+//Colour isn't part of the VIN, so apps which scan both can show the colour alongside the decode.
+package colourcodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//Colour is a paint of a manufacturer
+type Colour struct {
+	Manufacturer string
+	Code         string
+	Name         string
+	Finish       string //solid, metallic or pearl
+}
+
+var mu sync.RWMutex
+
+//Lookup returns the colour of the manufacturer's paint code.
+//Manufacturer names like 'BMW AG' or 'Toyota Motor Corporation' use the codes of the brand they start with.
+func Lookup(manufacturer, code string) (Colour, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	brand, ok := brandOf(manufacturer)
+
+	if !ok {
+		return Colour{}, fmt.Errorf("no colour codes for %s", manufacturer)
+	}
+
+	name, ok := colours[brand][normalise(code)]
+
+	if !ok {
+		return Colour{}, fmt.Errorf("%s is not a known %s colour code", code, brand)
+	}
+
+	return colour(brand, normalise(code), name), nil
+}
+
+//ForManufacturer returns the known colours of the manufacturer, ordered by code
+func ForManufacturer(manufacturer string) []Colour {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	brand, ok := brandOf(manufacturer)
+
+	if !ok {
+		return nil
+	}
+
+	var result []Colour
+
+	for code, name := range colours[brand] {
+		result = append(result, colour(brand, code, name))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+//Register adds colour codes, or renames existing codes, of the manufacturer
+func Register(manufacturer string, codes map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	brand, ok := brandOf(manufacturer)
+
+	if !ok {
+		brand = manufacturer
+		colours[brand] = make(map[string]string)
+	}
+
+	for code, name := range codes {
+		colours[brand][normalise(code)] = name
+	}
+}
+
+//brandOf returns the key of the manufacturer in colours
+func brandOf(manufacturer string) (string, bool) {
+	name := strings.ToLower(strings.TrimSpace(manufacturer))
+
+	for brand := range colours {
+		b := strings.ToLower(brand)
+
+		if name == b || strings.HasPrefix(name, b+" ") || strings.HasPrefix(name, b+",") {
+			return brand, true
+		}
+	}
+
+	return "", false
+}
+
+func normalise(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func colour(brand, code, name string) Colour {
+	finish := "solid"
+	lower := strings.ToLower(name)
+
+	if strings.Contains(lower, "metallic") {
+		finish = "metallic"
+	} else if strings.Contains(lower, "pearl") || strings.Contains(lower, "mica") || strings.Contains(lower, "multi-coat") {
+		finish = "pearl"
+	}
+
+	return Colour{Manufacturer: brand, Code: code, Name: name, Finish: finish}
+}
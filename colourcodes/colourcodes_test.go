@@ -0,0 +1,49 @@
+package colourcodes
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	c, err := Lookup("BMW AG", " a96 ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Name != "Mineral White Metallic" || c.Finish != "metallic" || c.Manufacturer != "BMW" {
+		t.Errorf("unexpected colour %+v", c)
+	}
+
+	c, err = Lookup("Tesla, Inc.", "PPSW")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Finish != "pearl" {
+		t.Errorf("expected a pearl finish, got %+v", c)
+	}
+
+	_, err = Lookup("Toyota", "A96")
+
+	if err == nil {
+		t.Error("expected codes to be per manufacturer")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("Subaru", map[string]string{"K1X": "Crystal Black Silica"})
+
+	c, err := Lookup("Subaru Corporation", "K1X")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Finish != "solid" {
+		t.Errorf("unexpected colour %+v", c)
+	}
+
+	if len(ForManufacturer("Subaru")) != 1 {
+		t.Error("expected the registered colour to be listed")
+	}
+}
@@ -0,0 +1,232 @@
+//Package compliance checks decoded VINs against the rules of low and ultra low emission zones.
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//Verdict is the outcome of a zone's rules for a vehicle
+type Verdict int
+
+const (
+	Allowed Verdict = iota
+	Charged
+	Banned
+)
+
+var verdictNames = [...]string{
+	"allowed",
+	"charged",
+	"banned"}
+
+func (v Verdict) String() string {
+	return verdictNames[v]
+}
+
+//MarshalText writes the verdict by name
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+const (
+	//AttrFirstRegistration is the VIN attribute with the year the vehicle was first registered, the model year is used when it's missing
+	AttrFirstRegistration = "firstRegistration"
+	//AttrEmissionStandard is the VIN attribute with the Euro emission standard, ie. 6. It's estimated from the registration year when it's missing
+	AttrEmissionStandard = "euro"
+)
+
+//euroDates are the years from which new registrations had to meet each Euro standard
+var euroDates = [...]int{1993, 1997, 2001, 2006, 2011, 2015}
+
+//Vehicle is what the rules of a zone are checked against
+type Vehicle struct {
+	Fuel              string //petrol, diesel, hybrid, electric or empty when unknown
+	Standard          int    //Euro emission standard, 0 when unknown
+	EstimatedStandard bool   //Standard was derived from the registration year
+	FirstRegistration int
+	Steering          string //vds.LHD, vds.RHD or empty when unknown
+}
+
+//Rule returns the verdict for the vehicle, applies is false when the rule doesn't cover the vehicle.
+type Rule func(v Vehicle) (verdict Verdict, reason string, applies bool)
+
+//Zone is a set of rules, the first rule which applies decides the verdict.
+//Vehicles no rule applies to get the Default verdict.
+type Zone struct {
+	Name    string
+	Rules   []Rule
+	Default Verdict
+}
+
+//Result is returned by CheckZone
+type Result struct {
+	Zone    string
+	Verdict Verdict
+	Reason  string
+	Vehicle Vehicle
+}
+
+var (
+	zonesMu sync.RWMutex
+	zones   = make(map[string]Zone)
+)
+
+func init() {
+	Register(Zone{
+		Name: "ulez",
+		Rules: []Rule{
+			ZeroEmission(),
+			MinimumStandard("petrol", 4, Charged),
+			MinimumStandard("hybrid", 4, Charged),
+			MinimumStandard("diesel", 6, Charged),
+		},
+		Default: Charged,
+	})
+}
+
+//Register adds the zone, or replaces the rules of a zone with the same name
+func Register(z Zone) {
+	zonesMu.Lock()
+	defer zonesMu.Unlock()
+
+	zones[strings.ToLower(z.Name)] = z
+}
+
+//Zones returns the names of the registered zones
+func Zones() []string {
+	zonesMu.RLock()
+	defer zonesMu.RUnlock()
+
+	var result []string
+
+	for name := range zones {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+//CheckZone returns whether the decoded VIN is allowed, charged or banned in the zone.
+func CheckZone(vin core.VIN, zone string) (Result, error) {
+	zonesMu.RLock()
+	z, ok := zones[strings.ToLower(zone)]
+	zonesMu.RUnlock()
+
+	if !ok {
+		return Result{}, fmt.Errorf("zone %s is not registered", zone)
+	}
+
+	v := VehicleOf(vin)
+	result := Result{Zone: z.Name, Vehicle: v, Verdict: z.Default, Reason: "no rule applies"}
+
+	for _, rule := range z.Rules {
+		verdict, reason, applies := rule(v)
+
+		if applies {
+			result.Verdict = verdict
+			result.Reason = reason
+			break
+		}
+	}
+
+	return result, nil
+}
+
+//VehicleOf returns the fuel, emission standard, registration year and steering position of the decoded VIN
+func VehicleOf(vin core.VIN) Vehicle {
+	result := Vehicle{
+		Fuel:              fuel(vin),
+		FirstRegistration: vin.ModelYear(),
+		Steering:          vin.VDSInfo.SteeringPosition,
+	}
+
+	if year, err := strconv.Atoi(vin.Attributes[AttrFirstRegistration]); err == nil {
+		result.FirstRegistration = year
+	}
+
+	if std, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(vin.Attributes[AttrEmissionStandard]), "euro")); err == nil {
+		result.Standard = std
+		return result
+	}
+
+	result.Standard = standardFor(result.FirstRegistration)
+	result.EstimatedStandard = result.Standard > 0
+
+	return result
+}
+
+//standardFor returns the Euro standard all new registrations in the year had to meet
+func standardFor(year int) int {
+	result := 0
+
+	for i, from := range euroDates {
+		if year >= from {
+			result = i + 1
+		}
+	}
+
+	return result
+}
+
+func fuel(vin core.VIN) string {
+	result := strings.ToLower(vin.Series.Platform.Engine.FuelType)
+
+	if len(result) == 0 && vin.VDSInfo.EV != nil {
+		switch vin.VDSInfo.EV.Powertrain {
+		case vds.BEV:
+			return "electric"
+		case vds.PHEV:
+			return "hybrid"
+		}
+	}
+
+	return result
+}
+
+//ZeroEmission allows electric vehicles
+func ZeroEmission() Rule {
+	return func(v Vehicle) (Verdict, string, bool) {
+		return Allowed, "zero emission", v.Fuel == "electric"
+	}
+}
+
+//MinimumStandard applies to vehicles of the fuel type, those below the Euro standard get the verdict.
+func MinimumStandard(fuel string, standard int, below Verdict) Rule {
+	return func(v Vehicle) (Verdict, string, bool) {
+		if v.Fuel != fuel {
+			return Allowed, "", false
+		}
+
+		if v.Standard >= standard {
+			return Allowed, fmt.Sprintf("%s meets Euro %d", fuel, standard), true
+		}
+
+		return below, fmt.Sprintf("%s below Euro %d", fuel, standard), true
+	}
+}
+
+//RegisteredBefore applies to vehicles of the fuel type first registered before the year, an empty fuel applies to every vehicle.
+func RegisteredBefore(fuel string, year int, verdict Verdict) Rule {
+	return func(v Vehicle) (Verdict, string, bool) {
+		applies := (len(fuel) == 0 || v.Fuel == fuel) && v.FirstRegistration < year
+
+		return verdict, fmt.Sprintf("registered before %d", year), applies
+	}
+}
+
+//SteeringPosition applies to vehicles with the steering position, ie. vds.LHD for imports into right-hand traffic countries.
+//Vehicles with an unknown steering position don't apply.
+func SteeringPosition(position string, verdict Verdict) Rule {
+	return func(v Vehicle) (Verdict, string, bool) {
+		return verdict, fmt.Sprintf("%s vehicle", position), len(v.Steering) > 0 && v.Steering == position
+	}
+}
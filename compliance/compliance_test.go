@@ -0,0 +1,98 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func vehicle(fuel string, year int, attrs map[string]string) core.VIN {
+	v := core.VIN{Years: []int{year}, Attributes: attrs}
+	v.Series.Platform.Engine.FuelType = fuel
+
+	return v
+}
+
+func TestCheckZone_ULEZ(t *testing.T) {
+	cases := []struct {
+		vin    core.VIN
+		expect Verdict
+	}{
+		{vehicle("Petrol", 2007, nil), Allowed},
+		{vehicle("Petrol", 2004, nil), Charged},
+		{vehicle("Diesel", 2012, nil), Charged},
+		{vehicle("Diesel", 2012, map[string]string{AttrEmissionStandard: "Euro6"}), Allowed},
+		{vehicle("Diesel", 2016, nil), Allowed},
+		{vehicle("Diesel", 2016, map[string]string{AttrFirstRegistration: "2014"}), Charged},
+		{vehicle("", 2019, nil), Charged},
+		{core.VIN{Years: []int{2012}, VDSInfo: vds.VDSInfo{EV: &vds.EVInfo{Powertrain: vds.BEV}}}, Allowed},
+	}
+
+	for i, c := range cases {
+		result, err := CheckZone(c.vin, "ULEZ")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Verdict != c.expect {
+			t.Errorf("case %d: expected %s, got %s (%s)", i, c.expect, result.Verdict, result.Reason)
+		}
+	}
+}
+
+func TestCheckZone_Custom(t *testing.T) {
+	Register(Zone{
+		Name:    "test-ban",
+		Rules:   []Rule{ZeroEmission(), RegisteredBefore("diesel", 2011, Banned)},
+		Default: Allowed,
+	})
+
+	result, err := CheckZone(vehicle("Diesel", 2009, nil), "test-ban")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Verdict != Banned {
+		t.Errorf("expected banned, got %s", result.Verdict)
+	}
+
+	_, err = CheckZone(vehicle("Diesel", 2009, nil), "unknown")
+
+	if err == nil {
+		t.Error("expected an error for an unregistered zone")
+	}
+}
+
+func TestCheckZone_SteeringPosition(t *testing.T) {
+	Register(Zone{
+		Name:    "test-rhd",
+		Rules:   []Rule{SteeringPosition(vds.LHD, Banned)},
+		Default: Allowed,
+	})
+
+	lhd := vehicle("Petrol", 2018, nil)
+	lhd.VDSInfo.SteeringPosition = vds.LHD
+
+	result, err := CheckZone(lhd, "test-rhd")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Verdict != Banned {
+		t.Errorf("expected banned, got %s", result.Verdict)
+	}
+
+	result, err = CheckZone(vehicle("Petrol", 2018, nil), "test-rhd")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Verdict != Allowed {
+		t.Errorf("expected unknown steering to be allowed, got %s", result.Verdict)
+	}
+}
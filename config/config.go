@@ -0,0 +1,313 @@
+//Package config loads the settings of the service once at startup, from a JSON file and the environment.
+//Environment variables override the file, so a deployment can change a setting without a new file.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Config is every setting of the service, see Load
+type Config struct {
+	Store      Store
+	Cache      Cache
+	Keys       Keys
+	Validation Validation
+	Decoders   Decoders
+	Quota      Quota
+	Reference  Reference
+	Stream     Stream
+}
+
+//Store configures the reference and VIN store
+type Store struct {
+	Seed       string //Regions which are seeded on startup
+	Quarantine bool   //Keeps VINs which can't be decoded for review
+	//MinCoverage is the share of manufacturers which VDS has to be decodable for the service to start, 0 only reports it
+	MinCoverage float64
+	CloneWindow Duration //How long after a VIN was created, another source sending it is suspicious, 0 turns it off
+	Retention   string   //Purges fields of old records, ie. serial=5y,attributes=1y
+}
+
+//Cache configures the caches of the decode
+type Cache struct {
+	NegativeTTL       Duration //How long WMI and VDS misses are remembered, 0 turns it off
+	BloomSize         int      //The fewest VINs the bloom filter of the VIN store is sized for
+	ReferenceInMemory bool     //Keeps the WMI and VDS reference data in memory
+}
+
+//Keys are the endpoints and credentials of external services
+type Keys struct {
+	PlateURL     string //The plate provider's API, with {plate} and {region} placeholders
+	PlateToken   string
+	PlateField   string //Field of the plate provider's response with the VIN
+	RetentionKey string //Hashes the VINs which had their serial purged
+}
+
+//Validation configures how VINs are validated
+type Validation struct {
+	Mode             string   //The validation mode when a request doesn't name one, see vinmath.ParseMode
+	Chars            string   //What happens to I, O and Q when a request doesn't name a policy, see vinmath.ParseCharPolicy
+	CheckDigitExempt []string //WMIs which don't use a check digit
+}
+
+//Decoders configures the decode steps
+type Decoders struct {
+	Disabled []string //Manufacturers which VDS decoders are turned off
+	FuzzyWMI bool     //Suggests similar WMIs when the manufacturer can't be found
+	Tables   []string //JSON files with manufacturer decoder tables, see vdskit.LoadTables
+	Budget   string   //Limits the time of a decode and its stages, ie. total=300ms,store=50ms
+}
+
+//Quota is the daily decode limit of callers without their own quota, 0 is unlimited
+type Quota struct {
+	Soft int
+	Hard int
+}
+
+//Reference configures where reference data is imported from
+type Reference struct {
+	Sync []string //Manufacturers which WMIs are synced from vPIC once a day
+	Dump string   //Directory with the CSV exports of the vPIC database, imported on startup
+}
+
+//Stream configures where decode events are published
+type Stream struct {
+	NATS    string //Address of the nats server
+	Kafka   string //URL of the Kafka REST Proxy
+	Subject string
+}
+
+//Duration is a time.Duration which is written as a string in JSON, ie. "1m30s"
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+
+	if err != nil {
+		return err
+	}
+
+	v, err := time.ParseDuration(s)
+
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(v)
+
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+//Default returns the settings of a service without a config file
+func Default() Config {
+	return Config{
+		Store: Store{Seed: "db/regions.seed.json", CloneWindow: Duration(30 * 24 * time.Hour)},
+		Cache: Cache{NegativeTTL: Duration(time.Minute), BloomSize: 100000},
+	}
+}
+
+//Load returns the Default settings, changed by the file and then the environment.
+//An empty path only reads the environment.
+func Load(path string) (Config, error) {
+	result := Default()
+
+	if len(path) > 0 {
+		data, err := ioutil.ReadFile(path)
+
+		if err != nil {
+			return result, err
+		}
+
+		err = json.Unmarshal(data, &result)
+
+		if err != nil {
+			return result, fmt.Errorf("%s: %s", path, err)
+		}
+	}
+
+	err := result.fromEnv(os.Getenv)
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, result.validate()
+}
+
+//validate returns an error for the first setting which can't be used
+func (c Config) validate() error {
+	_, err := vinmath.ParseMode(c.Validation.Mode)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = vinmath.ParseCharPolicy(c.Validation.Chars)
+
+	if err != nil {
+		return err
+	}
+
+	if c.Store.CloneWindow < 0 {
+		return fmt.Errorf("clone window %s can't be negative", time.Duration(c.Store.CloneWindow))
+	}
+
+	if c.Quota.Soft < 0 || c.Quota.Hard < 0 {
+		return fmt.Errorf("quota %d/%d can't be negative", c.Quota.Soft, c.Quota.Hard)
+	}
+
+	if c.Quota.Hard > 0 && c.Quota.Soft > c.Quota.Hard {
+		return fmt.Errorf("soft quota %d is above the hard quota %d", c.Quota.Soft, c.Quota.Hard)
+	}
+
+	if (len(c.Stream.NATS) > 0 || len(c.Stream.Kafka) > 0) && len(c.Stream.Subject) == 0 {
+		return fmt.Errorf("stream needs a subject")
+	}
+
+	if len(c.Reference.Dump) > 0 {
+		info, err := os.Stat(c.Reference.Dump)
+
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return fmt.Errorf("vpic dump %s isn't a directory", c.Reference.Dump)
+		}
+	}
+
+	return nil
+}
+
+//fromEnv overrides the settings with the environment variables which are set
+func (c *Config) fromEnv(getenv func(string) string) error {
+	str := func(name string, field *string) {
+		if v := getenv(name); len(v) > 0 {
+			*field = v
+		}
+	}
+
+	//failed is the first variable which couldn't be parsed
+	var failed error
+
+	fail := func(name string, err error) {
+		if failed == nil {
+			failed = fmt.Errorf("%s: %s", name, err)
+		}
+	}
+
+	flag := func(name string, field *bool) {
+		if v := getenv(name); len(v) > 0 {
+			b, err := strconv.ParseBool(v)
+
+			if err != nil {
+				fail(name, err)
+				return
+			}
+
+			*field = b
+		}
+	}
+
+	number := func(name string, field *int) {
+		if v := getenv(name); len(v) > 0 {
+			n, err := strconv.Atoi(v)
+
+			if err != nil {
+				fail(name, err)
+				return
+			}
+
+			*field = n
+		}
+	}
+
+	share := func(name string, field *float64) {
+		if v := getenv(name); len(v) > 0 {
+			f, err := strconv.ParseFloat(v, 64)
+
+			if err != nil {
+				fail(name, err)
+				return
+			}
+
+			*field = f
+		}
+	}
+
+	duration := func(name string, field *Duration) {
+		if v := getenv(name); len(v) > 0 {
+			d, err := time.ParseDuration(v)
+
+			if err != nil {
+				fail(name, err)
+				return
+			}
+
+			*field = Duration(d)
+		}
+	}
+
+	list := func(name string, field *[]string) {
+		if v := getenv(name); len(v) > 0 {
+			*field = strings.Split(v, ",")
+		}
+	}
+
+	str("SEEDFILE", &c.Store.Seed)
+	//QUARANTINE keeps VINs which can't be decoded for review
+	flag("QUARANTINE", &c.Store.Quarantine)
+	//REFINMEMORY keeps the WMI and VDS reference data in memory, decodes don't read the store
+	flag("REFINMEMORY", &c.Cache.ReferenceInMemory)
+	//PLATEURL is the plate provider's API, with {plate} and {region} placeholders
+	str("PLATEURL", &c.Keys.PlateURL)
+	str("PLATETOKEN", &c.Keys.PlateToken)
+	str("PLATEFIELD", &c.Keys.PlateField)
+	str("RETENTIONKEY", &c.Keys.RetentionKey)
+	str("VALIDATIONMODE", &c.Validation.Mode)
+	//VALIDATIONCHARS is reject, substitute or accept for I, O and Q
+	str("VALIDATIONCHARS", &c.Validation.Chars)
+	//CHECKDIGITEXEMPT is a comma separated list of WMIs which don't use a check digit
+	list("CHECKDIGITEXEMPT", &c.Validation.CheckDigitExempt)
+	//DECODERSOFF is a comma separated list of manufacturers which VDS decoders are turned off
+	list("DECODERSOFF", &c.Decoders.Disabled)
+	//WMIFUZZY suggests similar WMIs when the manufacturer can't be found
+	flag("WMIFUZZY", &c.Decoders.FuzzyWMI)
+	//DECODERTABLES is a comma separated list of JSON files with manufacturer decoder tables
+	list("DECODERTABLES", &c.Decoders.Tables)
+	//DECODEBUDGET limits the time of a decode and its stages, ie. total=300ms,store=50ms,plugin=100ms
+	str("DECODEBUDGET", &c.Decoders.Budget)
+	duration("NEGATIVETTL", &c.Cache.NegativeTTL)
+	number("BLOOMSIZE", &c.Cache.BloomSize)
+	//CLONEWINDOW is how long a VIN from a second source is suspicious, ie. 720h
+	duration("CLONEWINDOW", &c.Store.CloneWindow)
+	//RETENTION purges fields of old records, ie. serial=5y,attributes=1y
+	str("RETENTION", &c.Store.Retention)
+	//QUOTASOFT and QUOTAHARD are the daily decode limits of callers without their own quota
+	number("QUOTASOFT", &c.Quota.Soft)
+	number("QUOTAHARD", &c.Quota.Hard)
+	//WMISYNC is a comma separated list of manufacturers to sync from vPIC
+	list("WMISYNC", &c.Reference.Sync)
+	//VPICDUMP is a directory with the CSV exports of the vPIC database, imported on startup
+	str("VPICDUMP", &c.Reference.Dump)
+	//STREAM_NATS or STREAM_KAFKA publish decode events to STREAM_SUBJECT
+	str("STREAM_NATS", &c.Stream.NATS)
+	str("STREAM_KAFKA", &c.Stream.Kafka)
+	str("STREAM_SUBJECT", &c.Stream.Subject)
+	share("MINCOVERAGE", &c.Store.MinCoverage)
+
+	return failed
+}
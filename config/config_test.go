@@ -0,0 +1,162 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoad_FileAndEnvironment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "vin.json")
+	err = ioutil.WriteFile(path, []byte(`{"Cache": {"NegativeTTL": "5m"}, "Validation": {"Mode": "za"}, "Decoders": {"Disabled": ["Tesla"]}}`), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Duration(cfg.Cache.NegativeTTL) != 5*time.Minute || cfg.Validation.Mode != "za" || cfg.Store.Seed != Default().Store.Seed {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+
+	env := map[string]string{"DECODERSOFF": "BMW,Toyota", "BLOOMSIZE": "5000"}
+	err = cfg.fromEnv(func(name string) string { return env[name] })
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(cfg.Decoders.Disabled, []string{"BMW", "Toyota"}) || cfg.Cache.BloomSize != 5000 {
+		t.Errorf("expected the environment to override the file, got %+v", cfg)
+	}
+}
+
+func TestLoad_InvalidMode(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "vin.json")
+	ioutil.WriteFile(path, []byte(`{"Validation": {"Mode": "lenient"}}`), 0644)
+
+	if _, err := Load(path); err == nil {
+		t.Error("expected an unknown mode to fail")
+	}
+}
+
+func TestLoad_CharPolicy(t *testing.T) {
+	cfg := Default()
+	err := cfg.fromEnv(func(name string) string {
+		if name == "VALIDATIONCHARS" {
+			return "substitute"
+		}
+
+		return ""
+	})
+
+	if err != nil || cfg.Validation.Chars != "substitute" {
+		t.Fatalf("expected substitute, got %s %v", cfg.Validation.Chars, err)
+	}
+
+	dir, err := ioutil.TempDir("", "config")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "vin.json")
+	ioutil.WriteFile(path, []byte(`{"Validation": {"Chars": "ignore"}}`), 0644)
+
+	if _, err := Load(path); err == nil {
+		t.Error("expected an unknown character policy to fail")
+	}
+}
+
+func TestFromEnv_InvalidFlag(t *testing.T) {
+	cfg := Default()
+	err := cfg.fromEnv(func(name string) string {
+		if name == "QUARANTINE" {
+			return "yes"
+		}
+
+		return ""
+	})
+
+	if err == nil {
+		t.Error("expected an invalid flag to fail")
+	}
+
+	err = cfg.fromEnv(func(name string) string {
+		if name == "QUARANTINE" {
+			return "1"
+		}
+
+		return ""
+	})
+
+	if err != nil || !cfg.Store.Quarantine {
+		t.Errorf("expected 1 to turn quarantine on, got %v %v", cfg.Store.Quarantine, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	env := map[string]map[string]string{
+		"negative window": {"CLONEWINDOW": "-1h"},
+		"soft above hard": {"QUOTASOFT": "10", "QUOTAHARD": "5"},
+		"negative quota":  {"QUOTAHARD": "-1"},
+		"no subject":      {"STREAM_NATS": "nats://localhost:4222"},
+		"missing dump":    {"VPICDUMP": "does/not/exist"},
+		"invalid number":  {"QUOTASOFT": "ten"},
+	}
+
+	for name, vars := range env {
+		cfg := Default()
+		err := cfg.fromEnv(func(name string) string { return vars[name] })
+
+		if err == nil {
+			err = cfg.validate()
+		}
+
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	cfg := Default()
+	vars := map[string]string{"CLONEWINDOW": "720h", "QUOTASOFT": "5", "QUOTAHARD": "10", "STREAM_KAFKA": "http://localhost:8082", "STREAM_SUBJECT": "decodes", "WMISYNC": "BMW,Tesla"}
+	err := cfg.fromEnv(func(name string) string { return vars[name] })
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if time.Duration(cfg.Store.CloneWindow) != 720*time.Hour || cfg.Quota.Hard != 10 || !reflect.DeepEqual(cfg.Reference.Sync, []string{"BMW", "Tesla"}) {
+		t.Errorf("unexpected config %+v", cfg)
+	}
+}
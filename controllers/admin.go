@@ -4,13 +4,17 @@ import (
 	"net/http"
 
 	"github.com/louisevanderlith/droxolite/context"
-	"github.com/louisevanderlith/husk"
 	"github.com/louisevanderlith/vin/core"
 )
 
 type Admin struct {
 }
 
+type attributeUpdate struct {
+	Version    int
+	Attributes map[string]string
+}
+
 func (x *Admin) Get(ctx context.Requester) (int, interface{}) {
 	results := core.GetAllVINS(1, 10)
 
@@ -20,7 +24,7 @@ func (x *Admin) Get(ctx context.Requester) (int, interface{}) {
 // /v1/vin/:key
 func (req *Admin) View(ctx context.Requester) (int, interface{}) {
 	k := ctx.FindParam("key")
-	key, err := husk.ParseKey(k)
+	key, err := core.ParseKey(k)
 
 	if err != nil {
 		return http.StatusBadRequest, err
@@ -36,9 +40,69 @@ func (req *Admin) View(ctx context.Requester) (int, interface{}) {
 }
 
 // @router /all/:pagesize [get]
+// ?sort=created|vin|year|manufacturer|updated|decoded&order=asc|desc
 func (req *Admin) Search(ctx context.Requester) (int, interface{}) {
 	page, size := ctx.GetPageData()
-	results := core.GetAllVINS(page, size)
+	sortBy, err := core.ParseSortBy(ctx.FindQueryParam("sort"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	descending, err := core.ParseSortOrder(ctx.FindQueryParam("order"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	results := core.SortedVINS(page, size, sortBy, descending)
+
+	return http.StatusOK, results
+}
+
+// @Title Set Attributes
+// @Description Replaces the custom attributes of a VIN record
+// @router /v1/vin/ [put]
+func (req *Admin) Update(ctx context.Requester) (int, interface{}) {
+	body := attributeUpdate{}
+	key, err := ctx.GetKeyedRequest(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.SetAttributes(core.KeyOf(key), body.Version, body.Attributes)
+
+	if err == core.ErrVersionConflict {
+		return http.StatusConflict, err
+	}
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title Find by Attribute
+// @Description Gets the VINs which have the attribute set to the value
+// @router /attribute/:name/:value [get]
+func FindByAttribute(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+	results := core.FindByAttribute(ctx.FindParam("name"), ctx.FindParam("value"), page, size)
 
 	return http.StatusOK, results
 }
+
+// @Title Find by VIN
+// @Description Gets the stored VIN, without decoding it
+// @router /stored/:vin [get]
+func FindByVIN(ctx context.Requester) (int, interface{}) {
+	result, err := core.GetByFullVIN(ctx.FindParam("vin"))
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, result
+}
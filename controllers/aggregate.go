@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Aggregate
+// @Description Counts the stored VINs per manufacturer, year, country, body or fuel. Filter with ?manufacturer=, ?country=, ?from= and ?to= years
+// @Success 200 {[]core.Group} []core.Group
+// @router /aggregate/:dimension [get]
+func Aggregate(ctx context.Requester) (int, interface{}) {
+	dimension, err := core.ParseDimension(ctx.FindParam("dimension"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	filter := core.Where()
+
+	if v := ctx.FindQueryParam("manufacturer"); len(v) > 0 {
+		filter = filter.Manufacturer(v)
+	}
+
+	if v := ctx.FindQueryParam("country"); len(v) > 0 {
+		filter = filter.Country(v)
+	}
+
+	from, to := 0, 9999
+
+	if v := ctx.FindQueryParam("from"); len(v) > 0 {
+		from, err = strconv.Atoi(v)
+
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+	}
+
+	if v := ctx.FindQueryParam("to"); len(v) > 0 {
+		to, err = strconv.Atoi(v)
+
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+	}
+
+	if from != 0 || to != 9999 {
+		filter = filter.YearBetween(from, to)
+	}
+
+	return http.StatusOK, core.Aggregate(dimension, filter)
+}
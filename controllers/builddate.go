@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type buildDateResult struct {
+	VIN   *core.VIN
+	Built core.BuildDate
+}
+
+// @Title Estimate Build Date
+// @Description Decodes the VIN and estimates the month it was built from its serial number
+// @Success 200 {buildDateResult} buildDateResult
+// @router /built/:vin [get]
+func BuildDate(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	built, err := core.EstimateBuildDate(*obj)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, buildDateResult{VIN: obj, Built: built}
+}
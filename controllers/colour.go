@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/colourcodes"
+)
+
+// @Title Paint Colour
+// @Description Gets the colour of a manufacturer's paint code, as printed on the paint code plate
+// @Success 200 {colourcodes.Colour} colourcodes.Colour
+// @router /colour/:make/:code [get]
+func Colour(ctx context.Requester) (int, interface{}) {
+	obj, err := colourcodes.Lookup(ctx.FindParam("make"), ctx.FindParam("code"))
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, obj
+}
+
+// @Title Paint Colours
+// @Description Lists the known paint codes of a manufacturer
+// @Success 200 {[]colourcodes.Colour} []colourcodes.Colour
+// @router /colour/:make [get]
+func Colours(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, colourcodes.ForManufacturer(ctx.FindParam("make"))
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Compare VINs
+// @Description Decodes both VINs and explains which segments differ, ie. another plant, year or sequential serials
+// @Success 200 {core.Diff} core.Diff
+// @router /compare/:vinA/:vinB [get]
+func Compare(ctx context.Requester) (int, interface{}) {
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	return http.StatusOK, core.Compare(ctx.FindParam("vinA"), ctx.FindParam("vinB"))
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/compliance"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Check Emission Zone
+// @Description Decodes the VIN and returns whether it's allowed, charged or banned in the zone
+// @Success 200 {compliance.Result} compliance.Result
+// @router /compliance/:zone/:vin [get]
+func CheckZone(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	obj, err := core.GetByFullVIN(vin)
+
+	if err != nil {
+		if status, err := chargeDecode(ctx); err != nil {
+			return status, err
+		}
+
+		obj, err = core.BuildInfo(vin)
+
+		if err != nil {
+			log.Println("compliance", err)
+			return http.StatusInternalServerError, err
+		}
+	}
+
+	result, err := compliance.CheckZone(*obj, ctx.FindParam("zone"))
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, result
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Coverage
+// @Description Reports which manufacturers have VDS data, and the years it covers
+// @Success 200 {core.CoverageReport} core.CoverageReport
+// @router /coverage [get]
+func Coverage(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.Coverage()
+}
@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/vdskit"
+)
+
+// @Title Reload Decoder Tables
+// @Description Loads the manufacturer decoder tables of DECODERTABLES again, and returns the makes which were reloaded
+// @Success 200 {[]string} []string
+// @router /decoders/reload [post]
+func ReloadDecoders(ctx context.Requester) (int, interface{}) {
+	makes, err := vdskit.Reload()
+
+	if err != nil {
+		log.Println("reload decoders", err)
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, makes
+}
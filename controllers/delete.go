@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type deleteRequest struct {
+	core.DeleteFilter
+	DryRun bool
+}
+
+// @Title Delete Where
+// @Description Deletes the VINs matching the source, creation dates and WMI. Use DryRun to count them first
+// @Success 200 {int} int
+// @router /delete [post]
+func DeleteWhere(ctx context.Requester) (int, interface{}) {
+	body := deleteRequest{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	count, err := core.DeleteWhere(body.DeleteFilter, body.DryRun)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, count
+}
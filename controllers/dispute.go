@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type disputeFiling struct {
+	VIN      string
+	Field    string
+	Expected string
+	Comment  string
+}
+
+type disputeAssignment struct {
+	Reviewer string
+}
+
+type disputeResolution struct {
+	Fields map[string]string //Pinned with an override, leave empty when the reference data was fixed
+	Note   string
+}
+
+// @Title File Dispute
+// @Description Reports a decoded field of a VIN as wrong, for review
+// @Success 200 {husk.Recorder} husk.Recorder
+// @router /disputes [post]
+func FileDispute(ctx context.Requester) (int, interface{}) {
+	body := disputeFiling{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.ValidateVIN(body.VIN)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rec, err := core.FileDispute(body.VIN, body.Field, body.Expected, body.Comment, caller(ctx))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, rec
+}
+
+// @Title List Disputes
+// @Description Gets a page of the disputes in a state, open by default
+// @Success 200 {husk.Collection} husk.Collection
+// @router /disputes [get]
+func ListDisputes(ctx context.Requester) (int, interface{}) {
+	state := core.DisputeOpen
+
+	if name := ctx.FindQueryParam("state"); len(name) > 0 {
+		s, err := core.ParseDisputeState(name)
+
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+
+		state = s
+	}
+
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.GetDisputes(state, page, size)
+}
+
+// @Title Dispute Metrics
+// @Description Counts the disputes by state, field and resolution
+// @Success 200 {core.DisputeMetrics} core.DisputeMetrics
+// @router /disputes/metrics [get]
+func DisputeMetrics(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.GetDisputeMetrics()
+}
+
+// @Title Assign Dispute
+// @Description Assigns the dispute to a reviewer
+// @Success 200 {bool} bool
+// @router /disputes/:key/assign [post]
+func AssignDispute(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	body := disputeAssignment{}
+	err = ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.AssignDispute(key, body.Reviewer)
+
+	if err != nil {
+		return http.StatusConflict, err
+	}
+
+	return http.StatusOK, true
+}
+
+// @Title Resolve Dispute
+// @Description Resolves the dispute, with an override of the VIN's fields or after fixing the reference data
+// @Success 200 {bool} bool
+// @router /disputes/:key/resolve [post]
+func ResolveDispute(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	body := disputeResolution{}
+	err = ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.ResolveDispute(key, body.Fields, body.Note)
+
+	if err != nil {
+		return http.StatusConflict, err
+	}
+
+	return http.StatusOK, true
+}
+
+// @Title Reject Dispute
+// @Description Closes the dispute without changes, when the decode was correct
+// @Success 200 {bool} bool
+// @router /disputes/:key/reject [post]
+func RejectDispute(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	body := disputeResolution{}
+	err = ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.RejectDispute(key, caller(ctx), body.Note)
+
+	if err != nil {
+		return http.StatusConflict, err
+	}
+
+	return http.StatusOK, true
+}
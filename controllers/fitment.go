@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type fitmentResult struct {
+	Key     string
+	Fitment core.FitmentKey
+}
+
+// @Title Fitment Key
+// @Description Decodes the VIN and returns its ACES fitment attributes, to query aftermarket parts catalogs
+// @Success 200 {fitmentResult} fitmentResult
+// @router /fitment/:vin [get]
+func Fitment(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	key := obj.Fitment()
+
+	return http.StatusOK, fitmentResult{Key: key.String(), Fitment: key}
+}
@@ -0,0 +1,22 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/framecode"
+)
+
+// @Title Frame Number
+// @Description Gets the details of a Japanese domestic market frame number
+// @Success 200 {framecode.FrameNumber} framecode.FrameNumber
+// @router /framecode/:frame [get]
+func FrameCode(ctx context.Requester) (int, interface{}) {
+	obj, err := framecode.Parse(ctx.FindParam("frame"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, obj
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/graph"
+)
+
+type graphRequest struct {
+	Query string `json:"query"`
+}
+
+// @Title GraphQL
+// @Description Resolves a query against the vehicle graph, fields which fail are null and listed in the errors. Every lookup is charged as a decode.
+// @Success 200 {graph.Response} graph.Response
+// @router /graph [post]
+func Graph(ctx context.Requester) (int, interface{}) {
+	req := graphRequest{}
+	err := ctx.Body(&req)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	result := graph.Execute(req.Query, func() error {
+		return core.ChargeDecode(caller(ctx))
+	})
+
+	//The query couldn't be parsed
+	if result.Data == nil {
+		return http.StatusBadRequest, result
+	}
+
+	return http.StatusOK, result
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	stdctx "context"
+	"net/http"
+	"time"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Health
+// @Description Reports whether the store, reference data and external services are available
+// @Success 200 {core.HealthReport} core.HealthReport
+// @router /health [get]
+func Health(ctx context.Requester) (int, interface{}) {
+	c, cancel := stdctx.WithTimeout(stdctx.Background(), 5*time.Second)
+	defer cancel()
+
+	report := core.Health(c)
+
+	if !report.Healthy {
+		return http.StatusServiceUnavailable, report
+	}
+
+	return http.StatusOK, report
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/hin"
+)
+
+// @Title Hull Identification Number
+// @Description Validates and decodes the HIN of a boat, and stores it
+// @Success 200 {core.Hull} core.Hull
+// @router /hin/:hin [get]
+func Hull(ctx context.Requester) (int, interface{}) {
+	full := ctx.FindParam("hin")
+	err := hin.Validate(full)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	source := ctx.FindQueryParam("source")
+
+	if len(source) == 0 {
+		source = "api"
+	}
+
+	rec, err := core.CreateHull(full, source)
+
+	if err != nil {
+		log.Println("create hull", err)
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, rec
+}
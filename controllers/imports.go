@@ -0,0 +1,312 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type wmiImport struct {
+	Items []core.StagedManufacturer
+	core.ImportOptions
+}
+
+type vdsImport struct {
+	Items []core.VDSRecord
+	core.ImportOptions
+}
+
+type vinImport struct {
+	VINs   []string
+	Source string
+}
+
+type vinUpsert struct {
+	Source string
+	Items  []struct {
+		ExternalID string
+		VIN        string
+	}
+}
+
+type serialImport struct {
+	Items []core.SerialRange
+	core.ImportOptions
+}
+
+type approvalImport struct {
+	Items []core.TypeApproval
+	core.ImportOptions
+}
+
+type warrantyImport struct {
+	Items []core.WarrantyPolicy
+	core.ImportOptions
+}
+
+type serviceImport struct {
+	Items []core.ServiceInterval
+	core.ImportOptions
+}
+
+type wheelImport struct {
+	Items []core.WheelSpec
+	core.ImportOptions
+}
+
+type importRecalls struct {
+	Items []core.RecallRange
+	core.ImportOptions
+}
+
+// @Title Import WMI
+// @Description Imports manufacturers into the reference data, use DryRun to preview the changes, 409 when a region changed during the import
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/wmi [post]
+func ImportWMI(ctx context.Requester) (int, interface{}) {
+	body := wmiImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportWMI(body.Items, body.ImportOptions)
+
+	if err == core.ErrVersionConflict {
+		return http.StatusConflict, err
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import VDS
+// @Description Upserts VDS records, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/vds [post]
+func ImportVDS(ctx context.Requester) (int, interface{}) {
+	body := vdsImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.BulkUpsertVDS(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Serial Ranges
+// @Description Adds published serial ranges to the registry, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/serials [post]
+func ImportSerials(ctx context.Requester) (int, interface{}) {
+	body := serialImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportSerialRanges(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import VINs
+// @Description Decodes the VINs and creates them in one batch, VINs which can't be decoded are reported as conflicts
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/vins [post]
+func ImportVINs(ctx context.Requester) (int, interface{}) {
+	body := vinImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if len(body.Source) == 0 {
+		body.Source = "import"
+	}
+
+	diff := core.ImportDiff{}
+	var vins []core.VIN
+
+	for _, full := range body.VINs {
+		obj, err := core.BuildInfo(full)
+
+		if err != nil {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", full, err))
+			continue
+		}
+
+		obj.Source = body.Source
+		vins = append(vins, *obj)
+		diff.Adds = append(diff.Adds, full)
+	}
+
+	_, err = core.CreateBatch(vins)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Type-Approvals
+// @Description Adds EU type-approvals of a series to the registry, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/typeapprovals [post]
+func ImportTypeApprovals(ctx context.Requester) (int, interface{}) {
+	body := approvalImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportTypeApprovals(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Warranty Policies
+// @Description Adds the factory warranty policies of manufacturers, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/warranty [post]
+func ImportWarranty(ctx context.Requester) (int, interface{}) {
+	body := warrantyImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportWarrantyPolicies(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Service Intervals
+// @Description Adds maintenance intervals of a manufacturer, series or engine, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/service [post]
+func ImportService(ctx context.Requester) (int, interface{}) {
+	body := serviceImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportServiceIntervals(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Wheel Specs
+// @Description Adds OE tire sizes and wheel fitments of a series or trim, use DryRun to preview the changes
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/wheels [post]
+func ImportWheels(ctx context.Requester) (int, interface{}) {
+	body := wheelImport{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportWheelSpecs(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Import Recall Ranges
+// @Description Adds the serial ranges of recall campaigns, per WMI, plant and model year
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /import/recalls [post]
+func ImportRecalls(ctx context.Requester) (int, interface{}) {
+	body := importRecalls{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	diff, err := core.ImportRecallRanges(body.Items, body.ImportOptions)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, diff
+}
+
+// @Title Upsert VINs
+// @Description Stores the VINs under the partner's external IDs, VINs of IDs which were delivered before are updated instead of duplicated
+// @Success 200 {core.ImportDiff} core.ImportDiff
+// @router /upsert/vins [post]
+func UpsertVINs(ctx context.Requester) (int, interface{}) {
+	body := vinUpsert{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if len(body.Source) == 0 {
+		return http.StatusBadRequest, errors.New("source is required")
+	}
+
+	diff := core.ImportDiff{}
+
+	for _, item := range body.Items {
+		_, created, err := core.UpsertByExternalID(body.Source, item.ExternalID, item.VIN)
+
+		if err != nil {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item.ExternalID, err))
+			continue
+		}
+
+		if created {
+			diff.Adds = append(diff.Adds, item.ExternalID)
+		} else {
+			diff.Updates = append(diff.Updates, item.ExternalID)
+		}
+	}
+
+	return http.StatusOK, diff
+}
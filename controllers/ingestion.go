@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Ingestion Statistics
+// @Description Gets the number of VINs created per UTC day per source, defaults to the last 30 days, ranges of more than 366 days are rejected
+// @Success 200 {[]core.IngestionDay} []core.IngestionDay
+// @router /ingestion?from=2006-01-02&to=2006-01-02 [get]
+func Ingestion(ctx context.Requester) (int, interface{}) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -30)
+
+	if v := ctx.FindQueryParam("from"); len(v) > 0 {
+		t, err := time.Parse("2006-01-02", v)
+
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+
+		from = t
+	}
+
+	if v := ctx.FindQueryParam("to"); len(v) > 0 {
+		t, err := time.Parse("2006-01-02", v)
+
+		if err != nil {
+			return http.StatusBadRequest, err
+		}
+
+		to = t
+	}
+
+	result, err := core.IngestionStats(from, to)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, result
+}
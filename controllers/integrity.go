@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Integrity
+// @Description Reports dangling references and conflicting years in the reference data
+// @Success 200 {core.IntegrityReport} core.IntegrityReport
+// @router /integrity [get]
+func Integrity(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.CheckIntegrity()
+}
+
+// @Title Record Integrity
+// @Description Reports VIN records which were corrupted or edited outside the service
+// @Success 200 {core.RecordIntegrityReport} core.RecordIntegrityReport
+// @router /integrity/records [get]
+func RecordIntegrity(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.VerifyIntegrity()
+}
+
+// @Title Preflight
+// @Description Reports the WMI count, VDS coverage per manufacturer, dataset version and index health, as logged on startup
+// @Success 200 {core.PreflightReport} core.PreflightReport
+// @router /preflight [get]
+func Preflight(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.Preflight()
+}
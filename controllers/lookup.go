@@ -1,32 +1,66 @@
 package controllers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
 	"github.com/louisevanderlith/droxolite/context"
 	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
 )
 
 // @Title Validate and Deserialize
-// @Description Gets the details of a VIN after validation
+// @Description Gets the details of a VIN after validation, ?chars=substitute or ?chars=accept handles I, O and Q
 // @Success 200 {[]core.Profile} []core.Portfolio]
 // @router /:vin [get]
 func Lookup(ctx context.Requester) (int, interface{}) {
 	vin := ctx.FindParam("vin")
-	err := core.ValidateVIN(vin)
+	mode, err := core.ParseMode(ctx.FindQueryParam("mode"))
 
 	if err != nil {
 		return http.StatusBadRequest, err
 	}
 
-	obj, err := core.BuildInfo(vin)
+	policy, err := core.ParseCharPolicy(ctx.FindQueryParam("chars"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	vin, warnings, err := core.ValidateVINPolicy(vin, mode, policy)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if vinmath.IsPoliceClearance(vin) {
+		return http.StatusBadRequest, errors.New("police clearance VINs can't be decoded")
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfoMode(vin, mode)
 
 	if err != nil {
 		log.Println("build", err)
 		return http.StatusInternalServerError, err
 	}
 
+	obj.Warnings = core.MergeWarnings(warnings, obj.Warnings)
+	obj.Source = ctx.FindQueryParam("source")
+
+	if len(obj.Source) == 0 {
+		obj.Source = "api"
+	}
+
+	//Partial decodes aren't stored, the next lookup may have the time to complete it
+	if obj.Partial() {
+		return http.StatusOK, obj
+	}
+
 	rec, err := obj.Create()
 
 	if err != nil {
@@ -36,3 +70,33 @@ func Lookup(ctx context.Requester) (int, interface{}) {
 
 	return http.StatusOK, rec
 }
+
+// @Title Decode Batch
+// @Description Validates and decodes a list of VINs, every VIN has its own status, error code and decode. Responds 207 when any VIN failed, ?mode= and ?chars= apply to every VIN
+// @Success 200 {[]core.DecodeResult} []core.DecodeResult
+// @Success 207 {[]core.DecodeResult} []core.DecodeResult
+// @router /decode [post]
+func DecodeBatch(ctx context.Requester) (int, interface{}) {
+	var vins []string
+	err := ctx.Body(&vins)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	mode, err := core.ParseMode(ctx.FindQueryParam("mode"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	policy, err := core.ParseCharPolicy(ctx.FindQueryParam("chars"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	result := core.DecodeBatch(caller(ctx), vins, mode, policy)
+
+	return core.BatchStatus(result), result
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type overrideUpdate struct {
+	Fields map[string]string
+	Reason string
+}
+
+// @Title List Overrides
+// @Description Gets a page of the decode overrides
+// @Success 200 {husk.Collection} husk.Collection
+// @router /overrides [get]
+func ListOverrides(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.GetAllOverrides(page, size)
+}
+
+// @Title Get Override
+// @Description Gets the fields pinned for the VIN
+// @Success 200 {core.Override} core.Override
+// @router /overrides/:vin [get]
+func GetOverride(ctx context.Requester) (int, interface{}) {
+	result, err := core.GetOverride(ctx.FindParam("vin"))
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Set Override
+// @Description Pins corrected fields for the VIN, they replace the decoded values and the stored record
+// @Success 200
+// @router /overrides/:vin [put]
+func SetOverride(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	body := overrideUpdate{}
+	err = ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.SetOverride(core.Override{Full: vin, Fields: body.Fields, Reason: body.Reason})
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title Remove Override
+// @Description Removes the override, the VIN is decoded from the reference data again
+// @Success 200
+// @router /overrides/:vin [delete]
+func RemoveOverride(ctx context.Requester) (int, interface{}) {
+	err := core.RemoveOverride(ctx.FindParam("vin"))
+
+	if err == core.ErrOverrideNotFound {
+		return http.StatusNotFound, err
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
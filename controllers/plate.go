@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Plate Lookup
+// @Description Resolves a licence plate to a VIN with the configured provider, and decodes it
+// @Success 200 {core.VIN} core.VIN
+// @router /plate/:region/:plate [get]
+func PlateLookup(ctx context.Requester) (int, interface{}) {
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.DecodePlate(ctx.FindParam("plate"), ctx.FindParam("region"))
+
+	if err == core.ErrPlateNotFound {
+		return http.StatusNotFound, err
+	}
+
+	if err != nil {
+		log.Println("plate", err)
+		return http.StatusBadGateway, err
+	}
+
+	return http.StatusOK, obj
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+//Quarantine lists the VINs which couldn't be decoded
+type Quarantine struct {
+}
+
+func (req *Quarantine) Get(ctx context.Requester) (int, interface{}) {
+	results := core.GetAllQuarantined(1, 10)
+
+	return http.StatusOK, results
+}
+
+// /v1/quarantine/:key
+func (req *Quarantine) View(ctx context.Requester) (int, interface{}) {
+	k := ctx.FindParam("key")
+	key, err := core.ParseKey(k)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rec, err := core.GetQuarantinedVIN(key)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, rec
+}
+
+// @router /all/:pagesize [get]
+func (req *Quarantine) Search(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+	results := core.GetAllQuarantined(page, size)
+
+	return http.StatusOK, results
+}
+
+// @Title Retry Quarantine
+// @Description Decodes a quarantined VIN again, and creates it when it succeeds
+// @Success 200 {husk.Recorder} husk.Recorder
+// @router /quarantine/:key/retry [post]
+func RetryQuarantine(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rec, err := core.RetryQuarantined(key)
+
+	if err != nil {
+		return http.StatusUnprocessableEntity, err
+	}
+
+	return http.StatusOK, rec
+}
+
+// @Title Resolve Quarantine
+// @Description Marks a quarantined VIN as resolved, without decoding it
+// @Success 200 {bool} bool
+// @router /quarantine/:key/resolve [post]
+func ResolveQuarantine(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.ResolveQuarantined(key)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, true
+}
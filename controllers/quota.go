@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/louisevanderlith/droxolite/bodies"
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+//publicKey is the path of the key the callers' tokens are signed with
+var publicKey string
+
+//SetPublicKey sets the key which verifies the token of the caller
+func SetPublicKey(path string) {
+	publicKey = path
+}
+
+//caller is the user the request's token was issued to, requests without a valid token share the 'anonymous' quota.
+//Headers can be set by anyone, only the signed subject of the token identifies the caller.
+func caller(ctx context.Requester) string {
+	auth, err := ctx.GetHeader("Authorization")
+
+	if err != nil || !strings.HasPrefix(auth, "Bearer ") {
+		return "anonymous"
+	}
+
+	avoc, err := bodies.GetAvoCookie(strings.TrimPrefix(auth, "Bearer "), publicKey)
+
+	if err != nil || avoc == nil {
+		return "anonymous"
+	}
+
+	return avoc.UserKey.String()
+}
+
+//chargeDecode counts a decode against the caller's quota, the status is only used when there is an error.
+func chargeDecode(ctx context.Requester) (int, error) {
+	err := core.ChargeDecode(caller(ctx))
+
+	if err == core.ErrQuotaExceeded {
+		return http.StatusTooManyRequests, err
+	}
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title Usage
+// @Description Gets the decodes of the caller for the last 30 days
+// @Success 200 {core.QuotaUsage} core.QuotaUsage
+// @router /usage [get]
+func Usage(ctx context.Requester) (int, interface{}) {
+	result, err := core.Usage(caller(ctx), 30)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Caller Usage
+// @Description Gets the decodes of a caller for the last 30 days, the caller is the user key of the token
+// @Success 200 {core.QuotaUsage} core.QuotaUsage
+// @router /usage/:caller [get]
+func CallerUsage(ctx context.Requester) (int, interface{}) {
+	result, err := core.Usage(ctx.FindParam("caller"), 30)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Set Quota
+// @Description Sets the soft and hard daily decode limits of a caller, the caller is the user key of the token
+// @Success 200 {bool} bool
+// @router /quota/:caller [post]
+func SetQuota(ctx context.Requester) (int, interface{}) {
+	limit := core.QuotaLimit{}
+	err := ctx.Body(&limit)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	core.SetQuota(ctx.FindParam("caller"), limit)
+
+	return http.StatusOK, true
+}
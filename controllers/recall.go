@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type recallResult struct {
+	VIN     *core.VIN
+	Recalls []core.RecallRange
+}
+
+// @Title Find Recalls
+// @Description Returns the recall campaigns with a serial range which contains the VIN
+// @Success 200 {recallResult} recallResult
+// @router /recall/:vin [get]
+func Recalls(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	obj, recalls, err := core.FindRecalls(vin)
+
+	if err != nil {
+		log.Println("recall", err)
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, recallResult{VIN: obj, Recalls: recalls}
+}
@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+//referenceError returns the status of an edit which failed, validation errors are returned per field.
+func referenceError(err error) (int, interface{}) {
+	switch e := err.(type) {
+	case core.ValidationError:
+		return http.StatusBadRequest, []core.FieldError(e)
+	}
+
+	switch err {
+	case core.ErrReferenceNotFound:
+		return http.StatusNotFound, err
+	case core.ErrVersionConflict:
+		return http.StatusConflict, err
+	}
+
+	return http.StatusInternalServerError, err
+}
+
+//versionParam returns ?version=, the Version of the region the record was read from
+func versionParam(ctx context.Requester) (int, error) {
+	return strconv.Atoi(ctx.FindQueryParam("version"))
+}
+
+// @Title List Manufacturers
+// @Description Gets a page of manufacturers, ?search= matches the WMI or name
+// @Success 200 {core.ReferencePage} core.ReferencePage
+// @router /reference/manufacturers [get]
+func ListManufacturers(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.ListManufacturers(ctx.FindQueryParam("search"), page, size)
+}
+
+// @Title Update Manufacturer
+// @Description Updates the name, description and vehicle type of a manufacturer, ?version= must match the region
+// @router /reference/manufacturers/:wmi [put]
+func UpdateManufacturer(ctx context.Requester) (int, interface{}) {
+	body := core.Manufacturer{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	version, err := versionParam(ctx)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.UpdateManufacturer(ctx.FindParam("wmi"), version, body)
+
+	if err != nil {
+		return referenceError(err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title List Series
+// @Description Gets a page of series, ?wmi= limits them to a manufacturer
+// @Success 200 {core.ReferencePage} core.ReferencePage
+// @router /reference/series [get]
+func ListSeries(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.ListSeries(ctx.FindQueryParam("wmi"), page, size)
+}
+
+// @Title Update Series
+// @Description Replaces a series built at the plant, ?version= must match the region
+// @router /reference/series/:wmi/:plant/:spec [put]
+func UpdateSeries(ctx context.Requester) (int, interface{}) {
+	body := core.Series{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	version, err := versionParam(ctx)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.UpdateSeries(ctx.FindParam("wmi"), ctx.FindParam("plant"), ctx.FindParam("spec"), version, body)
+
+	if err != nil {
+		return referenceError(err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title List Platforms
+// @Description Gets a page of platforms, ?wmi= limits them to a manufacturer
+// @Success 200 {core.ReferencePage} core.ReferencePage
+// @router /reference/platforms [get]
+func ListPlatforms(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.ListPlatforms(ctx.FindQueryParam("wmi"), page, size)
+}
+
+// @Title Update Platform
+// @Description Replaces the platform on every series which uses it, ?version= must match the region
+// @router /reference/platforms/:wmi/:code [put]
+func UpdatePlatform(ctx context.Requester) (int, interface{}) {
+	body := core.Platform{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	version, err := versionParam(ctx)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.UpdatePlatform(ctx.FindParam("wmi"), ctx.FindParam("code"), version, body)
+
+	if err != nil {
+		return referenceError(err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title List Bodies
+// @Description Gets a page of platform bodies, ?wmi= limits them to a manufacturer
+// @Success 200 {core.ReferencePage} core.ReferencePage
+// @router /reference/bodies [get]
+func ListBodies(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.ListBodies(ctx.FindQueryParam("wmi"), page, size)
+}
+
+// @Title Update Body
+// @Description Replaces the body of a platform, ?version= must match the region
+// @router /reference/bodies/:wmi/:platform [put]
+func UpdateBody(ctx context.Requester) (int, interface{}) {
+	body := core.Body{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	version, err := versionParam(ctx)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.UpdateBody(ctx.FindParam("wmi"), ctx.FindParam("platform"), version, body)
+
+	if err != nil {
+		return referenceError(err)
+	}
+
+	return http.StatusOK, nil
+}
+
+// @Title List VDS
+// @Description Gets a page of VDS records, ?wmi= limits them to a manufacturer
+// @Success 200 {core.ReferencePage} core.ReferencePage
+// @router /reference/vds [get]
+func ListVDS(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+
+	return http.StatusOK, core.ListVDS(ctx.FindQueryParam("wmi"), page, size)
+}
+
+// @Title Update VDS
+// @Description Replaces a VDS record, the Version must match the stored record
+// @router /reference/vds/:key [put]
+func UpdateVDS(ctx context.Requester) (int, interface{}) {
+	key, err := core.ParseKey(ctx.FindParam("key"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	body := core.VDSRecord{}
+	err = ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = core.UpdateVDS(key, body)
+
+	if err != nil {
+		return referenceError(err)
+	}
+
+	return http.StatusOK, nil
+}
@@ -0,0 +1,42 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/regdoc"
+)
+
+// @Title Registration Document
+// @Description Decodes the VIN and returns the fields of a registration document (eu, uk-v5c or za-natis)
+// @Success 200 {regdoc.Document} regdoc.Document
+// @router /document/:doc/:vin [get]
+func RegistrationDocument(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	doc, err := regdoc.Map(ctx.FindParam("doc"), *obj)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, doc
+}
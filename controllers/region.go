@@ -4,7 +4,6 @@ import (
 	"net/http"
 
 	"github.com/louisevanderlith/droxolite/context"
-	"github.com/louisevanderlith/husk"
 	"github.com/louisevanderlith/vin/core"
 )
 
@@ -20,7 +19,7 @@ func (req *Regions) Get(ctx context.Requester) (int, interface{}) {
 // /v1/region/:key
 func (req *Regions) View(ctx context.Requester) (int, interface{}) {
 	k := ctx.FindParam("key")
-	key, err := husk.ParseKey(k)
+	key, err := core.ParseKey(k)
 
 	if err != nil {
 		return http.StatusBadRequest, err
@@ -52,7 +51,11 @@ func (req *Regions) Update(ctx context.Requester) (int, interface{}) {
 		return http.StatusBadRequest, err
 	}
 
-	err = body.Update(key)
+	err = body.Update(core.KeyOf(key))
+
+	if err == core.ErrVersionConflict {
+		return http.StatusConflict, err
+	}
 
 	if err != nil {
 		return http.StatusNotFound, err
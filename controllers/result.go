@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+// @Title Parsed and Decoded
+// @Description Decodes the VIN and returns what was parsed from the identifier apart from what was found in the reference data, ?mode= and ?chars= work like a lookup
+// @Success 200 {core.Result} core.Result
+// @router /result/:vin [get]
+func Result(ctx context.Requester) (int, interface{}) {
+	mode, err := core.ParseMode(ctx.FindQueryParam("mode"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	policy, err := core.ParseCharPolicy(ctx.FindQueryParam("chars"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	vin, warnings, err := core.ValidateVINPolicy(ctx.FindParam("vin"), mode, policy)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if vinmath.IsPoliceClearance(vin) {
+		return http.StatusBadRequest, errors.New("police clearance VINs can't be decoded")
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfoMode(vin, mode)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	obj.Warnings = core.MergeWarnings(warnings, obj.Warnings)
+
+	return http.StatusOK, obj.Result()
+}
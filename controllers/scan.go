@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type scanRequest struct {
+	Payload string
+}
+
+// @Title From Scan
+// @Description Extracts and validates the VIN from a barcode payload
+// @Success 200 {string} string
+// @router /scan [post]
+func FromScan(ctx context.Requester) (int, interface{}) {
+	body := scanRequest{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	vin, err := core.FromScan(body.Payload)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, vin
+}
+
+// @Title Rank Candidates
+// @Description Scores the OCR readings of a VIN, the most likely VIN is first
+// @Success 200 {[]core.Candidate} []core.Candidate
+// @router /rank [post]
+func RankCandidates(ctx context.Requester) (int, interface{}) {
+	var candidates []string
+	err := ctx.Body(&candidates)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, core.RankCandidates(candidates)
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type serviceResult struct {
+	VIN      *core.VIN
+	Schedule []core.ServiceInterval
+}
+
+// @Title Service Schedule
+// @Description Decodes the VIN and returns the maintenance intervals of its engine and series
+// @Success 200 {serviceResult} serviceResult
+// @router /service/:vin [get]
+func ServiceSchedule(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, serviceResult{VIN: obj, Schedule: core.ServiceSchedule(*obj)}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+//Staging lists the manufacturers found by the WMI sync, which are waiting for review
+type Staging struct {
+}
+
+func (req *Staging) Get(ctx context.Requester) (int, interface{}) {
+	results := core.GetAllStagedManufacturers(1, 10)
+
+	return http.StatusOK, results
+}
+
+// /v1/staging/:key
+func (req *Staging) View(ctx context.Requester) (int, interface{}) {
+	k := ctx.FindParam("key")
+	key, err := core.ParseKey(k)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	rec, err := core.GetStagedManufacturer(key)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, rec
+}
+
+// @router /all/:pagesize [get]
+func (req *Staging) Search(ctx context.Requester) (int, interface{}) {
+	page, size := ctx.GetPageData()
+	results := core.GetAllStagedManufacturers(page, size)
+
+	return http.StatusOK, results
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+// @Title Statistics
+// @Description Gets the number of stored VINs by manufacturer, model year, region and body type
+// @Success 200 {core.Statistics} core.Statistics
+// @router /stats [get]
+func Stats(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.Stats()
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type syncRequest struct {
+	URL    string //Base URL of the deployment to pull from
+	Token  string
+	Since  time.Time
+	Policy string
+	DryRun bool
+}
+
+// @Title Sync From
+// @Description Pulls new VINs and changed reference records from another deployment
+// @Success 200 {core.SyncReport} core.SyncReport
+// @router /sync [post]
+func SyncFrom(ctx context.Requester) (int, interface{}) {
+	body := syncRequest{}
+	err := ctx.Body(&body)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	policy, err := core.ParseConflictPolicy(body.Policy)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	opts := core.SyncOptions{Policy: policy, DryRun: body.DryRun}
+	report, err := core.SyncFrom(core.NewRemoteStore(body.URL, body.Token), body.Since, opts)
+
+	if err != nil {
+		return http.StatusBadGateway, err
+	}
+
+	return http.StatusOK, report
+}
+
+// @Title Sync VINs
+// @Description Lists the VINs created or changed after ?since=, for another deployment to pull
+// @Success 200 {[]core.VIN} []core.VIN
+// @router /sync/vins [get]
+func SyncVINs(ctx context.Requester) (int, interface{}) {
+	since, err := time.Parse(time.RFC3339, ctx.FindQueryParam("since"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	result, err := core.Local().VINsSince(since)
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Sync Regions
+// @Description Lists every region, for another deployment to pull
+// @Success 200 {[]core.Region} []core.Region
+// @router /sync/regions [get]
+func SyncRegions(ctx context.Requester) (int, interface{}) {
+	result, err := core.Local().Regions()
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Sync VDS
+// @Description Lists every VDS record, for another deployment to pull
+// @Success 200 {[]core.VDSRecord} []core.VDSRecord
+// @router /sync/vds [get]
+func SyncVDS(ctx context.Requester) (int, interface{}) {
+	result, err := core.Local().VDSRecords()
+
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, result
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type approvalResult struct {
+	VIN       *core.VIN
+	Approvals []core.TypeApproval
+}
+
+// @Title Find Type-Approvals
+// @Description Decodes the VIN and returns the EU type-approvals (CoC references) of its series
+// @Success 200 {approvalResult} approvalResult
+// @router /typeapproval/:vin [get]
+func TypeApprovals(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	obj, approvals, err := core.FindTypeApprovals(vin)
+
+	if err != nil {
+		log.Println("typeapproval", err)
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, approvalResult{VIN: obj, Approvals: approvals}
+}
@@ -8,12 +8,24 @@ import (
 )
 
 // @Title Validate
-// @Description Attempts to validate the vin
+// @Description Attempts to validate the vin, ?mode=za selects the South African profile and ?chars= the policy for I, O and Q
 // @Success 200 {bool} bool
 // @router /:vin [get]
 func Validate(ctx context.Requester) (int, interface{}) {
 	vin := ctx.FindParam("vin")
-	err := core.ValidateVIN(vin)
+	mode, err := core.ParseMode(ctx.FindQueryParam("mode"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	policy, err := core.ParseCharPolicy(ctx.FindQueryParam("chars"))
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	_, _, err = core.ValidateVINPolicy(vin, mode, policy)
 
 	if err != nil {
 		return http.StatusBadRequest, err
@@ -21,3 +33,35 @@ func Validate(ctx context.Requester) (int, interface{}) {
 
 	return http.StatusOK, true
 }
+
+// @Title Validate Batch
+// @Description Validates a list of VINs, returning the failed rule and position for each. Responds 207 when any VIN is invalid
+// @Success 200 {[]vinmath.ValidationResult} []vinmath.ValidationResult
+// @Success 207 {[]vinmath.ValidationResult} []vinmath.ValidationResult
+// @router /validate [post]
+func ValidateBatch(ctx context.Requester) (int, interface{}) {
+	var vins []string
+	err := ctx.Body(&vins)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	result := core.ValidateBatch(vins)
+
+	for _, r := range result {
+		if !r.Valid {
+			return http.StatusMultiStatus, result
+		}
+	}
+
+	return http.StatusOK, result
+}
+
+// @Title Specification
+// @Description Returns the weights, transliteration and legal characters per position of a VIN
+// @Success 200 {vinmath.Specification} vinmath.Specification
+// @router /spec [get]
+func Spec(ctx context.Requester) (int, interface{}) {
+	return http.StatusOK, core.Spec()
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type valueResult struct {
+	VIN   *core.VIN
+	Value core.ValueEstimate
+}
+
+// @Title Estimate Value
+// @Description Decodes the VIN and returns the value bands of the configured pricing engine
+// @Success 200 {valueResult} valueResult
+// @router /value/:vin [get]
+func EstimateValue(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, est, err := core.EstimateValue(vin)
+
+	if err != nil {
+		log.Println("value", err)
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, valueResult{VIN: obj, Value: est}
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/warranty"
+)
+
+type warrantyResult struct {
+	VIN      *core.VIN
+	Warranty []warranty.Window
+}
+
+// @Title Estimate Warranty
+// @Description Decodes the VIN and returns the remaining factory warranty of every coverage of its manufacturer
+// @Success 200 {warrantyResult} warrantyResult
+// @router /warranty/:vin [get]
+func Warranty(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	windows, err := warranty.EstimateAll(*obj)
+
+	if err != nil {
+		return http.StatusNotFound, err
+	}
+
+	return http.StatusOK, warrantyResult{VIN: obj, Warranty: windows}
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/louisevanderlith/droxolite/context"
+	"github.com/louisevanderlith/vin/core"
+)
+
+type wheelResult struct {
+	VIN    *core.VIN
+	Wheels []core.WheelSpec
+}
+
+// @Title Wheel Specs
+// @Description Decodes the VIN and returns the OE tire sizes and bolt pattern of its trim or series
+// @Success 200 {wheelResult} wheelResult
+// @router /wheels/:vin [get]
+func Wheels(ctx context.Requester) (int, interface{}) {
+	vin := ctx.FindParam("vin")
+	err := core.ValidateVIN(vin)
+
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	if status, err := chargeDecode(ctx); err != nil {
+		return status, err
+	}
+
+	obj, err := core.BuildInfo(vin)
+
+	if err != nil {
+		log.Println("build", err)
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, wheelResult{VIN: obj, Wheels: core.WheelSpecs(*obj)}
+}
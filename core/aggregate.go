@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//Dimension is what Aggregate groups VINs by
+type Dimension int
+
+const (
+	ByManufacturer Dimension = iota
+	ByYear
+	ByCountry
+	ByBody
+	ByFuel
+)
+
+var dimensionNames = [...]string{
+	"manufacturer",
+	"year",
+	"country",
+	"body",
+	"fuel"}
+
+func (d Dimension) String() string {
+	return dimensionNames[d]
+}
+
+//ParseDimension returns the Dimension for the name
+func ParseDimension(name string) (Dimension, error) {
+	for i, v := range dimensionNames {
+		if strings.EqualFold(v, name) {
+			return Dimension(i), nil
+		}
+	}
+
+	return ByManufacturer, fmt.Errorf("no dimension %s", name)
+}
+
+//unknownGroup holds the VINs which don't have a value for the dimension
+const unknownGroup = "Unknown"
+
+//Group is the number of VINs with the same value for a dimension
+type Group struct {
+	Key   string
+	Count int
+}
+
+//Aggregate counts the VINs which match the filter per value of the dimension, the largest groups are first.
+func Aggregate(dimension Dimension, filter Query) []Group {
+	counts := make(map[string]int)
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+
+		if filter.matches(v) {
+			counts[dimension.value(v)]++
+		}
+	})
+
+	result := make([]Group, 0, len(counts))
+
+	for k, c := range counts {
+		result = append(result, Group{Key: k, Count: c})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+//value returns the group the VIN belongs to
+func (d Dimension) value(v *VIN) string {
+	result := ""
+
+	switch d {
+	case ByManufacturer:
+		result = v.WMInfo.Manufacturer
+	case ByYear:
+		if year := v.ModelYear(); year > 0 {
+			result = strconv.Itoa(year)
+		}
+	case ByCountry:
+		result = v.WMInfo.Country
+	case ByBody:
+		result = bodyLayout(v)
+	case ByFuel:
+		result = v.Series.Platform.Engine.FuelType
+
+		if len(result) == 0 && v.VDSInfo.EV != nil {
+			result = v.VDSInfo.EV.Powertrain
+		}
+	}
+
+	if len(result) == 0 {
+		return unknownGroup
+	}
+
+	return result
+}
+
+//bodyLayout returns the name of the body layout, so "Sedan 4 Door" and "Sedan" are counted together.
+func bodyLayout(v *VIN) string {
+	style := strings.ToLower(v.VDSInfo.BodyStyle)
+
+	for _, name := range bodyLayoutNames {
+		if strings.Contains(style, strings.ToLower(name)) || strings.EqualFold(v.Series.Platform.Body.Layout, name) {
+			return name
+		}
+	}
+
+	if len(v.VDSInfo.BodyStyle) > 0 {
+		return v.VDSInfo.BodyStyle
+	}
+
+	return v.Series.Platform.Body.Layout
+}
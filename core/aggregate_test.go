@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestDimension_Value(t *testing.T) {
+	v := &VIN{
+		Years:   []int{2016},
+		WMInfo:  WMInfo{Manufacturer: "Tesla", Country: "United States"},
+		VDSInfo: vds.VDSInfo{BodyStyle: "Sedan 4 Door", EV: &vds.EVInfo{Powertrain: "BEV"}},
+	}
+
+	expected := map[Dimension]string{
+		ByManufacturer: "Tesla",
+		ByYear:         "2016",
+		ByCountry:      "United States",
+		ByBody:         "Sedan",
+		ByFuel:         "BEV",
+	}
+
+	for d, e := range expected {
+		if actual := d.value(v); actual != e {
+			t.Errorf("%s: expected %s, got %s", d, e, actual)
+		}
+	}
+
+	if actual := ByFuel.value(&VIN{}); actual != unknownGroup {
+		t.Errorf("expected %s, got %s", unknownGroup, actual)
+	}
+}
+
+func TestParseDimension(t *testing.T) {
+	d, err := ParseDimension("Fuel")
+
+	if err != nil || d != ByFuel {
+		t.Errorf("expected fuel, got %v %v", d, err)
+	}
+
+	if _, err := ParseDimension("colour"); err == nil {
+		t.Error("expecting error")
+	}
+}
@@ -14,3 +14,28 @@ type AssemblyPlant struct {
 func (m AssemblyPlant) Valid() (bool, error) {
 	return husk.ValidateStruct(&m)
 }
+
+//builds returns true when the plant has the code of position 11, and built vehicles in the year
+func (m AssemblyPlant) builds(code string, year int) bool {
+	return m.Code == code && (m.StartYear == 0 || year == 0 || m.StartYear <= year) && (m.EndYear == 0 || year <= m.EndYear)
+}
+
+//plantCountry returns the country of the plant in position 11, from the manufacturer's assembly plants or the VDS analyzer.
+//It's empty when the plant isn't known.
+func plantCountry(v VIN) string {
+	if len(v.Unique) < 11 {
+		return ""
+	}
+
+	m, err := FindManufacturer(v.Unique)
+
+	if err == nil {
+		for _, p := range m.AssemblyPlants {
+			if p.builds(v.Unique[10:11], v.ModelYear()) && len(p.Country) > 0 {
+				return p.Country
+			}
+		}
+	}
+
+	return v.VDSInfo.PlantCountry
+}
@@ -0,0 +1,23 @@
+package core
+
+import "testing"
+
+func TestAssemblyPlant_Builds(t *testing.T) {
+	plant := AssemblyPlant{Code: "K", Country: "South Africa", StartYear: 2005, EndYear: 2012}
+
+	if !plant.builds("K", 2008) {
+		t.Error("expected K to build in 2008")
+	}
+
+	if plant.builds("K", 2015) {
+		t.Error("expected K to be closed in 2015")
+	}
+
+	if plant.builds("J", 2008) {
+		t.Error("expected J to be another plant")
+	}
+
+	if !(AssemblyPlant{Code: "K"}).builds("K", 2015) {
+		t.Error("expected plants without years to always build")
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/louisevanderlith/husk"
+)
+
+const (
+	maxAttributes     = 32
+	maxAttributeValue = 256
+)
+
+var attributeKey = regexp.MustCompile(`^[A-Za-z0-9_.\-]{1,64}$`)
+
+//validateAttributes checks that there aren't too many attributes, and that keys and values are within limits.
+func validateAttributes(attrs map[string]string) error {
+	if len(attrs) > maxAttributes {
+		return fmt.Errorf("no more than %d attributes are allowed", maxAttributes)
+	}
+
+	for k, v := range attrs {
+		if !attributeKey.MatchString(k) {
+			return fmt.Errorf("attribute key %q is invalid", k)
+		}
+
+		if len(v) > maxAttributeValue {
+			return fmt.Errorf("attribute %s is longer than %d", k, maxAttributeValue)
+		}
+	}
+
+	return nil
+}
+
+//SetAttributes replaces the custom attributes of the VIN record, version is the Version of the record that was read.
+func SetAttributes(key Key, version int, attrs map[string]string) error {
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	err = validateAttributes(attrs)
+
+	if err != nil {
+		return err
+	}
+
+	rec, err := ctx.VIN.FindByKey(key.key)
+
+	if err != nil {
+		return err
+	}
+
+	//The version is checked and written under the lock of the VIN, another writer can't pass the same check
+	unlock := vinLocks.lock(rec.Data().(*VIN).Full)
+	defer unlock()
+
+	rec, err = ctx.VIN.FindByKey(key.key)
+
+	if err != nil {
+		return err
+	}
+
+	obj := *rec.Data().(*VIN)
+	err = checkVersion(obj.Version, version)
+
+	if err != nil {
+		return err
+	}
+
+	obj.Attributes = attrs
+	obj.Version++
+	obj.seal()
+
+	err = rec.Set(obj)
+
+	if err != nil {
+		return err
+	}
+
+	defer ctx.VIN.Save()
+	return ctx.VIN.Update(rec)
+}
+
+//FindByAttribute returns the VINs which have the attribute set to value
+func FindByAttribute(name, value string, page, size int) husk.Collection {
+	return ctx.VIN.Find(page, size, byAttribute(name, value))
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestValidateAttributes_Valid(t *testing.T) {
+	err := validateAttributes(map[string]string{"stock.no": "A123", "dealer_id": "77"})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateAttributes_BadKey(t *testing.T) {
+	err := validateAttributes(map[string]string{"stock no": "A123"})
+
+	if err == nil {
+		t.Error("Expecting error")
+	}
+}
+
+func TestSetAttributes_Conflict(t *testing.T) {
+	rec, err := VIN{Full: "WVWZZZ1JZXW000001", Unique: "WVWZZZ1JZXW", Serial: 1}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := KeyOf(rec.GetKey())
+	version := rec.Data().(*VIN).Version
+	errs := make(chan error, 50)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- SetAttributes(key, version, map[string]string{"stock": "A1"})
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	written := 0
+
+	for err := range errs {
+		switch err {
+		case nil:
+			written++
+		case ErrVersionConflict:
+		default:
+			t.Fatal(err)
+		}
+	}
+
+	if written != 1 {
+		t.Errorf("expected one writer to pass the version check, got %d", written)
+	}
+}
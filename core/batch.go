@@ -0,0 +1,173 @@
+package core
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//CreateBatch creates the VINs with one write and one save of the table, instead of a write per VIN.
+//VINs which are already stored, or repeated in the batch, return the existing record like Create.
+func CreateBatch(vins []VIN) ([]husk.Recorder, error) {
+	if len(vins) == 0 {
+		return nil, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer done()
+
+	defer ctx.VIN.Save()
+
+	result := make([]husk.Recorder, len(vins))
+	created := make(map[string]husk.Recorder)
+	now := time.Now()
+
+	for i, v := range vins {
+		v.Full = vinmath.Canonical(v.Full)
+
+		if rec, ok := created[v.Full]; ok {
+			result[i] = rec
+			continue
+		}
+
+		rec, err := createLocked(v, now)
+
+		if err != nil {
+			return result, err
+		}
+
+		created[v.Full] = rec
+		result[i] = rec
+	}
+
+	return result, nil
+}
+
+//createLocked creates the VIN of the batch under its lock, so a concurrent Create of it returns the same record.
+//The VINs are locked one at a time, a batch can't deadlock with another. The caller has to hold writing.
+func createLocked(v VIN, now time.Time) (husk.Recorder, error) {
+	unlock := vinLocks.lock(v.Full)
+	defer unlock()
+
+	if rec, err := findVIN(v.Full); err == nil {
+		decodedAgain(rec, v.Source)
+		return rec, nil
+	}
+
+	v.CreatedAt = now
+	v.LastDecodedAt = now
+	v.seal()
+	cset := ctx.VIN.Create(v)
+
+	if cset.Error != nil {
+		return nil, cset.Error
+	}
+
+	stats.add(v)
+	indexVIN(v, cset.Record.GetKey())
+	raise(EventCreated, v)
+
+	return cset.Record, nil
+}
+
+//Batcher collects VINs for CreateBatch, it writes when Size VINs are waiting or every Interval.
+type Batcher struct {
+	size    int
+	mu      sync.Mutex
+	pending []VIN
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+//NewBatcher starts a Batcher, Stop has to be called to write the last VINs.
+func NewBatcher(size int, interval time.Duration) *Batcher {
+	b := &Batcher{
+		size: size,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go b.run(interval)
+
+	return b
+}
+
+func (b *Batcher) run(interval time.Duration) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			err := b.Flush()
+
+			if err != nil {
+				log.Println("batch", err)
+			}
+		}
+	}
+}
+
+//Add queues the VIN, and writes the batch when it's full.
+//Invalid VINs are refused, they would fail every batch they're queued in.
+func (b *Batcher) Add(v VIN) error {
+	ok, err := v.Valid()
+
+	if !ok {
+		return err
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, v)
+	full := len(b.pending) >= b.size
+	b.mu.Unlock()
+
+	if !full {
+		return nil
+	}
+
+	return b.Flush()
+}
+
+//Flush writes the VINs which are waiting, when the write fails they wait for the next Flush.
+func (b *Batcher) Flush() error {
+	b.mu.Lock()
+	vins := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	_, err := CreateBatch(vins)
+
+	if err != nil {
+		//VINs of the batch which were created are found by the next Flush
+		b.mu.Lock()
+		b.pending = append(vins, b.pending...)
+		b.mu.Unlock()
+	}
+
+	return err
+}
+
+//Stop writes the VINs which are waiting, and stops the interval.
+func (b *Batcher) Stop() {
+	close(b.stop)
+	<-b.done
+
+	err := b.Flush()
+
+	if err != nil {
+		log.Println("batch", err)
+	}
+}
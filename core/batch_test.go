@@ -0,0 +1,139 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+//batchVIN returns a VIN with the serial, for the batch tests
+func batchVIN(serial int) VIN {
+	return VIN{Full: fmt.Sprintf("1FTFW1ET5DF%06d", serial), Unique: "1FTFW1ET5DF", Serial: serial}
+}
+
+//waitStored returns true when the VIN is stored, it waits up to a second for the Batcher
+func waitStored(fullvin string) bool {
+	for i := 0; i < 100; i++ {
+		if _, err := findVIN(fullvin); err == nil {
+			return true
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func TestCreateBatch_Dedupe(t *testing.T) {
+	recs, err := CreateBatch([]VIN{batchVIN(1), batchVIN(2), batchVIN(1)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recs[0].GetKey() != recs[2].GetKey() || recs[0].GetKey() == recs[1].GetKey() {
+		t.Error("expected the repeated VIN to be stored once")
+	}
+}
+
+func TestBatcher_Size(t *testing.T) {
+	b := NewBatcher(2, time.Hour)
+	defer b.Stop()
+
+	err := b.Add(batchVIN(10))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := findVIN(batchVIN(10).Full); err == nil {
+		t.Fatal("expected the VIN to wait for a full batch")
+	}
+
+	err = b.Add(batchVIN(11))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := findVIN(batchVIN(10).Full); err != nil {
+		t.Error("expected the full batch to be written")
+	}
+}
+
+func TestBatcher_Interval(t *testing.T) {
+	b := NewBatcher(100, 10*time.Millisecond)
+	defer b.Stop()
+
+	err := b.Add(batchVIN(20))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !waitStored(batchVIN(20).Full) {
+		t.Error("expected the batch to be written after the interval")
+	}
+}
+
+func TestBatcher_Stop(t *testing.T) {
+	b := NewBatcher(100, time.Hour)
+	err := b.Add(batchVIN(30))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.Stop()
+
+	if _, err := findVIN(batchVIN(30).Full); err != nil {
+		t.Error("expected Stop to write the waiting VINs")
+	}
+}
+
+func TestBatcher_Requeue(t *testing.T) {
+	b := NewBatcher(100, time.Hour)
+	defer b.Stop()
+
+	err := b.Add(batchVIN(40))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lifecycle.Lock()
+	closed = true
+	lifecycle.Unlock()
+
+	err = b.Flush()
+
+	lifecycle.Lock()
+	closed = false
+	lifecycle.Unlock()
+
+	if err != ErrClosed {
+		t.Fatalf("expected the flush to fail, got %v", err)
+	}
+
+	err = b.Flush()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := findVIN(batchVIN(40).Full); err != nil {
+		t.Error("expected the failed batch to be written by the next flush")
+	}
+}
+
+func TestBatcher_Invalid(t *testing.T) {
+	b := NewBatcher(100, time.Hour)
+	defer b.Stop()
+
+	v := batchVIN(50)
+	v.Attributes = map[string]string{"not a key": "x"}
+
+	if err := b.Add(v); err == nil {
+		t.Error("expected an invalid VIN to be refused")
+	}
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+//bloomFilter answers "definitely not stored" without reading the store, it can return false positives but never false negatives.
+type bloomFilter struct {
+	sync.RWMutex
+	bits     []uint64
+	hashes   uint32
+	count    int
+	capacity int
+	//values added while the filter is rebuilt, they're added to the rebuilt filter before it's published
+	pending []string
+}
+
+//newBloomFilter sizes the filter for the expected number of items and false positive rate.
+func newBloomFilter(items int, falsePositive float64) *bloomFilter {
+	if items < 1 {
+		items = 1
+	}
+
+	m := math.Ceil(-float64(items) * math.Log(falsePositive) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/float64(items)*math.Ln2))
+
+	return &bloomFilter{
+		bits:     make([]uint64, int(m)/64+1),
+		hashes:   uint32(k),
+		capacity: items,
+	}
+}
+
+//locations are the bits of the value, using double hashing of a 64 bit FNV hash.
+func (b *bloomFilter) locations(value string) []uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	sum := h.Sum64()
+	h1, h2 := uint32(sum), uint32(sum>>32)
+	size := uint64(len(b.bits) * 64)
+
+	result := make([]uint64, b.hashes)
+
+	for i := uint32(0); i < b.hashes; i++ {
+		result[i] = uint64(h1+i*h2) % size
+	}
+
+	return result
+}
+
+func (b *bloomFilter) add(value string) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.pending != nil {
+		b.pending = append(b.pending, value)
+	}
+
+	b.set(value)
+}
+
+//set adds the value, the caller has to hold the lock
+func (b *bloomFilter) set(value string) {
+	b.count++
+
+	for _, l := range b.locations(value) {
+		b.bits[l/64] |= 1 << (l % 64)
+	}
+}
+
+//collect keeps the values which are added from now on, until a rebuilt filter is published
+func (b *bloomFilter) collect() {
+	b.Lock()
+	defer b.Unlock()
+
+	b.pending = []string{}
+}
+
+//publish replaces the bits of the filter with the rebuilt ones, after the values which were added meanwhile were set in them
+func (b *bloomFilter) publish(rebuilt *bloomFilter) {
+	b.Lock()
+	defer b.Unlock()
+
+	for _, v := range b.pending {
+		rebuilt.set(v)
+	}
+
+	b.bits = rebuilt.bits
+	b.hashes = rebuilt.hashes
+	b.count = rebuilt.count
+	b.capacity = rebuilt.capacity
+	b.pending = nil
+}
+
+//full is true once more items were added than the filter was sized for, the false positive rate rises from there.
+func (b *bloomFilter) full() bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	return b.count > b.capacity
+}
+
+//mayContain is false when the value was never added
+func (b *bloomFilter) mayContain(value string) bool {
+	b.RLock()
+	defer b.RUnlock()
+
+	for _, l := range b.locations(value) {
+		if b.bits[l/64]&(1<<(l%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBloomFilter_NoFalseNegatives(t *testing.T) {
+	b := newBloomFilter(10000, 0.01)
+
+	for i := 0; i < 10000; i++ {
+		b.add(fmt.Sprintf("WDD2050082R%06d", i))
+	}
+
+	for i := 0; i < 10000; i++ {
+		v := fmt.Sprintf("WDD2050082R%06d", i)
+
+		if !b.mayContain(v) {
+			t.Fatalf("%s was added, but isn't contained", v)
+		}
+	}
+}
+
+func TestBloomFilter_FalsePositives(t *testing.T) {
+	b := newBloomFilter(10000, 0.01)
+
+	for i := 0; i < 10000; i++ {
+		b.add(fmt.Sprintf("WDD2050082R%06d", i))
+	}
+
+	positives := 0
+
+	for i := 0; i < 10000; i++ {
+		if b.mayContain(fmt.Sprintf("WVWZZZ1KZ6W%06d", i)) {
+			positives++
+		}
+	}
+
+	if positives > 300 {
+		t.Errorf("expected around 1%% false positives, got %d of 10000", positives)
+	}
+}
+
+func TestBloomFilter_Publish(t *testing.T) {
+	b := newBloomFilter(10, 0.01)
+	b.collect()
+	rebuilt := newBloomFilter(1000, 0.01)
+	rebuilt.set("WDD2050082R000001")
+
+	//Added after the index was read, but before the rebuilt filter is published
+	b.add("WDD2050082R000002")
+	b.publish(rebuilt)
+
+	for _, v := range []string{"WDD2050082R000001", "WDD2050082R000002"} {
+		if !b.mayContain(v) {
+			t.Errorf("%s was added, but isn't contained", v)
+		}
+	}
+
+	if b.capacity != 1000 {
+		t.Errorf("expected the capacity of the rebuilt filter, got %d", b.capacity)
+	}
+}
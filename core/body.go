@@ -1,6 +1,9 @@
 package core
 
-import "github.com/louisevanderlith/husk"
+import (
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/core/vds"
+)
 
 type BodyLayout = int
 
@@ -14,6 +17,8 @@ const (
 	Convertible
 	SUV
 	Fastback
+	SingleDeckBus
+	DoubleDeckBus
 )
 
 type Body struct {
@@ -27,3 +32,12 @@ type Body struct {
 func (m Body) Valid() (bool, error) {
 	return husk.ValidateStruct(&m)
 }
+
+//busBodyStyle is the body style of a bus, from the deck when the manufacturer encodes it
+func busBodyStyle(info vds.VDSInfo) string {
+	if info.Bus == nil {
+		return vds.BusInfo{}.BodyStyle()
+	}
+
+	return info.Bus.BodyStyle()
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Decode stages, every decodeStep belongs to one
+const (
+	//StageStore are lookups in the reference data and the VIN store
+	StageStore = "store"
+	//StageRemote are steps which call other services to enrich the decode
+	StageRemote = "remote"
+	//StagePlugin are the manufacturer VDS analyzers
+	StagePlugin = "plugin"
+)
+
+//DecodeBudget bounds the time a decode may take, so the latency of the API is bounded.
+//Steps which exceed their budget are skipped, and the decode is returned with the results of the other steps.
+type DecodeBudget struct {
+	Total  time.Duration            //Limit of the whole decode, 0 is no limit
+	Stages map[string]time.Duration //Limit of each step in the stage, 0 is no limit
+}
+
+var (
+	budgetMu sync.RWMutex
+	budget   DecodeBudget
+)
+
+//SetDecodeBudget sets the budget of every following decode, the zero value removes the limits.
+func SetDecodeBudget(b DecodeBudget) {
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+
+	budget = b
+}
+
+func currentBudget() DecodeBudget {
+	budgetMu.RLock()
+	defer budgetMu.RUnlock()
+
+	return budget
+}
+
+//ParseDecodeBudget reads a budget like "total=300ms,store=50ms,plugin=100ms"
+func ParseDecodeBudget(s string) (DecodeBudget, error) {
+	result := DecodeBudget{Stages: make(map[string]time.Duration)}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		if len(part) == 0 {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+
+		if len(kv) != 2 {
+			return result, fmt.Errorf("budget %s needs a stage=duration", part)
+		}
+
+		limit, err := time.ParseDuration(strings.TrimSpace(kv[1]))
+
+		if err != nil {
+			return result, err
+		}
+
+		switch stage := strings.TrimSpace(kv[0]); stage {
+		case "total":
+			result.Total = limit
+		case StageStore, StageRemote, StagePlugin:
+			result.Stages[stage] = limit
+		default:
+			return result, fmt.Errorf("%s is not a decode stage", stage)
+		}
+	}
+
+	return result, nil
+}
+
+//Partial returns true when steps of the decode exceeded their budget
+func (m VIN) Partial() bool {
+	for _, w := range m.Warnings {
+		if w.Code == WarnPartial {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//productionStart is the month production of a model year usually starts, in the year before it
+const productionStart = time.August
+
+//BuildDate is the estimated production date of a VIN
+type BuildDate struct {
+	Year       int
+	Month      time.Month `json:",omitempty"` //Empty when only the model year is known
+	Quarter    int        `json:",omitempty"`
+	Confidence float64    //0 to 1
+	Basis      string     //What the estimate is based on
+}
+
+//EstimateBuildDate estimates the month the VIN was built, from the position of its serial in the published ranges of the plant.
+//Ranges with production dates are interpolated, otherwise production is assumed to run for twelve months from August before the model year.
+func EstimateBuildDate(v VIN) (BuildDate, error) {
+	if v.ModelYear() == 0 || len(v.Unique) < 3 {
+		return BuildDate{}, errors.New("model year is unknown")
+	}
+
+	var ranges []SerialRange
+	wmi := v.Unique[:3]
+
+	scan(ctx.Serials, func(obj husk.Dataer) {
+		r := obj.(*SerialRange)
+
+		if r.WMICode == wmi && r.Year == v.ModelYear() {
+			ranges = append(ranges, *r)
+		}
+	})
+
+	return estimateBuild(v, ranges), nil
+}
+
+//estimateBuild uses the range of the plant before ranges of every plant, dated ranges are trusted more.
+func estimateBuild(v VIN, ranges []SerialRange) BuildDate {
+	year := v.ModelYear()
+	plant := ""
+
+	if len(v.Full) == 17 {
+		plant = v.Full[10:11]
+	}
+
+	var best *SerialRange
+	bestScore := 0
+
+	for i, r := range ranges {
+		if r.Year != year || !r.Contains(v.Serial) || (len(r.PlantCode) > 0 && r.PlantCode != plant) {
+			continue
+		}
+
+		score := 1
+
+		if len(r.PlantCode) > 0 {
+			score += 2
+		}
+
+		if !r.BuiltFrom.IsZero() && !r.BuiltTo.IsZero() {
+			score += 1
+		}
+
+		if score > bestScore {
+			best, bestScore = &ranges[i], score
+		}
+	}
+
+	if best == nil {
+		return BuildDate{Year: year, Confidence: 0.2, Basis: "model year"}
+	}
+
+	from, to := best.BuiltFrom, best.BuiltTo
+	basis := "published dates"
+
+	if from.IsZero() || to.IsZero() {
+		from = time.Date(year-1, productionStart, 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(1, 0, -1)
+		basis = "serial position"
+	}
+
+	position := 0.5
+
+	if best.End > best.Start {
+		position = float64(v.Serial-best.Start) / float64(best.End-best.Start)
+	}
+
+	built := from.Add(time.Duration(position * float64(to.Sub(from))))
+
+	return BuildDate{
+		Year:       built.Year(),
+		Month:      built.Month(),
+		Quarter:    (int(built.Month())-1)/3 + 1,
+		Confidence: []float64{0, 0.4, 0.5, 0.7, 0.9}[bestScore],
+		Basis:      basis,
+	}
+}
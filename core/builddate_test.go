@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateBuild(t *testing.T) {
+	v := VIN{Full: "WBA8E9G50GNT00001", Unique: "WBA8E9G5", Years: []int{2016}, Serial: 50000}
+
+	ranges := []SerialRange{
+		{WMICode: "WBA", Year: 2016, Start: 0, End: 99999},
+		{WMICode: "WBA", PlantCode: "N", Year: 2016, Start: 0, End: 99999,
+			BuiltFrom: time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+			BuiltTo:   time.Date(2016, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{WMICode: "WBA", PlantCode: "K", Year: 2016, Start: 0, End: 99999},
+	}
+
+	est := estimateBuild(v, ranges)
+
+	if est.Year != 2016 || est.Month != time.July || est.Quarter != 3 || est.Basis != "published dates" {
+		t.Errorf("expected July 2016 from the dated range of the plant, got %+v", est)
+	}
+
+	est = estimateBuild(v, ranges[:1])
+
+	if est.Year != 2016 || est.Month != time.January || est.Quarter != 1 || est.Confidence >= 0.5 {
+		t.Errorf("expected January 2016 from the position in the model year, got %+v", est)
+	}
+
+	est = estimateBuild(v, nil)
+
+	if est.Year != 2016 || est.Month != 0 || est.Confidence != 0.2 {
+		t.Errorf("expected only the model year, got %+v", est)
+	}
+}
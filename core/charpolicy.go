@@ -0,0 +1,39 @@
+package core
+
+import "github.com/louisevanderlith/vin/vinmath"
+
+//DefaultCharPolicy is what happens to I, O and Q in requests which don't name a policy
+var DefaultCharPolicy = vinmath.RejectIllegal
+
+//ParseCharPolicy returns the character policy for the name, an empty name is the DefaultCharPolicy.
+func ParseCharPolicy(name string) (vinmath.CharPolicy, error) {
+	if len(name) == 0 {
+		return DefaultCharPolicy, nil
+	}
+
+	return vinmath.ParseCharPolicy(name)
+}
+
+//ValidateVINPolicy validates the VIN with the mode, after I, O and Q were handled by the policy.
+//It returns the VIN to decode, and the warnings to add to its decode.
+func ValidateVINPolicy(fullvin string, mode vinmath.Mode, policy vinmath.CharPolicy) (string, []Warning, error) {
+	fullvin = vinmath.Canonical(fullvin)
+
+	if !vinmath.HasIllegal(fullvin) || policy == vinmath.RejectIllegal {
+		warnings, err := ValidateVINMode(fullvin, mode)
+
+		return fullvin, warnings, err
+	}
+
+	if policy == vinmath.AcceptIllegal {
+		warnings := []Warning{newWarning(WarnLegacyChars, "%s has I, O or Q, only its length and characters were checked", fullvin)}
+
+		return fullvin, warnings, vinmath.ValidateLegacy(fullvin)
+	}
+
+	result := vinmath.Substitute(fullvin)
+	warnings := []Warning{newWarning(WarnSubstituted, "%s was read as %s", fullvin, result)}
+	tolerated, err := ValidateVINMode(result, mode)
+
+	return result, append(warnings, tolerated...), err
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func TestValidateVINPolicy(t *testing.T) {
+	in := "5npeu46f77h2591i2"
+
+	if _, _, err := ValidateVINPolicy(in, vinmath.Strict, vinmath.RejectIllegal); err == nil {
+		t.Error("expected I to be rejected")
+	}
+
+	vin, warnings, err := ValidateVINPolicy(in, vinmath.Strict, vinmath.SubstituteIllegal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin != "5NPEU46F77H259112" || len(warnings) != 1 || warnings[0].Code != WarnSubstituted {
+		t.Errorf("unexpected %s %v", vin, warnings)
+	}
+
+	vin, warnings, err = ValidateVINPolicy(in, vinmath.Strict, vinmath.AcceptIllegal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin != "5NPEU46F77H2591I2" || len(warnings) != 1 || warnings[0].Code != WarnLegacyChars {
+		t.Errorf("unexpected %s %v", vin, warnings)
+	}
+
+	if _, warnings, _ := ValidateVINPolicy("5NPEU46F77H259112", vinmath.Strict, vinmath.SubstituteIllegal); len(warnings) > 0 {
+		t.Errorf("expected no warnings without I, O or Q, got %v", warnings)
+	}
+}
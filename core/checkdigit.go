@@ -0,0 +1,66 @@
+package core
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+var (
+	exemptMu sync.RWMutex
+	exempt   = make(map[string]bool)
+)
+
+//SetCheckDigitExempt replaces the WMIs which are known not to use a check digit.
+//VINs from these manufacturers pass validation with a warning when only the check digit is wrong.
+func SetCheckDigitExempt(wmis ...string) {
+	result := make(map[string]bool)
+
+	for _, wmi := range wmis {
+		wmi = strings.ToUpper(strings.TrimSpace(wmi))
+
+		if len(wmi) > 0 {
+			result[wmi] = true
+		}
+	}
+
+	exemptMu.Lock()
+	defer exemptMu.Unlock()
+
+	exempt = result
+}
+
+//CheckDigitExempt returns true when the WMI of the VIN doesn't comply with the check digit
+func CheckDigitExempt(fullvin string) bool {
+	if len(fullvin) < 3 {
+		return false
+	}
+
+	exemptMu.RLock()
+	defer exemptMu.RUnlock()
+
+	return exempt[strings.ToUpper(fullvin[:3])]
+}
+
+//tolerateCheckDigit clears the error when it's only the check digit of an exempt WMI, or of a region which doesn't enforce it. See CheckDigitRuleFor
+//The warning says why the check digit was tolerated.
+func tolerateCheckDigit(fullvin string, err error) ([]Warning, error) {
+	if _, ok := err.(vinmath.CheckDigitError); !ok {
+		return nil, err
+	}
+
+	if CheckDigitExempt(fullvin) {
+		return []Warning{checkDigitWarning(fullvin, "WMI "+fullvin[:3]+" is exempt")}, nil
+	}
+
+	if rule := CheckDigitRuleFor(fullvin); !rule.Required {
+		return []Warning{checkDigitWarning(fullvin, rule.Scheme+" doesn't enforce it")}, nil
+	}
+
+	return nil, err
+}
+
+func checkDigitWarning(fullvin, reason string) Warning {
+	return newWarning(WarnCheckDigit, "check digit %s should be %s, %s", fullvin[8:9], vinmath.CheckDigit(fullvin), reason)
+}
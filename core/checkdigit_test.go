@@ -0,0 +1,101 @@
+package core
+
+import "testing"
+
+func TestTolerateCheckDigit(t *testing.T) {
+	SetCheckDigitExempt("wdd")
+	defer SetCheckDigitExempt()
+
+	if err := ValidateVIN("WDD2050082R123456"); err != nil {
+		t.Errorf("exempt WMI failed validation, %s", err)
+	}
+
+	if err := ValidateVIN("WDD2050O82R123456"); err == nil {
+		t.Error("exempt WMI must still fail on illegal characters")
+	}
+
+	if err := ValidateVIN("1M8GDM9A1KP042788"); err == nil {
+		t.Error("expected other WMIs to fail the check digit")
+	}
+}
+
+func TestTolerateCheckDigit_Warning(t *testing.T) {
+	SetCheckDigitExempt("wdd")
+	defer SetCheckDigitExempt()
+
+	warnings, err := ValidateVINWarnings("WDD2050082R123456")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(warnings) != 1 || warnings[0].Code != WarnCheckDigit {
+		t.Fatalf("expected a check digit warning, got %+v", warnings)
+	}
+
+	decode := []Warning{newWarning(WarnCheckDigit, "check digit 0 should be 2"), newWarning(WarnRawVDS, "no VDS analyzer")}
+
+	if merged := MergeWarnings(warnings, decode); len(merged) != 2 || merged[0] != warnings[0] || merged[1].Code != WarnRawVDS {
+		t.Errorf("expected the decode's check digit warning to be left out, got %+v", merged)
+	}
+}
+
+func TestCheckDigitRuleFor(t *testing.T) {
+	cases := map[string]string{
+		"LSGKB54H5KA000001": "GB 16735",
+		"1M8GDM9A1KP042788": "49 CFR 565",
+		"JTDKN3DU5A0000001": "ISO 3779",
+		"WDD2050082R123456": "ISO 3779",
+	}
+
+	for vin, expect := range cases {
+		if rule := CheckDigitRuleFor(vin); rule.Scheme != expect {
+			t.Errorf("%s: expected %s, got %s", vin, expect, rule.Scheme)
+		}
+	}
+
+	if err := ValidateVIN("LSGKB54H5KA000001"); err == nil {
+		t.Error("expected China to enforce the check digit")
+	}
+
+	if err := ValidateVIN("WDD2050082R123456"); err == nil {
+		t.Error("expected Europe to enforce the check digit without a scheme")
+	}
+
+	rec, err := ctx.Regions.FindFirst(byUniqueVIN("WDD"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	europe := rec.Data().(*Region)
+	err = changeRegion(KeyOf(rec.GetKey()), europe.Version, func(r *Region) error {
+		r.CheckDigit = &CheckDigitScheme{Scheme: "ECE", Required: false}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		changed, err := ctx.Regions.FindByKey(rec.GetKey())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		changeRegion(KeyOf(rec.GetKey()), changed.Data().(*Region).Version, func(r *Region) error {
+			r.CheckDigit = nil
+			return nil
+		})
+	}()
+
+	if rule := CheckDigitRuleFor("WDD2050082R123456"); rule.Scheme != "ECE" || rule.Source != "Europe" {
+		t.Errorf("expected the scheme of the region data, got %+v", rule)
+	}
+
+	if err := ValidateVIN("WDD2050082R123456"); err != nil {
+		t.Errorf("expected the optional region to pass, %s", err)
+	}
+}
@@ -0,0 +1,59 @@
+package core
+
+//CheckDigitScheme is the standard which decides whether the VINs of a region or country have to carry a valid check digit
+type CheckDigitScheme struct {
+	Scheme   string //The standard which sets the requirement, ie. GB 16735
+	Required bool
+}
+
+func (s *CheckDigitScheme) clone() *CheckDigitScheme {
+	if s == nil {
+		return nil
+	}
+
+	result := *s
+
+	return &result
+}
+
+//CheckDigitRule is the check digit requirement which applies to a WMI, and the region or country which sets it
+type CheckDigitRule struct {
+	Scheme   string
+	Required bool
+	Source   string //Name of the region or country, empty when neither sets a scheme
+}
+
+//isoRule applies to the WMIs which region and country don't set a scheme. ISO 3779 doesn't mandate the check digit, but it's enforced unless the region data makes it optional.
+var isoRule = CheckDigitRule{Scheme: "ISO 3779", Required: true}
+
+//CheckDigitRuleFor returns the rule which applies to the WMI of the VIN. The scheme of the country applies before the scheme of its region,
+//see Country.CheckDigit and Region.CheckDigit. The ISO 3779 rule applies when neither sets one, or the region can't be found.
+func CheckDigitRuleFor(fullvin string) CheckDigitRule {
+	if len(fullvin) < 2 {
+		return isoRule
+	}
+
+	region, err := GetRegionByCode(fullvin)
+
+	if err != nil {
+		return isoRule
+	}
+
+	for i := range region.Countries {
+		country := &region.Countries[i]
+
+		if country.RegionCode == fullvin[:1] && country.HasCode(fullvin[1:2]) && country.CheckDigit != nil {
+			return ruleOf(*country.CheckDigit, country.Name)
+		}
+	}
+
+	if region.CheckDigit != nil {
+		return ruleOf(*region.CheckDigit, region.Name)
+	}
+
+	return isoRule
+}
+
+func ruleOf(scheme CheckDigitScheme, source string) CheckDigitRule {
+	return CheckDigitRule{Scheme: scheme.Scheme, Required: scheme.Required, Source: source}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//RecordIntegrityReport is the result of VerifyIntegrity.
+//VINs are listed by their number, other records by their table and VIN, ie. quarantine/<VIN>.
+type RecordIntegrityReport struct {
+	Records    int
+	Unsealed   []string //Records stored before checksums, or by hand without one
+	Mismatches []string //Records which changed after they were written
+}
+
+//checksum is the hash of the record's content, without the Checksum itself
+func (m VIN) checksum() string {
+	m.Checksum = ""
+	return contentHash(m)
+}
+
+func (m QuarantinedVIN) checksum() string {
+	m.Checksum = ""
+	return contentHash(m)
+}
+
+func (m Override) checksum() string {
+	m.Checksum = ""
+	return contentHash(m)
+}
+
+func (m Dispute) checksum() string {
+	m.Checksum = ""
+	return contentHash(m)
+}
+
+//contentHash is the hash of the record as it's stored
+func contentHash(obj interface{}) string {
+	data, err := json.Marshal(obj)
+
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+//seal updates the UpdatedAt and Checksum, it has to be called before every write
+func (m *VIN) seal() {
+	m.UpdatedAt = time.Now()
+	m.Checksum = m.checksum()
+}
+
+//VerifyIntegrity recalculates the checksum of every VIN, quarantine, override and dispute record, to find records which were corrupted or edited outside the service.
+//Regions and VDS records are seeded from files and don't carry a checksum, changes to them show in the dataset version of the Preflight.
+func VerifyIntegrity() RecordIntegrityReport {
+	result := RecordIntegrityReport{}
+
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+		result.verify(v.Full, v.Checksum, v.checksum())
+	})
+
+	scan(ctx.Quarantine, func(obj husk.Dataer) {
+		q := obj.(*QuarantinedVIN)
+		result.verify("quarantine/"+q.Full, q.Checksum, q.checksum())
+	})
+
+	scan(ctx.Overrides, func(obj husk.Dataer) {
+		o := obj.(*Override)
+		result.verify("overrides/"+o.Full, o.Checksum, o.checksum())
+	})
+
+	scan(ctx.Disputes, func(obj husk.Dataer) {
+		d := obj.(*Dispute)
+		result.verify("disputes/"+d.Full, d.Checksum, d.checksum())
+	})
+
+	return result
+}
+
+//verify adds the record to the report, stored is the checksum it was written with
+func (r *RecordIntegrityReport) verify(name, stored, sum string) {
+	r.Records++
+
+	if len(stored) == 0 {
+		r.Unsealed = append(r.Unsealed, name)
+		return
+	}
+
+	if stored != sum {
+		r.Mismatches = append(r.Mismatches, name)
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestVerifyIntegrity_Related(t *testing.T) {
+	fullvin := "JHMCM56557C404455"
+	err := SetOverride(Override{Full: fullvin, Fields: map[string]string{"series": "Accord LX"}, Reason: "integrity"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := "overrides/" + fullvin
+
+	if has(VerifyIntegrity().Mismatches, name) {
+		t.Fatalf("expected the override to match its checksum")
+	}
+
+	rec, err := ctx.Overrides.FindFirst(byOverride(fullvin))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited := *rec.Data().(*Override)
+	edited.Reason = "edited by hand"
+	err = rec.Set(edited)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ctx.Overrides.Update(rec)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !has(VerifyIntegrity().Mismatches, name) {
+		t.Errorf("expected the edited override to be reported")
+	}
+}
+
+func has(names []string, name string) bool {
+	for _, v := range names {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"time"
+)
+
+const (
+	//FlagPossibleClone marks VINs which were received from different sources within the CloneWindow
+	FlagPossibleClone  = "possible-clone"
+	EventPossibleClone = "possible-clone"
+)
+
+//CloneWindow is how long after a VIN was first created, another source sending it is suspicious.
+//Zero turns the alerts off.
+var CloneWindow = 30 * 24 * time.Hour
+
+//PossibleCloneAlert is raised with the EventPossibleClone event
+type PossibleCloneAlert struct {
+	VIN         string
+	FirstSource string
+	FirstSeen   time.Time
+	Source      string
+	Seen        time.Time
+}
+
+//checkClone flags the copy of the stored VIN when the same VIN arrives from another source within the CloneWindow,
+//and returns the alert to raise once it's written. VINs are only stored once, so the stored record carries the flag for both sources.
+//A VIN which is already flagged isn't alerted again.
+func checkClone(stored *VIN, source string) *PossibleCloneAlert {
+	now := time.Now()
+
+	if CloneWindow == 0 || len(source) == 0 || stored.Source == source || stored.flagged(FlagPossibleClone) || now.Sub(stored.CreatedAt) > CloneWindow {
+		return nil
+	}
+
+	//The copy shares the Flags of the stored VIN
+	stored.Flags = append([]string(nil), stored.Flags...)
+	stored.flag(FlagPossibleClone)
+	stored.seal()
+
+	return &PossibleCloneAlert{
+		VIN:         stored.Full,
+		FirstSource: stored.Source,
+		FirstSeen:   stored.CreatedAt,
+		Source:      source,
+		Seen:        now,
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCreate_PossibleClone(t *testing.T) {
+	const fullvin = "WDD2050041F000001"
+
+	var mu sync.Mutex
+	var alerts []PossibleCloneAlert
+	Subscribe(func(e Event) {
+		if e.Type != EventPossibleClone || e.VIN.Full != fullvin {
+			return
+		}
+
+		mu.Lock()
+		alerts = append(alerts, *e.Clone)
+		mu.Unlock()
+	})
+
+	for _, source := range []string{"auction", "auction", "dealer", "insurer"} {
+		_, err := VIN{Full: fullvin, Unique: fullvin[:11], Serial: 1, Source: source}.Create()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(alerts) != 1 {
+		t.Fatalf("expected the clone to be alerted once, got %+v", alerts)
+	}
+
+	if alerts[0].FirstSource != "auction" || alerts[0].Source != "dealer" {
+		t.Errorf("expected the alert to name both sources, got %+v", alerts[0])
+	}
+
+	stored, err := GetByFullVIN(fullvin)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !stored.flagged(FlagPossibleClone) || stored.Source != "auction" {
+		t.Errorf("expected the first record to be flagged, got %+v", stored)
+	}
+}
+
+func TestCheckClone_Window(t *testing.T) {
+	window := CloneWindow
+	CloneWindow = 0
+	defer func() { CloneWindow = window }()
+
+	stored := VIN{Source: "auction"}
+
+	if alert := checkClone(&stored, "dealer"); alert != nil || len(stored.Flags) != 0 {
+		t.Errorf("expected no alerts without a window, got %+v", alert)
+	}
+}
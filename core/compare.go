@@ -0,0 +1,169 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Findings of a Compare, they describe how two VINs relate
+const (
+	FindingSameVIN               = "same-vin"
+	FindingInvalid               = "invalid"                //One of the VINs fails validation
+	FindingDifferentManufacturer = "different-manufacturer" //The WMIs differ
+	FindingDifferentSpec         = "different-spec"         //The VDS differs, the vehicles aren't the same model
+	FindingDifferentYear         = "different-year"
+	FindingDifferentPlant        = "different-plant"
+	FindingSequentialSerials     = "sequential-serials" //Consecutive vehicles of the same production run
+	FindingSameSerial            = "same-serial"        //The serial is reused with another description, common with retagged vehicles
+)
+
+//SegmentDiff is a segment which isn't the same in both VINs
+type SegmentDiff struct {
+	Segment string
+	A       string
+	B       string
+	Meaning string
+}
+
+//Diff is the result of a Compare
+type Diff struct {
+	A        string
+	B        string
+	Segments []SegmentDiff
+	Findings []string
+}
+
+//Compare decodes both VINs and explains the segments which differ, to investigate suspected cloned or retagged vehicles.
+//VINs which can't be decoded are still compared by their characters.
+func Compare(vinA, vinB string) Diff {
+	vinA = vinmath.Canonical(vinA)
+	vinB = vinmath.Canonical(vinB)
+
+	result := Diff{A: vinA, B: vinB}
+
+	if vinA == vinB {
+		result.Findings = append(result.Findings, FindingSameVIN)
+		return result
+	}
+
+	if ValidateVIN(vinA) != nil || ValidateVIN(vinB) != nil {
+		result.Findings = append(result.Findings, FindingInvalid)
+	}
+
+	if len(vinA) != 17 || len(vinB) != 17 {
+		return result
+	}
+
+	return compare(result, decodeForCompare(vinA), decodeForCompare(vinB))
+}
+
+//decodeForCompare decodes the VIN, only the segments are filled in when it can't be decoded
+func decodeForCompare(fullvin string) *VIN {
+	v, err := newVIN(fullvin)
+
+	if err == nil {
+		return v
+	}
+
+	v = &VIN{Full: fullvin}
+	v.Unique, v.Serial = vinmath.UniqueSerial(fullvin)
+	v.Years, _ = vinmath.Years(fullvin[9:10])
+
+	return v
+}
+
+//compare adds the segments which differ between the decoded VINs to the Diff
+func compare(result Diff, a, b *VIN) Diff {
+	segA := vinmath.Segment(a.Full)
+	segB := vinmath.Segment(b.Full)
+
+	if segA.WMI != segB.WMI {
+		result.Findings = append(result.Findings, FindingDifferentManufacturer)
+		result.Segments = append(result.Segments, SegmentDiff{
+			Segment: "WMI",
+			A:       segA.WMI,
+			B:       segB.WMI,
+			Meaning: fmt.Sprintf("built by %s instead of %s", describeMaker(b), describeMaker(a)),
+		})
+	}
+
+	if segA.VDS != segB.VDS {
+		meaning := "the vehicle descriptions differ"
+
+		if len(a.Series.Spec) > 0 && len(b.Series.Spec) > 0 && a.Series.Spec != b.Series.Spec {
+			meaning = fmt.Sprintf("a %s instead of a %s", b.Series.Spec, a.Series.Spec)
+		}
+
+		result.Findings = append(result.Findings, FindingDifferentSpec)
+		result.Segments = append(result.Segments, SegmentDiff{Segment: "VDS", A: segA.VDS, B: segB.VDS, Meaning: meaning})
+	}
+
+	if segA.YearCode != segB.YearCode {
+		result.Findings = append(result.Findings, FindingDifferentYear)
+		result.Segments = append(result.Segments, SegmentDiff{
+			Segment: "YearCode",
+			A:       segA.YearCode,
+			B:       segB.YearCode,
+			Meaning: fmt.Sprintf("model year %d instead of %d", b.ModelYear(), a.ModelYear()),
+		})
+	}
+
+	if segA.PlantCode != segB.PlantCode {
+		meaning := "assembled at another plant"
+
+		if len(a.VDSInfo.Plant) > 0 && len(b.VDSInfo.Plant) > 0 {
+			meaning = fmt.Sprintf("assembled in %s instead of %s", b.VDSInfo.Plant, a.VDSInfo.Plant)
+		}
+
+		result.Findings = append(result.Findings, FindingDifferentPlant)
+		result.Segments = append(result.Segments, SegmentDiff{Segment: "PlantCode", A: segA.PlantCode, B: segB.PlantCode, Meaning: meaning})
+	}
+
+	if segA.Sequence != segB.Sequence {
+		gap := b.Serial - a.Serial
+		meaning := fmt.Sprintf("%d vehicles apart", abs(gap))
+
+		//Serials are only sequential within the same production run
+		if abs(gap) == 1 && len(result.Segments) == 0 {
+			meaning = "built one after the other"
+			result.Findings = append(result.Findings, FindingSequentialSerials)
+		}
+
+		result.Segments = append(result.Segments, SegmentDiff{Segment: "Sequence", A: segA.Sequence, B: segB.Sequence, Meaning: meaning})
+	} else if len(result.Segments) > 0 {
+		result.Findings = append(result.Findings, FindingSameSerial)
+	}
+
+	if segA.CheckDigit != segB.CheckDigit {
+		result.Segments = append(result.Segments, SegmentDiff{
+			Segment: "CheckDigit",
+			A:       segA.CheckDigit,
+			B:       segB.CheckDigit,
+			Meaning: "follows from the other segments",
+		})
+	}
+
+	return result
+}
+
+//describeMaker returns the manufacturer and country of the VIN, or its WMI when it wasn't decoded
+func describeMaker(v *VIN) string {
+	if len(v.WMInfo.Manufacturer) == 0 {
+		return v.Full[:3]
+	}
+
+	if len(v.WMInfo.Country) == 0 {
+		return v.WMInfo.Manufacturer
+	}
+
+	return fmt.Sprintf("%s (%s)", v.WMInfo.Manufacturer, v.WMInfo.Country)
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func compareVIN(fullvin, manufacturer, plant string) *VIN {
+	v := &VIN{Full: fullvin}
+	v.Unique, v.Serial = vinmath.UniqueSerial(fullvin)
+	v.WMInfo.Manufacturer = manufacturer
+	v.VDSInfo.Plant = plant
+
+	return v
+}
+
+func TestCompare_Sequential(t *testing.T) {
+	a := compareVIN("5NPEU46F77H259112", "Hyundai", "Asan")
+	b := compareVIN("5NPEU46F97H259113", "Hyundai", "Asan")
+
+	diff := compare(Diff{}, a, b)
+
+	if !reflect.DeepEqual(diff.Findings, []string{FindingSequentialSerials}) {
+		t.Errorf("expected sequential serials, got %v", diff.Findings)
+	}
+
+	if len(diff.Segments) != 2 || diff.Segments[0].Segment != "Sequence" || diff.Segments[1].Segment != "CheckDigit" {
+		t.Errorf("expected the sequence and check digit to differ, got %+v", diff.Segments)
+	}
+}
+
+func TestCompare_Retagged(t *testing.T) {
+	a := compareVIN("5NPEU46F77H259112", "Hyundai", "Asan")
+	b := compareVIN("5NPEU46F7AU259112", "Hyundai", "Ulsan")
+
+	diff := compare(Diff{}, a, b)
+	expected := []string{FindingDifferentYear, FindingDifferentPlant, FindingSameSerial}
+
+	if !reflect.DeepEqual(diff.Findings, expected) {
+		t.Errorf("expected %v, got %v", expected, diff.Findings)
+	}
+
+	if diff.Segments[1].Meaning != "assembled in Ulsan instead of Asan" {
+		t.Errorf("unexpected meaning %q", diff.Segments[1].Meaning)
+	}
+}
+
+func TestCompare_SameVIN(t *testing.T) {
+	diff := Compare("5NPEU46F77H259112", " 5npeu46f77h259112")
+
+	if !reflect.DeepEqual(diff.Findings, []string{FindingSameVIN}) {
+		t.Errorf("expected the same VIN, got %v", diff.Findings)
+	}
+}
@@ -8,6 +8,8 @@ type Country struct {
 	StartChar     string
 	EndChar       string
 	Manufacturers []Manufacturer
+	//CheckDigit replaces the scheme of the region for the country, ie. GB 16735 for China
+	CheckDigit *CheckDigitScheme `json:",omitempty"`
 }
 
 func (m Country) Valid() (bool, error) {
@@ -0,0 +1,147 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//YearRange is an inclusive range of model years
+type YearRange struct {
+	Start int
+	End   int
+}
+
+//ManufacturerCoverage is how much of a manufacturer's VDS can be decoded
+type ManufacturerCoverage struct {
+	WMICode    string
+	Name       string
+	Country    string
+	HasDecoder bool        //A VDS decoder is registered for the manufacturer
+	VDSRecords int         //VDS reference records for the WMI
+	Years      []YearRange //Years covered by the VDS records and series
+	VINs       int         //Stored VINs with the WMI, shows the demand for better coverage
+}
+
+//Covered returns true when some part of the VDS can be decoded
+func (c ManufacturerCoverage) Covered() bool {
+	return c.HasDecoder || c.VDSRecords > 0
+}
+
+//CoverageReport is the result of Coverage
+type CoverageReport struct {
+	Covered       int
+	Uncovered     int
+	Manufacturers []ManufacturerCoverage
+}
+
+//Coverage summarises which manufacturers have VDS data, and the years it covers.
+//Uncovered manufacturers with the most stored VINs are listed first, as they should be sourced next.
+func Coverage() CoverageReport {
+	result := CoverageReport{}
+	records := make(map[string][]VDSRecord)
+	vins := make(map[string]int)
+
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		rec := obj.(*VDSRecord)
+		records[rec.WMICode] = append(records[rec.WMICode], *rec)
+	})
+
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		vins[obj.(*VIN).Unique[:3]]++
+	})
+
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		item := ManufacturerCoverage{
+			WMICode:    m.WMICode,
+			Name:       m.Name,
+			Country:    country.Name,
+			HasDecoder: vds.HasAnalyzer(m.Name),
+		}
+
+		var ranges []YearRange
+
+		for wmi, recs := range records {
+			if !strings.HasPrefix(wmi, m.WMICode) {
+				continue
+			}
+
+			item.VDSRecords += len(recs)
+
+			for _, r := range recs {
+				ranges = append(ranges, YearRange{r.StartYear, r.EndYear})
+			}
+		}
+
+		for _, plant := range m.AssemblyPlants {
+			for _, s := range plant.Series {
+				if s.StartYear > 0 {
+					ranges = append(ranges, YearRange{s.StartYear, s.EndYear})
+				}
+			}
+		}
+
+		item.Years = mergeYears(ranges)
+
+		for wmi, count := range vins {
+			if strings.HasPrefix(wmi, m.WMICode) {
+				item.VINs += count
+			}
+		}
+
+		if item.Covered() {
+			result.Covered++
+		} else {
+			result.Uncovered++
+		}
+
+		result.Manufacturers = append(result.Manufacturers, item)
+	})
+
+	sort.SliceStable(result.Manufacturers, func(i, j int) bool {
+		a, b := result.Manufacturers[i], result.Manufacturers[j]
+
+		if a.Covered() != b.Covered() {
+			return !a.Covered()
+		}
+
+		if a.VINs != b.VINs {
+			return a.VINs > b.VINs
+		}
+
+		return a.WMICode < b.WMICode
+	})
+
+	return result
+}
+
+//mergeYears combines overlapping and adjacent ranges
+func mergeYears(ranges []YearRange) []YearRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	result := []YearRange{ranges[0]}
+
+	for _, r := range ranges[1:] {
+		last := &result[len(result)-1]
+
+		if r.Start <= last.End+1 {
+			if r.End > last.End {
+				last.End = r.End
+			}
+
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	return result
+}
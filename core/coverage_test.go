@@ -0,0 +1,16 @@
+package core
+
+import "testing"
+
+func TestMergeYears(t *testing.T) {
+	in := []YearRange{{2015, 2018}, {2004, 2008}, {2019, 2021}, {2006, 2010}}
+	out := mergeYears(in)
+
+	if len(out) != 2 {
+		t.Fatalf("expected 2 ranges, got %v", out)
+	}
+
+	if out[0] != (YearRange{2004, 2010}) || out[1] != (YearRange{2015, 2021}) {
+		t.Errorf("unexpected ranges %v", out)
+	}
+}
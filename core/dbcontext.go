@@ -4,29 +4,55 @@ import (
 	"github.com/louisevanderlith/husk"
 )
 
-type context struct {
-	VIN     husk.Tabler
-	Regions husk.Tabler
+type dbContext struct {
+	VIN           husk.Tabler
+	Regions       husk.Tabler
+	Staging       husk.Tabler
+	VDS           husk.Tabler
+	Quarantine    husk.Tabler
+	Serials       husk.Tabler
+	TypeApprovals husk.Tabler
+	Warranties    husk.Tabler
+	Services      husk.Tabler
+	Wheels        husk.Tabler
+	Overrides     husk.Tabler
+	Disputes      husk.Tabler
+	Recalls       husk.Tabler
+	Hulls         husk.Tabler
 }
 
-var ctx context
+var ctx dbContext
+
+//seedFile has the regions which are seeded when the context is created
+var seedFile = "db/regions.seed.json"
 
 func CreateContext() {
 	defer seed()
 
-	ctx = context{
-		Regions: husk.NewTable(new(Region)),
-		VIN:     husk.NewTable(new(VIN)),
+	ctx = dbContext{
+		Regions:       husk.NewTable(new(Region)),
+		VIN:           husk.NewTable(new(VIN)),
+		Staging:       husk.NewTable(new(StagedManufacturer)),
+		VDS:           husk.NewTable(new(VDSRecord)),
+		Quarantine:    husk.NewTable(new(QuarantinedVIN)),
+		Serials:       husk.NewTable(new(SerialRange)),
+		TypeApprovals: husk.NewTable(new(TypeApproval)),
+		Warranties:    husk.NewTable(new(WarrantyPolicy)),
+		Services:      husk.NewTable(new(ServiceInterval)),
+		Wheels:        husk.NewTable(new(WheelSpec)),
+		Overrides:     husk.NewTable(new(Override)),
+		Disputes:      husk.NewTable(new(Dispute)),
+		Recalls:       husk.NewTable(new(RecallRange)),
+		Hulls:         husk.NewTable(new(Hull)),
 	}
-}
 
-func Shutdown() {
-	ctx.Regions.Save()
-	ctx.VIN.Save()
+	rebuildStats()
+	rebuildIndex()
+	referenceChanged()
 }
 
 func seed() {
-	err := ctx.Regions.Seed("db/regions.seed.json")
+	err := ctx.Regions.Seed(seedFile)
 
 	if err != nil {
 		panic(err)
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Codes of the VINs in a batch which failed, besides the vinmath rules RuleLength, RuleCharacter, RuleYearCode and RuleCheckDigit
+const (
+	CodeInvalid     = "invalid"     //The VIN failed a rule of the mode, ie. a PIN or legacy identifier
+	CodeUndecodable = "undecodable" //The VIN is valid, but couldn't be decoded
+	CodeQuota       = "quota"       //The caller reached the hard limit of decodes
+	CodeStore       = "store"       //The VIN was decoded, but couldn't be stored
+)
+
+var errPoliceClearance = errors.New("police clearance VINs can't be decoded")
+
+//DecodeResult is the outcome of one VIN in a batch, a VIN which failed doesn't fail the others
+type DecodeResult struct {
+	VIN    string
+	Status int    //HTTP status of the VIN, 200 when it was decoded
+	Code   string `json:",omitempty"` //Why the VIN failed
+	Error  string `json:",omitempty"`
+	Decode *VIN   `json:",omitempty"`
+}
+
+//OK returns true when the VIN was decoded
+func (r DecodeResult) OK() bool {
+	return r.Status == http.StatusOK
+}
+
+//DecodeBatch validates and decodes every VIN, with the mode and policy of a single lookup. The results are in the same order as the VINs.
+//Every valid VIN is charged to the caller, and the decoded VINs are stored with one write. Partial decodes aren't stored.
+func DecodeBatch(caller string, vins []string, mode vinmath.Mode, policy vinmath.CharPolicy) []DecodeResult {
+	result := make([]DecodeResult, len(vins))
+	var decoded []VIN
+	var positions []int
+
+	for i, v := range vins {
+		result[i] = DecodeResult{VIN: v}
+		fullvin, warnings, err := ValidateVINPolicy(v, mode, policy)
+
+		if err != nil {
+			result[i].fail(http.StatusBadRequest, failedRule(v, err), err)
+			continue
+		}
+
+		if vinmath.IsPoliceClearance(fullvin) {
+			result[i].fail(http.StatusBadRequest, CodeInvalid, errPoliceClearance)
+			continue
+		}
+
+		err = ChargeDecode(caller)
+
+		if err == ErrQuotaExceeded {
+			result[i].fail(http.StatusTooManyRequests, CodeQuota, err)
+			continue
+		}
+
+		if err != nil {
+			result[i].fail(http.StatusInternalServerError, CodeQuota, err)
+			continue
+		}
+
+		obj, err := BuildInfoMode(fullvin, mode)
+
+		if err != nil {
+			result[i].fail(http.StatusUnprocessableEntity, CodeUndecodable, err)
+			continue
+		}
+
+		obj.Warnings = MergeWarnings(warnings, obj.Warnings)
+		obj.Source = "batch"
+		result[i].Status = http.StatusOK
+		result[i].Decode = obj
+
+		if !obj.Partial() {
+			decoded = append(decoded, *obj)
+			positions = append(positions, i)
+		}
+	}
+
+	recs, err := CreateBatch(decoded)
+
+	for k, i := range positions {
+		if k < len(recs) && recs[k] != nil {
+			result[i].Decode = recs[k].Data().(*VIN)
+		} else if err != nil {
+			result[i].Decode = nil
+			result[i].fail(http.StatusInternalServerError, CodeStore, err)
+		}
+	}
+
+	return result
+}
+
+//BatchStatus returns http.StatusOK when every VIN was decoded, and http.StatusMultiStatus when the results are mixed or all failed
+func BatchStatus(results []DecodeResult) int {
+	for _, r := range results {
+		if !r.OK() {
+			return http.StatusMultiStatus
+		}
+	}
+
+	return http.StatusOK
+}
+
+func (r *DecodeResult) fail(status int, code string, err error) {
+	r.Status = status
+	r.Code = code
+	r.Error = err.Error()
+}
+
+//failedRule returns the vinmath rule the VIN failed, CodeInvalid when it failed a rule of the mode
+func failedRule(fullvin string, err error) string {
+	if _, ok := err.(vinmath.CheckDigitError); ok {
+		return vinmath.RuleCheckDigit
+	}
+
+	diagnosis := vinmath.ValidateBatch([]string{fullvin})[0]
+
+	if len(diagnosis.Rule) > 0 && diagnosis.Rule != vinmath.RuleCheckDigit {
+		return diagnosis.Rule
+	}
+
+	return CodeInvalid
+}
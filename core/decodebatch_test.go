@@ -0,0 +1,47 @@
+package core
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	vins := []string{"5NPEU46F77H259112", "5NBEU46F77H259112", "5NPEU46F77H2591", "SAPS0000000012345"}
+	result := DecodeBatch("batch-test", vins, vinmath.Strict, vinmath.RejectIllegal)
+
+	expect := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusOK, ""},
+		{http.StatusBadRequest, vinmath.RuleCheckDigit},
+		{http.StatusBadRequest, vinmath.RuleLength},
+		{http.StatusBadRequest, vinmath.RuleYearCode},
+	}
+
+	for i, e := range expect {
+		if result[i].Status != e.status || result[i].Code != e.code {
+			t.Errorf("%s: expected %d %s, got %d %s", vins[i], e.status, e.code, result[i].Status, result[i].Code)
+		}
+	}
+
+	if result[0].Decode == nil || result[0].Decode.WMInfo.Manufacturer != "Hyundai" {
+		t.Errorf("expected the decode of %s, got %+v", vins[0], result[0].Decode)
+	}
+
+	if BatchStatus(result) != http.StatusMultiStatus {
+		t.Error("expected a mixed batch to be 207")
+	}
+
+	if BatchStatus(result[:1]) != http.StatusOK {
+		t.Error("expected a successful batch to be 200")
+	}
+
+	za := DecodeBatch("batch-test", vins[3:], vinmath.SouthAfrica, vinmath.RejectIllegal)
+
+	if za[0].Status != http.StatusBadRequest || za[0].Code != CodeInvalid {
+		t.Errorf("expected police clearance VINs to be invalid, got %+v", za[0])
+	}
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//DeleteFilter selects the VINs removed by DeleteWhere, empty fields match everything.
+type DeleteFilter struct {
+	Source string
+	From   time.Time //Created at or after
+	To     time.Time //Created before
+	WMI    string
+}
+
+func (f DeleteFilter) empty() bool {
+	return len(f.Source) == 0 && f.From.IsZero() && f.To.IsZero() && len(f.WMI) == 0
+}
+
+func (f DeleteFilter) matches(v *VIN) bool {
+	if len(f.Source) > 0 && v.Source != f.Source {
+		return false
+	}
+
+	if !f.From.IsZero() && v.CreatedAt.Before(f.From) {
+		return false
+	}
+
+	if !f.To.IsZero() && !v.CreatedAt.Before(f.To) {
+		return false
+	}
+
+	return len(f.WMI) == 0 || strings.HasPrefix(v.Full, strings.ToUpper(f.WMI))
+}
+
+//DeleteWhere removes the VINs which match the filter, and returns how many there were.
+//When dryRun is set, the VINs are only counted. A filter without criteria is refused.
+func DeleteWhere(filter DeleteFilter, dryRun bool) (int, error) {
+	if filter.empty() {
+		return 0, errors.New("filter needs at least one criteria")
+	}
+
+	//The VINs are collected under the lock, so those created or changed meanwhile aren't missed or deleted by mistake
+	done, err := writing()
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer done()
+
+	var keys []husk.Key
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+
+		if !filter.matches(v) {
+			return
+		}
+
+		rec, err := findVIN(v.id())
+
+		if err == nil {
+			keys = append(keys, rec.GetKey())
+		}
+	})
+
+	if dryRun || len(keys) == 0 {
+		return len(keys), nil
+	}
+
+	defer ctx.VIN.Save()
+	defer rebuildStats()
+	defer rebuildIndex()
+
+	for i, k := range keys {
+		err := ctx.VIN.Delete(k)
+
+		if err != nil {
+			return i, err
+		}
+	}
+
+	return len(keys), nil
+}
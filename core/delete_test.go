@@ -0,0 +1,62 @@
+package core
+
+import "testing"
+
+func TestDeleteWhere_EmptyFilter(t *testing.T) {
+	_, err := DeleteWhere(DeleteFilter{}, true)
+
+	if err == nil {
+		t.Error("expected a filter without criteria to be refused")
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	vins := []VIN{
+		{Full: "2HGFC2F59LH000001", Unique: "2HGFC2F59LH", Serial: 1, Source: "delete-test"},
+		{Full: "2HGFC2F59LH000002", Unique: "2HGFC2F59LH", Serial: 2, Source: "delete-test"},
+	}
+
+	for _, v := range vins {
+		_, err := v.Create()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	total := Stats().Total
+	filter := DeleteFilter{Source: "delete-test"}
+	count, err := DeleteWhere(filter, true)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != len(vins) {
+		t.Errorf("expected %d VINs to be counted, got %d", len(vins), count)
+	}
+
+	if _, err := findVIN(vins[0].Full); err != nil {
+		t.Error("expected a dry run to keep the VINs")
+	}
+
+	count, err = DeleteWhere(filter, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != len(vins) {
+		t.Errorf("expected %d VINs to be deleted, got %d", len(vins), count)
+	}
+
+	for _, v := range vins {
+		if _, err := findVIN(v.Full); err != ErrVINNotFound {
+			t.Errorf("expected %s to be removed from the index, got %v", v.Full, err)
+		}
+	}
+
+	if Stats().Total != total-len(vins) {
+		t.Errorf("expected the statistics to count %d VINs, got %d", total-len(vins), Stats().Total)
+	}
+}
@@ -0,0 +1,306 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//DisputeState is where a Dispute is in the review workflow
+type DisputeState int
+
+const (
+	DisputeOpen DisputeState = iota
+	DisputeAssigned
+	DisputeResolved
+	DisputeRejected
+)
+
+var disputeStateNames = [...]string{
+	"open",
+	"assigned",
+	"resolved",
+	"rejected"}
+
+func (s DisputeState) String() string {
+	return disputeStateNames[s]
+}
+
+//ParseDisputeState returns the DisputeState for the name
+func ParseDisputeState(name string) (DisputeState, error) {
+	for i, v := range disputeStateNames {
+		if strings.EqualFold(v, name) {
+			return DisputeState(i), nil
+		}
+	}
+
+	return DisputeOpen, fmt.Errorf("%s is not a dispute state", name)
+}
+
+//disputeTransitions are the states each state can move to
+var disputeTransitions = map[DisputeState][]DisputeState{
+	DisputeOpen:     {DisputeAssigned, DisputeRejected},
+	DisputeAssigned: {DisputeAssigned, DisputeResolved, DisputeRejected},
+}
+
+const (
+	//ResolvedByOverride is the Resolution of disputes fixed with an Override of the VIN
+	ResolvedByOverride = "override"
+	//ResolvedByReference is the Resolution of disputes fixed in the reference data
+	ResolvedByReference = "reference-data"
+)
+
+//DisputeEvent is a change of a Dispute's state
+type DisputeEvent struct {
+	State DisputeState
+	By    string
+	Note  string
+	At    time.Time
+}
+
+//Dispute is a decode which an end user reported as wrong
+type Dispute struct {
+	Full       string `hsk:"size(17)"`
+	Field      string //The field which is wrong, ie. series
+	Expected   string //What the reporter expected the field to be
+	Comment    string
+	Reporter   string
+	Reviewer   string
+	State      DisputeState
+	Resolution string //ResolvedByOverride or ResolvedByReference
+	History    []DisputeEvent
+	CreatedAt  time.Time
+	ClosedAt   time.Time
+	Checksum   string //Hash of the content, see VerifyIntegrity
+}
+
+func (m Dispute) Valid() (bool, error) {
+	if len(m.Field) == 0 {
+		return false, errors.New("dispute needs the field which is wrong")
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+//transition moves the dispute to the state, when the workflow allows it
+func (m *Dispute) transition(to DisputeState, by, note string, at time.Time) error {
+	allowed := false
+
+	for _, s := range disputeTransitions[m.State] {
+		if s == to {
+			allowed = true
+		}
+	}
+
+	if !allowed {
+		return fmt.Errorf("a %s dispute can't be %s", m.State, to)
+	}
+
+	m.State = to
+	m.History = append(m.History, DisputeEvent{State: to, By: by, Note: note, At: at})
+
+	if to == DisputeResolved || to == DisputeRejected {
+		m.ClosedAt = at
+	}
+
+	return nil
+}
+
+//FileDispute reports a decoded field of the VIN as wrong
+func FileDispute(fullvin, field, expected, comment, reporter string) (husk.Recorder, error) {
+	now := time.Now()
+	obj := Dispute{
+		Full:      vinmath.Canonical(fullvin),
+		Field:     strings.ToLower(field),
+		Expected:  expected,
+		Comment:   comment,
+		Reporter:  reporter,
+		State:     DisputeOpen,
+		History:   []DisputeEvent{{State: DisputeOpen, By: reporter, Note: comment, At: now}},
+		CreatedAt: now,
+	}
+
+	if ok, err := obj.Valid(); !ok {
+		return nil, err
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer done()
+
+	obj.Checksum = obj.checksum()
+	cset := ctx.Disputes.Create(obj)
+
+	if cset.Error != nil {
+		return nil, cset.Error
+	}
+
+	defer ctx.Disputes.Save()
+	return cset.Record, nil
+}
+
+func GetDispute(key Key) (*Dispute, error) {
+	rec, err := ctx.Disputes.FindByKey(key.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Data().(*Dispute), nil
+}
+
+//GetDisputes returns a page of the disputes in the state
+func GetDisputes(state DisputeState, page, size int) husk.Collection {
+	return ctx.Disputes.Find(page, size, byDisputeState(state))
+}
+
+//AssignDispute hands the dispute to a reviewer, assigned disputes can be reassigned.
+func AssignDispute(key Key, reviewer string) error {
+	return changeDispute(key, func(d *Dispute) error {
+		d.Reviewer = reviewer
+		return d.transition(DisputeAssigned, reviewer, "", time.Now())
+	})
+}
+
+//ResolveDispute closes the dispute. When fields are given they're pinned with an Override of the VIN,
+//otherwise the reference data is expected to have been fixed.
+func ResolveDispute(key Key, fields map[string]string, note string) error {
+	d, err := GetDispute(key)
+
+	if err != nil {
+		return err
+	}
+
+	if d.State != DisputeAssigned {
+		return fmt.Errorf("a %s dispute can't be resolved", d.State)
+	}
+
+	resolution := ResolvedByReference
+
+	if len(fields) > 0 {
+		err = SetOverride(Override{Full: d.Full, Fields: fields, Reason: fmt.Sprintf("dispute: %s", note)})
+
+		if err != nil {
+			return err
+		}
+
+		resolution = ResolvedByOverride
+	}
+
+	return changeDispute(key, func(d *Dispute) error {
+		d.Resolution = resolution
+		return d.transition(DisputeResolved, d.Reviewer, note, time.Now())
+	})
+}
+
+//RejectDispute closes the dispute without changes, when the decode was correct.
+func RejectDispute(key Key, by, note string) error {
+	return changeDispute(key, func(d *Dispute) error {
+		return d.transition(DisputeRejected, by, note, time.Now())
+	})
+}
+
+func changeDispute(key Key, change func(d *Dispute) error) error {
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	rec, err := ctx.Disputes.FindByKey(key.key)
+
+	if err != nil {
+		return err
+	}
+
+	obj := *rec.Data().(*Dispute)
+	err = change(&obj)
+
+	if err != nil {
+		return err
+	}
+
+	obj.Checksum = obj.checksum()
+	err = rec.Set(obj)
+
+	if err != nil {
+		return err
+	}
+
+	defer ctx.Disputes.Save()
+	return ctx.Disputes.Update(rec)
+}
+
+//DisputeMetrics summarises the review workflow
+type DisputeMetrics struct {
+	States          map[string]int
+	Fields          map[string]int //Disputes per field
+	Resolutions     map[string]int
+	MeanTimeToClose time.Duration
+	OldestOpen      time.Time `json:",omitempty"`
+}
+
+//GetDisputeMetrics counts the disputes by state, field and resolution
+func GetDisputeMetrics() DisputeMetrics {
+	var all []Dispute
+	scan(ctx.Disputes, func(obj husk.Dataer) {
+		all = append(all, *obj.(*Dispute))
+	})
+
+	return disputeMetrics(all)
+}
+
+func disputeMetrics(all []Dispute) DisputeMetrics {
+	result := DisputeMetrics{
+		States:      make(map[string]int),
+		Fields:      make(map[string]int),
+		Resolutions: make(map[string]int),
+	}
+
+	var closed int
+	var total time.Duration
+
+	for _, d := range all {
+		result.States[d.State.String()]++
+		result.Fields[d.Field]++
+
+		if len(d.Resolution) > 0 {
+			result.Resolutions[d.Resolution]++
+		}
+
+		if !d.ClosedAt.IsZero() {
+			closed++
+			total += d.ClosedAt.Sub(d.CreatedAt)
+		} else if result.OldestOpen.IsZero() || d.CreatedAt.Before(result.OldestOpen) {
+			result.OldestOpen = d.CreatedAt
+		}
+	}
+
+	if closed > 0 {
+		result.MeanTimeToClose = total / time.Duration(closed)
+	}
+
+	return result
+}
+
+type disputeFilter func(obj *Dispute) bool
+
+func (f disputeFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*Dispute))
+}
+
+func byDisputeState(state DisputeState) disputeFilter {
+	return func(obj *Dispute) bool {
+		return obj.State == state
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDispute_Transition(t *testing.T) {
+	now := time.Now()
+	d := Dispute{Full: "WBA8E9G50GNT00001", Field: "series", State: DisputeOpen, CreatedAt: now}
+
+	if err := d.transition(DisputeResolved, "jane", "", now); err == nil {
+		t.Error("expected an open dispute to need a reviewer before it's resolved")
+	}
+
+	if err := d.transition(DisputeAssigned, "jane", "", now); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.transition(DisputeResolved, "jane", "fixed the VDS record", now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.transition(DisputeRejected, "jane", "", now); err == nil {
+		t.Error("expected a resolved dispute to be closed")
+	}
+
+	if len(d.History) != 2 || d.ClosedAt.IsZero() {
+		t.Errorf("unexpected history %+v", d)
+	}
+}
+
+func TestDisputeMetrics(t *testing.T) {
+	now := time.Now()
+	all := []Dispute{
+		{Field: "series", State: DisputeResolved, Resolution: ResolvedByOverride, CreatedAt: now, ClosedAt: now.Add(2 * time.Hour)},
+		{Field: "series", State: DisputeRejected, CreatedAt: now, ClosedAt: now.Add(4 * time.Hour)},
+		{Field: "year", State: DisputeOpen, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	m := disputeMetrics(all)
+
+	if m.States["open"] != 1 || m.Fields["series"] != 2 || m.Resolutions[ResolvedByOverride] != 1 {
+		t.Errorf("unexpected counts %+v", m)
+	}
+
+	if m.MeanTimeToClose != 3*time.Hour || !m.OldestOpen.Equal(now.Add(-time.Hour)) {
+		t.Errorf("unexpected times %+v", m)
+	}
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	EventDecoded = "decoded"
+	EventCreated = "created"
+)
+
+//Event is raised when a VIN is decoded or created
+type Event struct {
+	Type  string
+	Time  time.Time
+	VIN   VIN
+	Clone *PossibleCloneAlert `json:",omitempty"`
+}
+
+var (
+	eventMu     sync.RWMutex
+	subscribers []func(e Event)
+)
+
+//Subscribe registers fn to receive every Event. fn is called on the goroutine that raised the event, so it shouldn't block.
+func Subscribe(fn func(e Event)) {
+	eventMu.Lock()
+	defer eventMu.Unlock()
+
+	subscribers = append(subscribers, fn)
+}
+
+func raise(kind string, v VIN) {
+	raiseEvent(Event{Type: kind, Time: time.Now(), VIN: v})
+}
+
+func raiseEvent(e Event) {
+	eventMu.RLock()
+	defer eventMu.RUnlock()
+
+	for _, fn := range subscribers {
+		fn(e)
+	}
+}
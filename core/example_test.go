@@ -0,0 +1,40 @@
+package core_test
+
+import (
+	"fmt"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func ExampleValidateVIN() {
+	err := core.ValidateVIN("5npeu46f77h259112")
+	fmt.Println(err)
+
+	err = core.ValidateVIN("5NBEU46F77H259112")
+
+	if cErr, ok := err.(vinmath.CheckDigitError); ok {
+		fmt.Println("expected check digit", cErr.Expected)
+	}
+
+	fmt.Println(core.ValidateVIN("5NPEU46F77H2591"))
+	// Output:
+	// <nil>
+	// expected check digit X
+	// not correct length
+}
+
+func ExampleBuildInfo() {
+	vin, err := core.BuildInfo("5NPEU46F77H259112")
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(vin.WMInfo.Manufacturer, vin.WMInfo.Country)
+	fmt.Println(vin.Unique, vin.Serial)
+	// Output:
+	// Hyundai United States
+	// 5NPEU46F77H 259112
+}
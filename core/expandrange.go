@@ -0,0 +1,68 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//MaxExpandRange is the most VINs ExpandRange will generate
+var MaxExpandRange = 100000
+
+//ExpandRange returns every VIN from the first to the last serial, with the correct check digits.
+//Both VINs must describe the same vehicles, only the serial in positions 12-17 may differ.
+//The check digits of the bounds are ignored, recall notices often leave them out, ie. 5NPEU46F*7H259110.
+func ExpandRange(firstVIN, lastVIN string) ([]string, error) {
+	firstVIN = vinmath.Canonical(firstVIN)
+	lastVIN = vinmath.Canonical(lastVIN)
+
+	for _, v := range []string{firstVIN, lastVIN} {
+		if len(v) == 17 {
+			v = v[:8] + vinmath.CheckDigit(v) + v[9:]
+		}
+
+		err := vinmath.Validate(v)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", v, err)
+		}
+	}
+
+	if firstVIN[:8] != lastVIN[:8] || firstVIN[9:11] != lastVIN[9:11] {
+		return nil, errors.New("range must be of the same manufacturer, vehicle, year and plant")
+	}
+
+	start, err := strconv.Atoi(firstVIN[11:])
+
+	if err != nil {
+		return nil, fmt.Errorf("serial %s isn't a number", firstVIN[11:])
+	}
+
+	end, err := strconv.Atoi(lastVIN[11:])
+
+	if err != nil {
+		return nil, fmt.Errorf("serial %s isn't a number", lastVIN[11:])
+	}
+
+	if start > end {
+		return nil, fmt.Errorf("range starts at %d after it ends at %d", start, end)
+	}
+
+	if end-start >= MaxExpandRange {
+		return nil, fmt.Errorf("range of %d VINs is larger than %d", end-start+1, MaxExpandRange)
+	}
+
+	result := make([]string, 0, end-start+1)
+	buf := []byte(firstVIN)
+
+	for serial := start; serial <= end; serial++ {
+		copy(buf[11:], fmt.Sprintf("%06d", serial))
+		buf[8] = vinmath.CheckDigit(string(buf))[0]
+
+		result = append(result, string(buf))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func TestExpandRange(t *testing.T) {
+	vins, err := ExpandRange("5NPEU46F_7H259110", "5npeu46f77h259112")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"5NPEU46F37H259110", "5NPEU46F57H259111", "5NPEU46F77H259112"}
+
+	if len(vins) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, vins)
+	}
+
+	for i, v := range vins {
+		if v != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], v)
+		}
+
+		if err := vinmath.Validate(v); err != nil {
+			t.Errorf("%s: %s", v, err)
+		}
+	}
+}
+
+func TestExpandRange_Invalid(t *testing.T) {
+	ranges := [][2]string{
+		{"5NPEU46F77H259112", "5NPEU46F77J259113"}, //Different plant
+		{"5NPEU46F77H259113", "5NPEU46F77H259112"}, //Backwards
+		{"5NPEU46F77H2591", "5NPEU46F77H259112"},   //Too short
+		{"5NPEU46F77H000000", "5NPEU46F77H999999"}, //Too large
+	}
+
+	for _, r := range ranges {
+		if _, err := ExpandRange(r[0], r[1]); err == nil {
+			t.Errorf("%s-%s: expecting error", r[0], r[1])
+		}
+	}
+}
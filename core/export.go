@@ -0,0 +1,72 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ExportNDJSON writes every VIN created after the cursor to w, one JSON object per line, in the order they were created.
+//The returned cursor is passed to the next export to only receive the VINs created since.
+//An empty cursor exports everything. When nothing new was found the same cursor is returned.
+func ExportNDJSON(w io.Writer, cursor string) (string, error) {
+	after, full, err := parseCursor(cursor)
+
+	if err != nil {
+		return cursor, err
+	}
+
+	var vins []VIN
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+
+		if v.CreatedAt.After(after) || (v.CreatedAt.Equal(after) && v.Full > full) {
+			vins = append(vins, *v)
+		}
+	})
+
+	sortVINS(vins, SortCreated)
+	enc := json.NewEncoder(w)
+
+	for _, v := range vins {
+		err := enc.Encode(v)
+
+		if err != nil {
+			return cursor, err
+		}
+
+		cursor = makeCursor(v)
+	}
+
+	return cursor, nil
+}
+
+//makeCursor points to the VIN, it's the creation time and full VIN, so VINs created at the same time aren't skipped.
+func makeCursor(v VIN) string {
+	return fmt.Sprintf("%d.%s", v.CreatedAt.UnixNano(), v.Full)
+}
+
+func parseCursor(cursor string) (time.Time, string, error) {
+	if len(cursor) == 0 {
+		return time.Time{}, "", nil
+	}
+
+	parts := strings.SplitN(cursor, ".", 2)
+
+	if len(parts) != 2 {
+		return time.Time{}, "", fmt.Errorf("invalid cursor %s", cursor)
+	}
+
+	nano, err := strconv.ParseInt(parts[0], 10, 64)
+
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid cursor %s", cursor)
+	}
+
+	return time.Unix(0, nano), parts[1], nil
+}
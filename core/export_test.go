@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	v := VIN{Full: "5NPEU46F77H259112", CreatedAt: time.Date(2020, 3, 1, 10, 0, 0, 5, time.UTC)}
+	at, full, err := parseCursor(makeCursor(v))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !at.Equal(v.CreatedAt) || full != v.Full {
+		t.Errorf("expected %s %s, got %s %s", v.CreatedAt, v.Full, at, full)
+	}
+}
+
+func TestCursor_Invalid(t *testing.T) {
+	_, _, err := parseCursor("yesterday")
+
+	if err == nil {
+		t.Error("expecting error")
+	}
+}
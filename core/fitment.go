@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+//FitmentKey describes the decoded vehicle with the ACES (VCdb) attribute names parts catalogs are keyed by.
+type FitmentKey struct {
+	Year         int
+	Make         string
+	Model        string
+	VehicleType  string //Car, Truck, Van, Motorcycle...
+	BodyType     string `json:",omitempty"`
+	BodyNumDoors int    `json:",omitempty"`
+	Liter        string `json:",omitempty"` //ie. 2.0
+	CC           int    `json:",omitempty"`
+	CID          int    `json:",omitempty"`
+	Cylinders    int    `json:",omitempty"`
+	BlockType    string `json:",omitempty"` //L, V, H, R or W
+	Aspiration   string `json:",omitempty"`
+	FuelType     string `json:",omitempty"`
+}
+
+//acesVehicleTypes maps our vehicle types to VCdb, which lists SUVs and MPVs as trucks
+var acesVehicleTypes = map[string]string{
+	PassengerCar.String(): "Car",
+	Motorcycle.String():   "Motorcycle",
+	Truck.String():        "Truck",
+	MPV.String():          "Truck",
+	Trailer.String():      "Trailer",
+	LSV.String():          "Low Speed Vehicle",
+	ATV.String():          "ATV",
+}
+
+var acesBodyTypes = map[string]string{
+	"Pickup":        "Pickup",
+	"Station Wagon": "Wagon",
+	"SUV":           "Sport Utility",
+}
+
+var acesBlockTypes = map[string]string{
+	"inline": "L",
+	"v":      "V",
+	"boxer":  "H",
+	"rotary": "R",
+	"w":      "W",
+}
+
+var acesAspiration = map[string]string{
+	"turbo":        "Turbocharged",
+	"supercharger": "Supercharged",
+	"na":           "Naturally Aspirated",
+}
+
+var acesFuelTypes = map[string]string{
+	"petrol":   "GAS",
+	"diesel":   "DIESEL",
+	"electric": "ELECTRIC",
+	"hybrid":   "GAS / ELECTRIC",
+	"lpg":      "PROPANE",
+	"bev":      "ELECTRIC",
+	"phev":     "GAS / ELECTRIC",
+}
+
+//Fitment returns the fitment key of the decoded VIN. Attributes which weren't decoded are left empty, so a catalog query can ignore them.
+//The Model is the Series, or the chassis code when the Series isn't known.
+func (m VIN) Fitment() FitmentKey {
+	engine := m.Series.Platform.Engine
+	result := FitmentKey{
+		Year:         m.ModelYear(),
+		Make:         m.WMInfo.Manufacturer,
+		Model:        m.Series.Spec,
+		VehicleType:  acesVehicleTypes[m.WMInfo.VehicleType],
+		BodyNumDoors: m.Series.Platform.Body.Doors,
+		CC:           engine.Displacement,
+		Cylinders:    engine.Cylinders,
+		BlockType:    acesBlockTypes[strings.ToLower(engine.Layout)],
+		Aspiration:   acesAspiration[strings.ToLower(engine.Induction)],
+	}
+
+	if len(result.Model) == 0 {
+		result.Model = m.VDSInfo.Chassis
+	}
+
+	if layout := bodyLayout(&m); len(layout) > 0 {
+		result.BodyType = layout
+
+		if aces, ok := acesBodyTypes[layout]; ok {
+			result.BodyType = aces
+		}
+	}
+
+	if engine.Displacement > 0 {
+		result.Liter = fmt.Sprintf("%.1f", float64(engine.Displacement)/1000)
+		result.CID = int(math.Round(float64(engine.Displacement) / 16.387))
+	}
+
+	fuel := engine.FuelType
+
+	if len(fuel) == 0 && m.VDSInfo.EV != nil {
+		fuel = m.VDSInfo.EV.Powertrain
+	}
+
+	result.FuelType = acesFuelTypes[strings.ToLower(fuel)]
+
+	return result
+}
+
+//String is the key parts catalogs are commonly indexed by, Year|Make|Model|Engine|Body
+func (k FitmentKey) String() string {
+	engine := ""
+
+	if len(k.Liter) > 0 {
+		engine = strings.TrimSpace(fmt.Sprintf("%sL %s%d", k.Liter, k.BlockType, k.Cylinders))
+	}
+
+	body := k.BodyType
+
+	if k.BodyNumDoors > 0 {
+		body = fmt.Sprintf("%s %dD", body, k.BodyNumDoors)
+	}
+
+	return strings.Join([]string{fmt.Sprint(k.Year), k.Make, k.Model, engine, strings.TrimSpace(body)}, "|")
+}
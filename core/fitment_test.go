@@ -0,0 +1,24 @@
+package core
+
+import "testing"
+
+func TestVIN_Fitment(t *testing.T) {
+	v := VIN{Years: []int{2016}, WMInfo: WMInfo{Manufacturer: "BMW", VehicleType: "PassengerCar"}}
+	v.Series.Spec = "F30"
+	v.Series.Platform.Body = Body{Layout: "Sedan", Doors: 4}
+	v.Series.Platform.Engine = Engine{Displacement: 1998, Cylinders: 4, Layout: "Inline", Induction: "Turbo", FuelType: "Petrol"}
+
+	key := v.Fitment()
+
+	if key.Liter != "2.0" || key.CID != 122 || key.BlockType != "L" || key.Aspiration != "Turbocharged" || key.FuelType != "GAS" {
+		t.Errorf("unexpected engine %+v", key)
+	}
+
+	if key.VehicleType != "Car" || key.BodyType != "Sedan" {
+		t.Errorf("unexpected vehicle %+v", key)
+	}
+
+	if key.String() != "2016|BMW|F30|2.0L L4|Sedan 4D" {
+		t.Errorf("unexpected key %s", key)
+	}
+}
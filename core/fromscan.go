@@ -0,0 +1,65 @@
+package core
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	groupSeparator  = "\x1d"
+	recordSeparator = "\x1e"
+	endOfTransfer   = "\x04"
+	//vinIdentifier is the ANSI MH10.8.2 data identifier for a VIN, used by Code 39 and ISO 15394 labels
+	vinIdentifier = "I"
+)
+
+//FromScan extracts the VIN from a barcode payload and validates it.
+//It accepts plain VINs, Code 39 with the 'I' data identifier and start/stop characters,
+//and ISO 15434 envelopes like the ones on ISO 15394 labels.
+func FromScan(payload string) (string, error) {
+	vin, err := extractVIN(payload)
+
+	if err != nil {
+		return "", err
+	}
+
+	return vin, ValidateVIN(vin)
+}
+
+func extractVIN(payload string) (string, error) {
+	payload = strings.TrimSpace(payload)
+
+	//AIM symbology identifiers like ]A0 are added by some scanners
+	if strings.HasPrefix(payload, "]") && len(payload) > 3 {
+		payload = payload[3:]
+	}
+
+	//Envelope: [)> RS 06 GS field GS field ... RS EOT
+	if strings.HasPrefix(payload, "[)>") {
+		fields := strings.FieldsFunc(payload, func(r rune) bool {
+			return strings.ContainsRune(groupSeparator+recordSeparator+endOfTransfer, r)
+		})
+
+		for _, f := range fields {
+			if len(f) == 18 && strings.HasPrefix(f, vinIdentifier) {
+				return strings.ToUpper(f[1:]), nil
+			}
+		}
+
+		return "", errors.New("no VIN field in the label")
+	}
+
+	payload = strings.Trim(payload, "*")
+	payload = strings.ToUpper(strings.TrimSpace(payload))
+
+	//VINs can't contain an I, so a leading I is always the data identifier
+	if len(payload) == 18 && strings.HasPrefix(payload, vinIdentifier) {
+		payload = payload[1:]
+	}
+
+	if len(payload) != 17 {
+		return "", errors.New("no VIN in the scan")
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,34 @@
+package core
+
+import "testing"
+
+func TestExtractVIN_Payloads(t *testing.T) {
+	payloads := map[string]string{
+		"5NPEU46F77H259112":                                  "plain",
+		"I5NPEU46F77H259112":                                 "code 39 identifier",
+		"*I5NPEU46F77H259112*":                               "code 39 start/stop",
+		"]A0I5NPEU46F77H259112\r\n":                          "symbology identifier",
+		"[)>\x1e06\x1d1PPART1\x1dI5NPEU46F77H259112\x1e\x04": "ISO 15434 envelope",
+	}
+
+	for in, name := range payloads {
+		vin, err := extractVIN(in)
+
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+
+		if vin != "5NPEU46F77H259112" {
+			t.Errorf("%s: got %s", name, vin)
+		}
+	}
+}
+
+func TestExtractVIN_NoVIN(t *testing.T) {
+	_, err := extractVIN("[)>\x1e06\x1d1PPART1\x1e\x04")
+
+	if err == nil {
+		t.Error("expecting error")
+	}
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"sort"
+)
+
+//FuzzyWMI enables suggestions of similar WMIs when FindWMInfo can't find the manufacturer
+var FuzzyWMI = false
+
+//SuggestWMI returns the known WMIs which are a single edit away from the WMI of the VIN.
+//A match is usually a typo, either in the VIN or in the reference data.
+func SuggestWMI(uniquevin string) []string {
+	wmi := uniquevin[:3]
+	found := make(map[string]bool)
+
+	eachManufacturer(func(region *Region, country Country, manufacturer Manufacturer) {
+		code := manufacturer.WMICode
+
+		//Short codes are prefixes, so only the same number of characters are compared
+		if len(code) < len(wmi) && levenshtein(wmi[:len(code)], code) == 1 {
+			found[code] = true
+		}
+
+		if len(code) == len(wmi) && levenshtein(wmi, code) == 1 {
+			found[code] = true
+		}
+	})
+
+	var result []string
+
+	for k := range found {
+		result = append(result, k)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+//levenshtein returns the number of single character edits needed to change a into b
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func min(vals ...int) int {
+	result := vals[0]
+
+	for _, v := range vals[1:] {
+		if v < result {
+			result = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,22 @@
+package core
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	cases := []struct {
+		a, b string
+		dist int
+	}{
+		{"WVW", "WVW", 0},
+		{"WVW", "WVV", 1},
+		{"WVW", "VW", 1},
+		{"WAU", "WUA", 2},
+		{"", "KNH", 3},
+	}
+
+	for _, c := range cases {
+		if d := levenshtein(c.a, c.b); d != c.dist {
+			t.Errorf("%s -> %s expected %d, got %d", c.a, c.b, c.dist, d)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files with the current decodes")
+
+//goldenDecodes is the curated set of VINs which decode output is fixed in testdata/decode.golden.json
+const goldenDecodes = "testdata/decode.golden.json"
+
+//goldenCase is a decode in the golden file, VINs which can't be decoded keep their error
+type goldenCase struct {
+	VIN    string
+	Error  string          `json:",omitempty"`
+	Decode json.RawMessage `json:",omitempty"`
+}
+
+//TestDecode_Golden fails when the decode of a curated VIN changed.
+//When the change is intended, run the test with -update and commit the reviewed diff of the golden file with it.
+func TestDecode_Golden(t *testing.T) {
+	data, err := ioutil.ReadFile(goldenDecodes)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []goldenCase
+	err = json.Unmarshal(data, &cases)
+
+	if err != nil {
+		t.Fatalf("%s: %s", goldenDecodes, err)
+	}
+
+	for i, c := range cases {
+		actual := goldenDecode(c.VIN)
+
+		if *updateGolden {
+			cases[i] = actual
+			continue
+		}
+
+		if actual.Error != c.Error {
+			t.Errorf("%s: expected error %q, got %q", c.VIN, c.Error, actual.Error)
+		}
+
+		if !sameJSON(actual.Decode, c.Decode) {
+			t.Errorf("%s: expected decode %s, got %s", c.VIN, c.Decode, actual.Decode)
+		}
+	}
+
+	if !*updateGolden {
+		return
+	}
+
+	data, err = json.MarshalIndent(cases, "", "  ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(goldenDecodes, append(data, '\n'), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func goldenDecode(fullvin string) goldenCase {
+	result := goldenCase{VIN: fullvin}
+	vin, err := BuildInfo(fullvin)
+
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Decode, err = json.Marshal(vin)
+
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+//sameJSON compares the JSON values, regardless of formatting
+func sameJSON(a, b json.RawMessage) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	var va, vb interface{}
+
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
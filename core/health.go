@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//HealthCheck is the result of a single check
+type HealthCheck struct {
+	Name    string
+	Healthy bool
+	Error   string `json:",omitempty"`
+}
+
+//HealthReport is returned by Health, it's only Healthy when every check passed.
+type HealthReport struct {
+	Healthy bool
+	Checks  []HealthCheck
+}
+
+var (
+	healthMu        sync.RWMutex
+	healthEndpoints = make(map[string]string)
+)
+
+//RegisterHealthEndpoint adds an external service which has to respond for the service to be healthy
+func RegisterHealthEndpoint(name, url string) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+
+	healthEndpoints[name] = url
+}
+
+//Health verifies that the store is open, the reference data is loaded and that registered endpoints respond.
+func Health(c context.Context) HealthReport {
+	result := HealthReport{Healthy: true}
+	result.add("store", checkStore())
+	result.add("reference", checkReference())
+
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+
+	for name, url := range healthEndpoints {
+		result.add(name, checkEndpoint(c, url))
+	}
+
+	return result
+}
+
+func (r *HealthReport) add(name string, err error) {
+	check := HealthCheck{Name: name, Healthy: err == nil}
+
+	if err != nil {
+		check.Error = err.Error()
+		r.Healthy = false
+	}
+
+	r.Checks = append(r.Checks, check)
+}
+
+func checkStore() error {
+	tables := []struct {
+		name  string
+		table husk.Tabler
+	}{
+		{"VIN", ctx.VIN}, {"Regions", ctx.Regions}, {"VDS", ctx.VDS}, {"Staging", ctx.Staging}, {"Quarantine", ctx.Quarantine},
+		{"Serials", ctx.Serials}, {"TypeApprovals", ctx.TypeApprovals}, {"Warranties", ctx.Warranties}, {"Services", ctx.Services},
+		{"Wheels", ctx.Wheels}, {"Overrides", ctx.Overrides}, {"Disputes", ctx.Disputes}, {"Recalls", ctx.Recalls}, {"Hulls", ctx.Hulls},
+	}
+
+	for _, t := range tables {
+		if t.table == nil {
+			return fmt.Errorf("table %s has not been created", t.name)
+		}
+
+		err := readTable(t.table)
+
+		if err != nil {
+			return fmt.Errorf("table %s can't be read: %s", t.name, err)
+		}
+	}
+
+	//Decodes read the reference data through the Store, which may be another deployment
+	_, err := currentStore().FindRegion("1HG")
+
+	if err != nil && err != ErrReferenceNotFound {
+		return fmt.Errorf("store can't be read: %s", err)
+	}
+
+	return nil
+}
+
+//readTable reads the first record of the table, a table which can't be read panics.
+func readTable(t husk.Tabler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	t.Find(1, 1, husk.Everything())
+
+	return nil
+}
+
+func checkReference() error {
+	if ctx.Regions == nil {
+		return fmt.Errorf("regions have not been loaded")
+	}
+
+	_, err := ctx.Regions.FindFirst(husk.Everything())
+
+	if err != nil {
+		return fmt.Errorf("regions are empty: %s", err)
+	}
+
+	count := 0
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		count++
+	})
+
+	if count == 0 {
+		return fmt.Errorf("no manufacturers")
+	}
+
+	return nil
+}
+
+func checkEndpoint(c context.Context, url string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req.WithContext(c))
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+//unavailableStore fails every region lookup
+type unavailableStore struct {
+	localStore
+}
+
+func (unavailableStore) FindRegion(uniquevin string) (Region, error) {
+	return Region{}, errors.New("store is unavailable")
+}
+
+//storeCheck returns the store check of the report
+func storeCheck(t *testing.T, report HealthReport) HealthCheck {
+	for _, c := range report.Checks {
+		if c.Name == "store" {
+			return c
+		}
+	}
+
+	t.Fatal("expected a store check")
+	return HealthCheck{}
+}
+
+func TestHealth_Store(t *testing.T) {
+	check := storeCheck(t, Health(context.Background()))
+
+	if !check.Healthy {
+		t.Errorf("expected the store to be healthy, got %s", check.Error)
+	}
+}
+
+func TestHealth_StoreDegraded(t *testing.T) {
+	SetStore(unavailableStore{})
+	defer SetStore(nil)
+
+	report := Health(context.Background())
+
+	if report.Healthy || storeCheck(t, report).Healthy {
+		t.Error("expected a store which can't be read to be unhealthy")
+	}
+}
+
+func TestHealth_MissingTable(t *testing.T) {
+	hulls := ctx.Hulls
+	ctx.Hulls = nil
+	defer func() { ctx.Hulls = hulls }()
+
+	check := storeCheck(t, Health(context.Background()))
+
+	if check.Healthy || check.Error != "table Hulls has not been created" {
+		t.Errorf("expected the missing table to be reported, got %+v", check)
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Kinds of HistoryRecord
+const (
+	HistoryCreated  = "created"
+	HistoryDispute  = "dispute"
+	HistoryOverride = "override"
+)
+
+//HistoryRecord is a change to what's known about a VIN
+type HistoryRecord struct {
+	At     time.Time
+	Kind   string
+	Detail string
+}
+
+//GetHistory returns when the VIN was created, the changes of its disputes and its override, oldest first.
+//VINs which aren't stored can still have disputes and an override.
+func GetHistory(fullvin string) []HistoryRecord {
+	fullvin = vinmath.Canonical(fullvin)
+	var result []HistoryRecord
+
+	if v, err := GetByFullVIN(fullvin); err == nil {
+		result = append(result, HistoryRecord{At: v.CreatedAt, Kind: HistoryCreated, Detail: v.Source})
+	}
+
+	scan(ctx.Disputes, func(obj husk.Dataer) {
+		d := obj.(*Dispute)
+
+		if d.Full != fullvin {
+			return
+		}
+
+		for _, e := range d.History {
+			detail := fmt.Sprintf("%s %s by %s", d.Field, e.State, e.By)
+
+			if len(e.Note) > 0 {
+				detail += ": " + e.Note
+			}
+
+			result = append(result, HistoryRecord{At: e.At, Kind: HistoryDispute, Detail: detail})
+		}
+	})
+
+	if o, err := GetOverride(fullvin); err == nil {
+		result = append(result, HistoryRecord{At: o.UpdatedAt, Kind: HistoryOverride, Detail: o.Reason})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].At.Before(result[j].At)
+	})
+
+	return result
+}
@@ -0,0 +1,43 @@
+package core
+
+import "testing"
+
+func TestGetHistory(t *testing.T) {
+	const fullvin = "1HGCM82633A004352"
+	_, err := VIN{Full: fullvin, Unique: fullvin[:11], Serial: 4352, Source: "history"}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FileDispute(fullvin, "series", "Accord EX", "it's an EX", "dealer")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = SetOverride(Override{Full: fullvin, Fields: map[string]string{"series": "Accord EX"}, Reason: "dispute"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer RemoveOverride(fullvin)
+
+	history := GetHistory(" " + fullvin)
+	kinds := []string{HistoryCreated, HistoryDispute, HistoryOverride}
+
+	if len(history) != len(kinds) {
+		t.Fatalf("expected %d records, got %+v", len(kinds), history)
+	}
+
+	for i, k := range kinds {
+		if history[i].Kind != k {
+			t.Errorf("expected %s at %d, got %+v", k, i, history[i])
+		}
+	}
+
+	if history[0].Detail != "history" || history[1].Detail != "series open by dealer: it's an EX" {
+		t.Errorf("unexpected details %+v", history)
+	}
+}
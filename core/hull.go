@@ -0,0 +1,80 @@
+package core
+
+import (
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/hin"
+)
+
+//Hull is a stored boat, identified by its HIN instead of a VIN
+type Hull struct {
+	HIN       hin.HIN
+	Source    string //Where the HIN was ingested from
+	CreatedAt time.Time
+}
+
+func (m Hull) Valid() (bool, error) {
+	err := hin.Validate(m.HIN.Full)
+
+	if err != nil {
+		return false, err
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+//CreateHull decodes and stores the HIN, the stored record is returned when it already exists.
+func CreateHull(fullhin, source string) (husk.Recorder, error) {
+	h, err := hin.Parse(fullhin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer done()
+
+	rec, err := ctx.Hulls.FindFirst(byHIN(h.Full))
+
+	if err == nil {
+		return rec, nil
+	}
+
+	cset := ctx.Hulls.Create(Hull{HIN: h, Source: source, CreatedAt: time.Now()})
+
+	if cset.Error != nil {
+		return nil, cset.Error
+	}
+
+	defer ctx.Hulls.Save()
+	return cset.Record, nil
+}
+
+//GetHull returns the stored hull of the HIN
+func GetHull(fullhin string) (*Hull, error) {
+	rec, err := ctx.Hulls.FindFirst(byHIN(hin.Canonical(fullhin)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Data().(*Hull), nil
+}
+
+type hullFilter func(obj *Hull) bool
+
+func (f hullFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*Hull))
+}
+
+func byHIN(full string) hullFilter {
+	return func(obj *Hull) bool {
+		return obj.HIN.Full == full
+	}
+}
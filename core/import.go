@@ -0,0 +1,321 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ImportOptions control how reference data is imported
+type ImportOptions struct {
+	DryRun bool //Only report the changes, nothing is written
+	Prune  bool //Delete existing records in the imported scope which aren't part of the import
+}
+
+//ImportDiff lists the changes an import made, or would make when it's a dry-run.
+type ImportDiff struct {
+	Adds      []string
+	Updates   []string
+	Conflicts []string
+	Deletions []string
+}
+
+type wmiChange struct {
+	record  husk.Recorder
+	version int
+	country int
+	item    Manufacturer
+	delete  bool
+}
+
+//ImportWMI adds or updates the manufacturers in the reference data.
+//Manufacturers are placed in the country their WMI is assigned to.
+func ImportWMI(items []StagedManufacturer, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var changes []wmiChange
+	seen := make(map[string]bool)
+	touched := make(map[string]wmiChange)
+
+	for _, item := range items {
+		wmi := strings.ToUpper(item.WMICode)
+
+		if len(wmi) != 3 {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: WMI must be 3 characters", item.WMICode))
+			continue
+		}
+
+		if seen[wmi] {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: imported more than once", wmi))
+			continue
+		}
+
+		seen[wmi] = true
+		rec, err := ctx.Regions.FindFirst(byUniqueVIN(wmi))
+
+		if err != nil {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: no region", wmi))
+			continue
+		}
+
+		region := rec.Data().(*Region)
+		cIdx := region.countryIndex(wmi)
+
+		if cIdx == -1 {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: no country in %s", wmi, region.Name))
+			continue
+		}
+
+		country := region.Countries[cIdx]
+		touched[region.Name+"/"+country.Name] = wmiChange{record: rec, version: region.Version, country: cIdx}
+		item.WMICode = wmi
+		change := wmiChange{record: rec, version: region.Version, country: cIdx, item: item.Manufacturer}
+		found := false
+
+		for _, m := range country.Manufacturers {
+			if m.WMICode == wmi {
+				found = true
+
+				if m.Name != item.Name || m.Description != item.Description || m.VehicleType != item.VehicleType {
+					diff.Updates = append(diff.Updates, wmi)
+					changes = append(changes, change)
+				}
+
+				break
+			}
+
+			//Manufacturers without a WMICode in the seed don't cover anything
+			if len(m.WMICode) > 0 && strings.HasPrefix(wmi, m.WMICode) {
+				found = true
+				diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: already covered by %s", wmi, m.WMICode))
+				break
+			}
+		}
+
+		if !found {
+			diff.Adds = append(diff.Adds, wmi)
+			changes = append(changes, change)
+		}
+	}
+
+	if opts.Prune {
+		for _, t := range touched {
+			country := t.record.Data().(*Region).Countries[t.country]
+
+			for _, m := range country.Manufacturers {
+				if !seen[m.WMICode] {
+					diff.Deletions = append(diff.Deletions, m.WMICode)
+					changes = append(changes, wmiChange{record: t.record, version: t.version, country: t.country, item: m, delete: true})
+				}
+			}
+		}
+	}
+
+	if opts.DryRun || len(changes) == 0 {
+		return diff, nil
+	}
+
+	return diff, applyWMIChanges(changes)
+}
+
+//applyWMIChanges changes a copy of every region, and saves it when the region hasn't been changed since the import was planned.
+func applyWMIChanges(changes []wmiChange) error {
+	var keys []Key
+	byRegion := make(map[string][]wmiChange)
+
+	for _, c := range changes {
+		key := KeyOf(c.record.GetKey())
+
+		if _, ok := byRegion[key.String()]; !ok {
+			keys = append(keys, key)
+		}
+
+		byRegion[key.String()] = append(byRegion[key.String()], c)
+	}
+
+	for _, key := range keys {
+		regionChanges := byRegion[key.String()]
+		err := changeRegion(key, regionChanges[0].version, func(region *Region) error {
+			for _, c := range regionChanges {
+				applyWMIChange(&region.Countries[c.country], c)
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//applyWMIChange adds, updates or deletes the manufacturer in the country
+func applyWMIChange(country *Country, c wmiChange) {
+	idx := -1
+
+	for i, m := range country.Manufacturers {
+		if m.WMICode == c.item.WMICode {
+			idx = i
+			break
+		}
+	}
+
+	switch {
+	case c.delete && idx != -1:
+		country.Manufacturers = append(country.Manufacturers[:idx], country.Manufacturers[idx+1:]...)
+	case idx != -1:
+		curr := &country.Manufacturers[idx]
+		curr.Name = c.item.Name
+		curr.Description = c.item.Description
+		curr.VehicleType = c.item.VehicleType
+	case !c.delete:
+		country.Manufacturers = append(country.Manufacturers, c.item)
+	}
+}
+
+//BulkUpsertVDS adds new VDS records, and updates the existing records with the same WMI, code, market and start year.
+//Records which overlap the years of an existing record for another series are reported as conflicts.
+func BulkUpsertVDS(items []VDSRecord, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []VDSRecord
+	var updates []husk.Recorder
+	var updated []VDSRecord
+	imported := make(map[string]bool)
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+		item.Code = strings.ToUpper(item.Code)
+		name := fmt.Sprintf("%s%s %s %d-%d", item.WMICode, item.Code, item.Market, item.StartYear, item.EndYear)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+
+		imported[item.WMICode+item.Code] = true
+
+		var clash *VDSRecord
+		scan(ctx.VDS, func(obj husk.Dataer) {
+			curr := obj.(*VDSRecord)
+
+			if byVDSCode(item.WMICode, item.Code)(curr) && curr.Market == item.Market &&
+				curr.StartYear != item.StartYear && curr.SeriesSpec != item.SeriesSpec && curr.overlaps(item) {
+				clash = curr
+			}
+		})
+
+		if clash != nil {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: overlaps %s %d-%d", name, clash.SeriesSpec, clash.StartYear, clash.EndYear))
+			continue
+		}
+
+		rec, err := ctx.VDS.FindFirst(byVDSRecord(item))
+
+		if err != nil {
+			diff.Adds = append(diff.Adds, name)
+			adds = append(adds, item)
+			continue
+		}
+
+		curr := *rec.Data().(*VDSRecord)
+
+		//Items without a Version overwrite whatever is stored
+		if item.Version != 0 && checkVersion(curr.Version, item.Version) != nil {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", name, ErrVersionConflict))
+			continue
+		}
+
+		item.Version = curr.Version
+
+		if curr != item {
+			item.Version++
+			diff.Updates = append(diff.Updates, name)
+			updates = append(updates, rec)
+			updated = append(updated, item)
+		}
+	}
+
+	var deletes []husk.Key
+
+	if opts.Prune {
+		for wmiCode := range imported {
+			wmi, code := wmiCode[:3], wmiCode[3:]
+			var stale []VDSRecord
+			scan(ctx.VDS, func(obj husk.Dataer) {
+				curr := obj.(*VDSRecord)
+
+				if byVDSCode(wmi, code)(curr) && !containsVDS(items, *curr) {
+					stale = append(stale, *curr)
+				}
+			})
+
+			for _, s := range stale {
+				rec, err := ctx.VDS.FindFirst(byVDSRecord(s))
+
+				if err == nil {
+					diff.Deletions = append(diff.Deletions, fmt.Sprintf("%s%s %s %d-%d", s.WMICode, s.Code, s.Market, s.StartYear, s.EndYear))
+					deletes = append(deletes, rec.GetKey())
+				}
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer referenceChanged()
+	defer ctx.VDS.Save()
+
+	for _, item := range adds {
+		cset := ctx.VDS.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	for i, rec := range updates {
+		err := rec.Set(updated[i])
+
+		if err != nil {
+			return diff, err
+		}
+
+		err = ctx.VDS.Update(rec)
+
+		if err != nil {
+			return diff, err
+		}
+	}
+
+	for _, k := range deletes {
+		err := ctx.VDS.Delete(k)
+
+		if err != nil {
+			return diff, err
+		}
+	}
+
+	return diff, nil
+}
+
+func containsVDS(items []VDSRecord, rec VDSRecord) bool {
+	for _, item := range items {
+		if strings.EqualFold(item.WMICode, rec.WMICode) && strings.EqualFold(item.Code, rec.Code) &&
+			item.Market == rec.Market && item.StartYear == rec.StartYear {
+			return true
+		}
+	}
+
+	return false
+}
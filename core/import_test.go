@@ -0,0 +1,129 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportWMI(t *testing.T) {
+	items := []StagedManufacturer{
+		{Manufacturer: Manufacturer{WMICode: "1z9", Name: "Rivian", VehicleType: Truck}},
+		{Manufacturer: Manufacturer{WMICode: "1Z", Name: "Too short"}},
+		{Manufacturer: Manufacturer{WMICode: "1Z9", Name: "Again"}},
+	}
+
+	diff, err := ImportWMI(items, ImportOptions{DryRun: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(diff.Adds, []string{"1Z9"}) || len(diff.Conflicts) != 2 {
+		t.Fatalf("expected 7FC to be added and two conflicts, got %+v", diff)
+	}
+
+	if _, err := FindManufacturer("1Z9TGAAA8PN000001"); err == nil {
+		t.Fatal("expected a dry-run to not write the manufacturer")
+	}
+
+	_, err = ImportWMI(items[:1], ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := FindManufacturer("1Z9TGAAA8PN000001")
+
+	if err != nil || m.Name != "Rivian" {
+		t.Fatalf("expected the imported manufacturer, got %+v %v", m, err)
+	}
+
+	items[0].Name = "Rivian Automotive"
+	diff, err = ImportWMI(items[:1], ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(diff.Updates, []string{"1Z9"}) || len(diff.Adds) > 0 {
+		t.Errorf("expected 7FC to be updated, got %+v", diff)
+	}
+}
+
+func TestBulkUpsertVDS(t *testing.T) {
+	record := VDSRecord{WMICode: "zzx", Code: "ab1cd", SeriesSpec: "Alpha", StartYear: 2010, EndYear: 2015}
+	diff, err := BulkUpsertVDS([]VDSRecord{record}, ImportOptions{DryRun: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Adds) != 1 || len(findVDS(t, "ZZX", "AB1CD")) != 0 {
+		t.Fatalf("expected a dry-run to report the add without writing it, got %+v", diff)
+	}
+
+	_, err = BulkUpsertVDS([]VDSRecord{record}, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := findVDS(t, "ZZX", "AB1CD")
+
+	if len(stored) != 1 || stored[0].SeriesSpec != "Alpha" {
+		t.Fatalf("expected the record to be added, got %+v", stored)
+	}
+
+	record.EndYear = 2016
+	diff, err = BulkUpsertVDS([]VDSRecord{record}, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Updates) != 1 || findVDS(t, "ZZX", "AB1CD")[0].Version != 1 {
+		t.Errorf("expected the record to be updated, got %+v", diff)
+	}
+
+	stale := record
+	stale.SeriesSpec = "Alpha S"
+	stale.Version = 5
+	clash := VDSRecord{WMICode: "ZZX", Code: "AB1CD", SeriesSpec: "Beta", StartYear: 2014, EndYear: 2018}
+	diff, err = BulkUpsertVDS([]VDSRecord{stale, clash}, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Conflicts) != 2 || len(diff.Adds)+len(diff.Updates) > 0 {
+		t.Errorf("expected the version and the overlap to conflict, got %+v", diff)
+	}
+}
+
+func findVDS(t *testing.T, wmi, code string) []VDSRecord {
+	result, err := localStore{}.FindVDSRecords(wmi, code, []int{2010, 2011, 2012, 2013, 2014, 2015, 2016})
+
+	if err != nil && err != ErrReferenceNotFound {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestImportWMI_Unassigned(t *testing.T) {
+	if _, err := FindManufacturer("7FCTGAAA8PN000001"); err == nil {
+		t.Fatal("expected 7FC to have no manufacturer")
+	}
+
+	_, err := ImportWMI([]StagedManufacturer{{Manufacturer: Manufacturer{WMICode: "7FC", Name: "Rivian"}}}, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := FindManufacturer("7FCTGAAA8PN000001")
+
+	if err != nil || m.Name != "Rivian" {
+		t.Errorf("expected the manufacturer imported in a country without manufacturers, got %+v %v", m, err)
+	}
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ErrVINNotFound is returned when the VIN isn't stored
+var ErrVINNotFound = errors.New("vin not found")
+
+//indexEntry is the record key of a stored VIN, the key is only known once the record has been created or found.
+type indexEntry struct {
+	key   husk.Key
+	known bool
+}
+
+//vinIndex finds stored VINs by their id, and groups them by their Unique (WMI+VDS) prefix, so VINs can be found without scanning the table.
+type vinIndex struct {
+	sync.RWMutex
+	keys   map[string]indexEntry
+	unique map[string]map[string]bool
+	//changes made while the index is rebuilt, they're applied to the rebuilt index before it's published
+	pending []indexChange
+}
+
+//indexChange is a VIN which was added to, or removed from, the index
+type indexChange struct {
+	v      VIN
+	entry  indexEntry
+	remove bool
+}
+
+//rebuildMu serializes rebuilds of the index, so only one of them collects the pending changes
+var rebuildMu sync.Mutex
+
+var index = newVINIndex()
+
+func newVINIndex() *vinIndex {
+	return &vinIndex{
+		keys:   make(map[string]indexEntry),
+		unique: make(map[string]map[string]bool),
+	}
+}
+
+//uniqueOf is the 11 character prefix the index groups on, VINs of other lengths have their own group.
+func uniqueOf(full string) string {
+	if len(full) < 11 {
+		return full
+	}
+
+	return full[:11]
+}
+
+func (i *vinIndex) add(v VIN, entry indexEntry) {
+	i.Lock()
+	defer i.Unlock()
+
+	if i.pending != nil {
+		i.pending = append(i.pending, indexChange{v: v, entry: entry})
+	}
+
+	i.set(v, entry)
+}
+
+//set adds the VIN, the caller has to hold the lock
+func (i *vinIndex) set(v VIN, entry indexEntry) {
+	id := v.id()
+	u := uniqueOf(v.Full)
+	i.keys[id] = entry
+
+	if i.unique[u] == nil {
+		i.unique[u] = make(map[string]bool)
+	}
+
+	i.unique[u][id] = true
+}
+
+func (i *vinIndex) remove(v VIN) {
+	i.Lock()
+	defer i.Unlock()
+
+	if i.pending != nil {
+		i.pending = append(i.pending, indexChange{v: v, remove: true})
+	}
+
+	i.unset(v)
+}
+
+//unset removes the VIN, the caller has to hold the lock
+func (i *vinIndex) unset(v VIN) {
+	id := v.id()
+	u := uniqueOf(v.Full)
+	delete(i.keys, id)
+	delete(i.unique[u], id)
+
+	if len(i.unique[u]) == 0 {
+		delete(i.unique, u)
+	}
+}
+
+func (i *vinIndex) find(id string) (indexEntry, bool) {
+	i.RLock()
+	defer i.RUnlock()
+
+	entry, ok := i.keys[id]
+
+	return entry, ok
+}
+
+//ids returns the VINs which share the Unique prefix
+func (i *vinIndex) ids(unique string) []string {
+	i.RLock()
+	defer i.RUnlock()
+
+	var result []string
+
+	for id := range i.unique[unique] {
+		result = append(result, id)
+	}
+
+	return result
+}
+
+//rebuildIndex indexes every stored VIN, it's only required when the tables are loaded or records were deleted.
+//VINs are indexed while the table is scanned, their changes are applied to the rebuilt index before it replaces the current one.
+func rebuildIndex() {
+	rebuildMu.Lock()
+	defer rebuildMu.Unlock()
+
+	index.collect()
+	idx := newVINIndex()
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		idx.set(*obj.(*VIN), indexEntry{})
+	})
+
+	index.publish(idx)
+	rebuildBloom()
+}
+
+//collect keeps the changes which are made from now on, until a rebuilt index is published
+func (i *vinIndex) collect() {
+	i.Lock()
+	defer i.Unlock()
+
+	i.pending = []indexChange{}
+}
+
+//publish replaces the VINs of the index with the rebuilt ones, after the pending changes were applied to them
+func (i *vinIndex) publish(rebuilt *vinIndex) {
+	i.Lock()
+	defer i.Unlock()
+
+	for _, c := range i.pending {
+		if c.remove {
+			rebuilt.unset(c.v)
+		} else {
+			rebuilt.set(c.v, c.entry)
+		}
+	}
+
+	i.keys = rebuilt.keys
+	i.unique = rebuilt.unique
+	i.pending = nil
+}
+
+//bloomMinimum is the smallest number of VINs the bloom filter is sized for
+var bloomMinimum = 100000
+
+const bloomRate = 0.01
+
+//existing holds every indexed VIN, so Create can skip the lookup for new VINs
+var existing = newBloomFilter(bloomMinimum, bloomRate)
+
+//bloomMu serializes rebuilds of the bloom filter, so only one of them collects the added VINs
+var bloomMu sync.Mutex
+
+//rebuildBloom sizes the bloom filter for twice the indexed VINs, so it only has to grow again after they double.
+//VINs which are indexed while it's filled are added to the rebuilt filter before it replaces the current one.
+func rebuildBloom() {
+	bloomMu.Lock()
+	defer bloomMu.Unlock()
+
+	existing.collect()
+	existing.publish(bloomOf(index))
+}
+
+//bloomOf returns a bloom filter of the VINs in the index
+func bloomOf(idx *vinIndex) *bloomFilter {
+	idx.RLock()
+	defer idx.RUnlock()
+
+	size := 2 * len(idx.keys)
+
+	if size < bloomMinimum {
+		size = bloomMinimum
+	}
+
+	b := newBloomFilter(size, bloomRate)
+
+	for id := range idx.keys {
+		b.set(id)
+	}
+
+	return b
+}
+
+//indexVIN adds the VIN which was just written to the index and the bloom filter
+func indexVIN(v VIN, key husk.Key) {
+	index.add(v, indexEntry{key: key, known: true})
+	existing.add(v.id())
+
+	if existing.full() {
+		rebuildBloom()
+	}
+}
+
+//findVIN returns the record of the VIN, or of the hashed VIN when the serial was purged.
+//VINs which aren't in the index are never read from the table.
+func findVIN(id string) (husk.Recorder, error) {
+	if !existing.mayContain(id) {
+		return nil, ErrVINNotFound
+	}
+
+	entry, ok := index.find(id)
+
+	if !ok {
+		return nil, ErrVINNotFound
+	}
+
+	if entry.known {
+		rec, err := ctx.VIN.FindByKey(entry.key)
+
+		if err == nil && rec.Data().(*VIN).id() == id {
+			return rec, nil
+		}
+	}
+
+	rec, err := ctx.VIN.FindFirst(byID(id))
+
+	if err != nil {
+		return nil, err
+	}
+
+	index.add(*rec.Data().(*VIN), indexEntry{key: rec.GetKey(), known: true})
+
+	return rec, nil
+}
+
+//FindByUnique returns the stored VINs with the same WMI and VDS, for joins against the VDS reference data.
+func FindByUnique(unique string) []VIN {
+	var result []VIN
+
+	for _, id := range index.ids(unique) {
+		rec, err := findVIN(id)
+
+		if err == nil {
+			result = append(result, *rec.Data().(*VIN))
+		}
+	}
+
+	sortVINS(result, SortVIN)
+
+	return result
+}
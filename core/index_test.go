@@ -0,0 +1,70 @@
+package core
+
+import "testing"
+
+func TestVINIndex_Unique(t *testing.T) {
+	idx := newVINIndex()
+	a := VIN{Full: "WDD2050082R123456"}
+	b := VIN{Full: "WDD2050082R654321"}
+	c := VIN{Full: "WVWZZZ1KZ6W000001"}
+	idx.add(a, indexEntry{})
+	idx.add(b, indexEntry{})
+	idx.add(c, indexEntry{})
+
+	if ids := idx.ids("WDD2050082R"); len(ids) != 2 {
+		t.Errorf("expected 2 VINs, got %v", ids)
+	}
+
+	idx.remove(a)
+
+	if _, ok := idx.find(a.Full); ok {
+		t.Error("removed VIN is still indexed")
+	}
+
+	if _, ok := idx.find(b.Full); !ok {
+		t.Error("expected VIN to be indexed")
+	}
+}
+
+func TestVINIndex_Hashed(t *testing.T) {
+	idx := newVINIndex()
+	a := VIN{Full: "WDD2050082R******", HashedVIN: "a1"}
+	b := VIN{Full: "WDD2050082R******", HashedVIN: "b2"}
+	idx.add(a, indexEntry{})
+	idx.add(b, indexEntry{})
+
+	if ids := idx.ids("WDD2050082R"); len(ids) != 2 {
+		t.Errorf("expected 2 VINs, got %v", ids)
+	}
+
+	if _, ok := idx.find(a.Full); ok {
+		t.Error("purged VINs must only be found by their hash")
+	}
+}
+
+func TestVINIndex_Publish(t *testing.T) {
+	idx := newVINIndex()
+	a := VIN{Full: "WDD2050082R123456"}
+	b := VIN{Full: "WDD2050082R654321"}
+	idx.add(a, indexEntry{})
+
+	//b is created, and a deleted, while the table is scanned
+	idx.collect()
+	rebuilt := newVINIndex()
+	rebuilt.set(a, indexEntry{})
+	idx.add(b, indexEntry{})
+	idx.remove(a)
+	idx.publish(rebuilt)
+
+	if _, ok := idx.find(b.Full); !ok {
+		t.Error("expected the VIN which was added during the rebuild to be indexed")
+	}
+
+	if _, ok := idx.find(a.Full); ok {
+		t.Error("expected the VIN which was removed during the rebuild to be removed")
+	}
+
+	if idx.pending != nil {
+		t.Error("expected changes to no longer be collected")
+	}
+}
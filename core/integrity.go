@@ -0,0 +1,150 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/louisevanderlith/husk"
+)
+
+const (
+	DanglingSeries   = "dangling-series"
+	MissingPlatform  = "missing-platform"
+	OverlappingYears = "overlapping-years"
+	InvalidYears     = "invalid-years" //The range ends before it starts
+	OrphanedBody     = "orphaned-body"
+)
+
+//IntegrityIssue is a single problem found in the reference data
+type IntegrityIssue struct {
+	Kind      string
+	Reference string
+	Message   string
+}
+
+//IntegrityReport is the result of CheckIntegrity
+type IntegrityReport struct {
+	Manufacturers int
+	Series        int
+	VDSRecords    int
+	Issues        []IntegrityIssue
+}
+
+func (r *IntegrityReport) add(kind, ref, msg string, args ...interface{}) {
+	r.Issues = append(r.Issues, IntegrityIssue{
+		Kind:      kind,
+		Reference: ref,
+		Message:   fmt.Sprintf(msg, args...),
+	})
+}
+
+//CheckIntegrity scans the reference tables for references that can't be resolved, and year ranges that contradict each other.
+func CheckIntegrity() IntegrityReport {
+	result := IntegrityReport{}
+	series := make(map[string][]Series)
+
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		result.Manufacturers++
+		result.checkManufacturer(m)
+
+		for _, plant := range m.AssemblyPlants {
+			series[m.WMICode] = append(series[m.WMICode], plant.Series...)
+		}
+	})
+
+	var records []VDSRecord
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		records = append(records, *obj.(*VDSRecord))
+	})
+
+	result.checkVDS(records, series)
+
+	return result
+}
+
+//plantSeries is a Series, with the plant it's built in
+type plantSeries struct {
+	Series
+	plant string
+}
+
+//checkManufacturer reports the series without a platform, and the year ranges of the plants and series.
+//A spec may be built in several plants at once, but not twice in the same plant or on another platform in the same years.
+func (r *IntegrityReport) checkManufacturer(m Manufacturer) {
+	var all []plantSeries
+
+	for _, plant := range m.AssemblyPlants {
+		if invalidYears(plant.StartYear, plant.EndYear) {
+			r.add(InvalidYears, fmt.Sprintf("%s/%s", m.WMICode, plant.Code), "plant opens in %d after it closes in %d", plant.StartYear, plant.EndYear)
+		}
+
+		for _, s := range plant.Series {
+			r.Series++
+			ref := fmt.Sprintf("%s/%s/%s", m.WMICode, plant.Code, s.Spec)
+
+			if len(s.Platform.Code) == 0 {
+				r.add(MissingPlatform, ref, "series has no platform")
+
+				if len(s.Platform.Body.Code) > 0 {
+					r.add(OrphanedBody, ref, "body %s is not on a platform", s.Platform.Body.Code)
+				}
+			}
+
+			if invalidYears(s.StartYear, s.EndYear) {
+				r.add(InvalidYears, ref, "starts in %d after it ends in %d", s.StartYear, s.EndYear)
+				continue
+			}
+
+			for _, o := range all {
+				if o.Spec != s.Spec || s.StartYear > o.EndYear || o.StartYear > s.EndYear {
+					continue
+				}
+
+				if o.plant == plant.Code {
+					r.add(OverlappingYears, ref, "overlaps %d-%d of the same spec", o.StartYear, o.EndYear)
+				} else if o.Platform.Code != s.Platform.Code {
+					r.add(OverlappingYears, ref, "overlaps %d-%d of the same spec on platform %s in plant %s", o.StartYear, o.EndYear, o.Platform.Code, o.plant)
+				}
+			}
+
+			all = append(all, plantSeries{Series: s, plant: plant.Code})
+		}
+	}
+}
+
+//checkVDS reports the records of series which don't exist, and the year ranges which contradict each other
+func (r *IntegrityReport) checkVDS(records []VDSRecord, series map[string][]Series) {
+	for i, rec := range records {
+		r.VDSRecords++
+		ref := fmt.Sprintf("%s%s", rec.WMICode, rec.Code)
+
+		if !hasSeries(series[rec.WMICode], rec.SeriesSpec) {
+			r.add(DanglingSeries, ref, "series %s doesn't exist for %s", rec.SeriesSpec, rec.WMICode)
+		}
+
+		if invalidYears(rec.StartYear, rec.EndYear) {
+			r.add(InvalidYears, ref, "starts in %d after it ends in %d", rec.StartYear, rec.EndYear)
+			continue
+		}
+
+		for _, o := range records[i+1:] {
+			if o.WMICode == rec.WMICode && o.Code == rec.Code && o.Market == rec.Market && !invalidYears(o.StartYear, o.EndYear) && rec.overlaps(o) {
+				r.add(OverlappingYears, ref, "%d-%d overlaps %d-%d", rec.StartYear, rec.EndYear, o.StartYear, o.EndYear)
+			}
+		}
+	}
+}
+
+//invalidYears returns true when the range ends before it starts, ranges without an end are open
+func invalidYears(start, end int) bool {
+	return end != 0 && start > end
+}
+
+func hasSeries(series []Series, spec string) bool {
+	for _, s := range series {
+		if s.Spec == spec {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package core
+
+import "testing"
+
+func issueKinds(r IntegrityReport) map[string]int {
+	result := make(map[string]int)
+
+	for _, issue := range r.Issues {
+		result[issue.Kind]++
+	}
+
+	return result
+}
+
+func TestIntegrity_InvalidYears(t *testing.T) {
+	r := IntegrityReport{}
+	r.checkManufacturer(Manufacturer{
+		WMICode: "WBA",
+		AssemblyPlants: []AssemblyPlant{
+			{Code: "A", StartYear: 2012, EndYear: 2005, Series: []Series{
+				{Spec: "320i", Platform: Platform{Code: "F30"}, StartYear: 2016, EndYear: 2012},
+				{Spec: "320i", Platform: Platform{Code: "F30"}, StartYear: 2012, EndYear: 2019},
+			}},
+		},
+	})
+	r.checkVDS([]VDSRecord{{WMICode: "WBA", Code: "8E", SeriesSpec: "320i", StartYear: 2019, EndYear: 2012}}, map[string][]Series{"WBA": {{Spec: "320i"}}})
+
+	kinds := issueKinds(r)
+
+	if kinds[InvalidYears] != 3 || kinds[OverlappingYears] != 0 {
+		t.Errorf("expected the plant, series and VDS record to have invalid years, got %+v", r.Issues)
+	}
+}
+
+func TestIntegrity_OverlappingSeries(t *testing.T) {
+	r := IntegrityReport{}
+	r.checkManufacturer(Manufacturer{
+		WMICode: "WBA",
+		AssemblyPlants: []AssemblyPlant{
+			{Code: "A", Series: []Series{
+				{Spec: "320i", Platform: Platform{Code: "F30"}, StartYear: 2012, EndYear: 2019},
+				{Spec: "320i", Platform: Platform{Code: "F30"}, StartYear: 2018, EndYear: 2022},
+			}},
+			//Built in another plant on the same platform at the same time is fine
+			{Code: "E", Series: []Series{
+				{Spec: "320i", Platform: Platform{Code: "F30"}, StartYear: 2012, EndYear: 2019},
+				{Spec: "330i", Platform: Platform{Code: "F30"}, StartYear: 2012, EndYear: 2019},
+			}},
+			//On another platform in the same years contradicts the other plants
+			{Code: "K", Series: []Series{
+				{Spec: "330i", Platform: Platform{Code: "G20"}, StartYear: 2019, EndYear: 2022},
+			}},
+		},
+	})
+
+	if kinds := issueKinds(r); kinds[OverlappingYears] != 2 || len(r.Issues) != 2 {
+		t.Errorf("expected the overlap in plant A and the platform of 330i in plant K, got %+v", r.Issues)
+	}
+
+	if r.Series != 5 {
+		t.Errorf("expected 5 series, got %d", r.Series)
+	}
+}
+
+func TestIntegrity_VDS(t *testing.T) {
+	r := IntegrityReport{}
+	r.checkVDS([]VDSRecord{
+		{WMICode: "WBA", Code: "8E", SeriesSpec: "320i", StartYear: 2012, EndYear: 2016},
+		{WMICode: "WBA", Code: "8E", SeriesSpec: "320i", StartYear: 2015, EndYear: 2019},
+		{WMICode: "WBA", Code: "8E", SeriesSpec: "320i", Market: "EU", StartYear: 2015, EndYear: 2019},
+		{WMICode: "WBA", Code: "8F", SeriesSpec: "M3", StartYear: 2012, EndYear: 2016},
+	}, map[string][]Series{"WBA": {{Spec: "320i"}}})
+
+	kinds := issueKinds(r)
+
+	if kinds[OverlappingYears] != 1 || kinds[DanglingSeries] != 1 || r.VDSRecords != 4 {
+		t.Errorf("expected one overlap and one dangling series, got %+v", r.Issues)
+	}
+}
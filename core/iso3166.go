@@ -0,0 +1,115 @@
+package core
+
+import "strings"
+
+//isoCountry is the ISO 3166-1 alpha-2 and alpha-3 codes of a country
+type isoCountry struct {
+	Alpha2 string
+	Alpha3 string
+}
+
+//isoCountries are the codes of the country names in the reference data, by name.
+//The names are kept as they were seeded, misspellings included. Eastern Germany and the USSR have their withdrawn codes of ISO 3166-3.
+var isoCountries = map[string]isoCountry{
+	"Angola":            {"AO", "AGO"},
+	"Argentina":         {"AR", "ARG"},
+	"Australia":         {"AU", "AUS"},
+	"Austria":           {"AT", "AUT"},
+	"Belarus":           {"BY", "BLR"},
+	"Belgium":           {"BE", "BEL"},
+	"Benin":             {"BJ", "BEN"},
+	"Brazil":            {"BR", "BRA"},
+	"Bulgaria":          {"BG", "BGR"},
+	"Canada":            {"CA", "CAN"},
+	"Chile":             {"CL", "CHL"},
+	"China":             {"CN", "CHN"},
+	"Colombia":          {"CO", "COL"},
+	"Croatia":           {"HR", "HRV"},
+	"Czech Republic":    {"CZ", "CZE"},
+	"Denmark":           {"DK", "DNK"},
+	"Eastern Germany":   {"DD", "DDR"},
+	"Ecuador":           {"EC", "ECU"},
+	"Egypt":             {"EG", "EGY"},
+	"Estonia":           {"EE", "EST"},
+	"Finland":           {"FI", "FIN"},
+	"France":            {"FR", "FRA"},
+	"Germany":           {"DE", "DEU"},
+	"Ghana":             {"GH", "GHA"},
+	"Greece":            {"GR", "GRC"},
+	"Hungary":           {"HU", "HUN"},
+	"India":             {"IN", "IND"},
+	"Indonesia":         {"ID", "IDN"},
+	"Iran":              {"IR", "IRN"},
+	"Ireland":           {"IE", "IRL"},
+	"Isreal":            {"IL", "ISR"},
+	"Italy":             {"IT", "ITA"},
+	"Ivory Coast":       {"CI", "CIV"},
+	"Japan":             {"JP", "JPN"},
+	"Kazakhstan":        {"KZ", "KAZ"},
+	"Kenya":             {"KE", "KEN"},
+	"Latvia":            {"LV", "LVA"},
+	"Lithuania":         {"LT", "LTU"},
+	"Luxembourg":        {"LU", "LUX"},
+	"Madagascar":        {"MG", "MDG"},
+	"Malaysia":          {"MY", "MYS"},
+	"Malta":             {"MT", "MLT"},
+	"Mexico":            {"MX", "MEX"},
+	"Morocco":           {"MA", "MAR"},
+	"Mozambique":        {"MZ", "MOZ"},
+	"Myanmar":           {"MM", "MMR"},
+	"Netherlands":       {"NL", "NLD"},
+	"New Zealand":       {"NZ", "NZL"},
+	"Nigeria":           {"NG", "NGA"},
+	"Norway":            {"NO", "NOR"},
+	"Pakistan":          {"PK", "PAK"},
+	"Paraguay":          {"PY", "PRY"},
+	"Peru":              {"PE", "PER"},
+	"Philippenes":       {"PH", "PHL"},
+	"Poland":            {"PL", "POL"},
+	"Portugal":          {"PT", "PRT"},
+	"Romania":           {"RO", "ROU"},
+	"Russia":            {"RU", "RUS"},
+	"Saudi Arabia":      {"SA", "SAU"},
+	"Serbia":            {"RS", "SRB"},
+	"Singapore":         {"SG", "SGP"},
+	"Slovakia":          {"SK", "SVK"},
+	"Slovenia":          {"SI", "SVN"},
+	"South Africa":      {"ZA", "ZAF"},
+	"South Korea":       {"KR", "KOR"},
+	"Spain":             {"ES", "ESP"},
+	"Sri Lanka":         {"LK", "LKA"},
+	"Sweden":            {"SE", "SWE"},
+	"Switzerland":       {"CH", "CHE"},
+	"Taiwan":            {"TW", "TWN"},
+	"Tanzania":          {"TZ", "TZA"},
+	"Thailand":          {"TH", "THA"},
+	"Trinidad & Tobago": {"TT", "TTO"},
+	"Tunisia":           {"TN", "TUN"},
+	"Turkey":            {"TR", "TUR"},
+	"UAE":               {"AE", "ARE"},
+	"USSR":              {"SU", "SUN"},
+	"Ukraine":           {"UA", "UKR"},
+	"United Kingdom":    {"GB", "GBR"},
+	"United States":     {"US", "USA"},
+	"Uruguay":           {"UY", "URY"},
+	"Venezuela":         {"VE", "VEN"},
+	"Vietnam":           {"VN", "VNM"},
+	"Zambia":            {"ZM", "ZMB"},
+}
+
+//CountryCodes returns the ISO 3166-1 alpha-2 and alpha-3 codes of the country name in the reference data, ie. DE and DEU for Germany
+func CountryCodes(name string) (alpha2, alpha3 string, ok bool) {
+	name = strings.TrimSpace(name)
+
+	if v, ok := isoCountries[name]; ok {
+		return v.Alpha2, v.Alpha3, true
+	}
+
+	for k, v := range isoCountries {
+		if strings.EqualFold(k, name) {
+			return v.Alpha2, v.Alpha3, true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,26 @@
+package core
+
+import "testing"
+
+func TestCountryCodes(t *testing.T) {
+	cases := map[string][2]string{
+		"Germany":         {"DE", "DEU"},
+		"united states":   {"US", "USA"},
+		"South Africa":    {"ZA", "ZAF"},
+		" South Korea ":   {"KR", "KOR"},
+		"Isreal":          {"IL", "ISR"},
+		"Eastern Germany": {"DD", "DDR"},
+	}
+
+	for name, expect := range cases {
+		alpha2, alpha3, ok := CountryCodes(name)
+
+		if !ok || alpha2 != expect[0] || alpha3 != expect[1] {
+			t.Errorf("%s: expected %v, got %s %s", name, expect, alpha2, alpha3)
+		}
+	}
+
+	if _, _, ok := CountryCodes("Not Assigned"); ok {
+		t.Error("expected no codes for Not Assigned")
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+import "github.com/louisevanderlith/husk"
+
+//Key identifies a stored record, so callers don't need to depend on husk to find it
+type Key struct {
+	key husk.Key
+}
+
+//ParseKey reads a Key from its String form
+func ParseKey(k string) (Key, error) {
+	key, err := husk.ParseKey(k)
+
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{key: key}, nil
+}
+
+func (k Key) String() string {
+	return k.key.String()
+}
+
+//KeyOf wraps a husk key, for keys which arrive from droxolite requests
+func KeyOf(key husk.Key) Key {
+	return Key{key: key}
+}
@@ -0,0 +1,40 @@
+package core
+
+import "testing"
+
+func TestParseKey(t *testing.T) {
+	rec, err := VIN{Full: "YV1MS382972000001", Unique: "YV1MS382972", Serial: 1}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := KeyOf(rec.GetKey())
+	parsed, err := ParseKey(key.String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if parsed != key {
+		t.Errorf("expected %s, got %s", key, parsed)
+	}
+
+	found, err := GetVIN(parsed)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Full != "YV1MS382972000001" {
+		t.Errorf("expected the VIN of the key, got %s", found.Full)
+	}
+}
+
+func TestParseKey_Invalid(t *testing.T) {
+	_, err := ParseKey("YV1MS382972000001")
+
+	if err == nil {
+		t.Error("expected a VIN not to be a key")
+	}
+}
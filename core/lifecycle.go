@@ -0,0 +1,77 @@
+package core
+
+import (
+	"errors"
+	"sync"
+)
+
+//ErrClosed is returned by writes after Close has been called
+var ErrClosed = errors.New("vin store has been closed")
+
+var (
+	lifecycle sync.RWMutex
+	closed    bool
+	closeOnce sync.Once
+	stoppers  []func()
+	//snapshot is held shared by writes, and exclusively by read transactions. See View
+	snapshot sync.RWMutex
+)
+
+//OnClose registers a background job's stop function, which is called by Close before the tables are saved.
+func OnClose(stop func()) {
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+
+	stoppers = append(stoppers, stop)
+}
+
+//writing must be held for the duration of a write, so that Close waits for it to finish.
+//The returned func releases it.
+func writing() (func(), error) {
+	lifecycle.RLock()
+
+	if closed {
+		lifecycle.RUnlock()
+		return nil, ErrClosed
+	}
+
+	snapshot.RLock()
+
+	return func() {
+		snapshot.RUnlock()
+		lifecycle.RUnlock()
+	}, nil
+}
+
+//Close stops background jobs, waits for writes in progress and saves every table.
+//Writes fail with ErrClosed afterwards. It's safe to call Close more than once.
+func Close() {
+	closeOnce.Do(func() {
+		lifecycle.RLock()
+		jobs := stoppers
+		lifecycle.RUnlock()
+
+		for _, stop := range jobs {
+			stop()
+		}
+
+		lifecycle.Lock()
+		defer lifecycle.Unlock()
+
+		closed = true
+		ctx.Regions.Save()
+		ctx.VIN.Save()
+		ctx.Staging.Save()
+		ctx.VDS.Save()
+		ctx.Quarantine.Save()
+		ctx.Serials.Save()
+		ctx.TypeApprovals.Save()
+		ctx.Warranties.Save()
+		ctx.Services.Save()
+		ctx.Wheels.Save()
+		ctx.Overrides.Save()
+		ctx.Disputes.Save()
+		ctx.Recalls.Save()
+		ctx.Hulls.Save()
+	})
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+//reopen undoes Close, so the tests after it can write
+func reopen() {
+	lifecycle.Lock()
+	defer lifecycle.Unlock()
+
+	closed = false
+	stoppers = nil
+	closeOnce = sync.Once{}
+}
+
+func TestClose(t *testing.T) {
+	defer reopen()
+
+	stopped := make(chan struct{})
+	OnClose(func() { close(stopped) })
+
+	done, err := writing()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closing := make(chan struct{})
+
+	go func() {
+		Close()
+		close(closing)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected the background job to be stopped")
+	}
+
+	select {
+	case <-closing:
+		t.Fatal("expected Close to wait for the write")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	done()
+
+	select {
+	case <-closing:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to return after the write")
+	}
+
+	_, err = VIN{Full: "VF1RFB00X56000001", Unique: "VF1RFB00X56", Serial: 1}.Create()
+
+	if err != ErrClosed {
+		t.Errorf("expected writes to fail after Close, got %v", err)
+	}
+
+	//Close is safe to call again
+	Close()
+}
@@ -1,6 +1,12 @@
 package core
 
-import "github.com/louisevanderlith/husk"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
 
 type VehicleType int
 
@@ -13,6 +19,7 @@ const (
 	LSV // Low speed vehicle
 	ATV
 	Incomplete
+	Bus
 )
 
 var vehTypes = [...]string{
@@ -23,12 +30,23 @@ var vehTypes = [...]string{
 	"Trailer",
 	"LSV",
 	"ATV",
-	"Incomplete"}
+	"Incomplete",
+	"Bus"}
 
 func (s VehicleType) String() string {
 	return vehTypes[s]
 }
 
+//ratedByWeight returns true for the vehicle types FMVSS 565 requires the GVWR and brakes in the VDS of
+func ratedByWeight(vehicleType string) bool {
+	switch vehicleType {
+	case Truck.String(), MPV.String(), Incomplete.String(), Bus.String():
+		return true
+	}
+
+	return false
+}
+
 type Manufacturer struct {
 	WMICode        string
 	Name           string
@@ -40,3 +58,46 @@ type Manufacturer struct {
 func (m Manufacturer) Valid() (bool, error) {
 	return husk.ValidateStruct(&m)
 }
+
+//FindManufacturer returns the Manufacturer registered for the WMI of the given VIN
+func FindManufacturer(uniquevin string) (*Manufacturer, error) {
+	region, err := GetRegionByCode(uniquevin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	regionCode := uniquevin[:1]
+	countryCode := uniquevin[1:2]
+	wmi := uniquevin[:3]
+
+	for i := 0; i < len(region.Countries); i++ {
+		country := region.Countries[i]
+
+		if country.RegionCode != regionCode || !country.HasCode(countryCode) {
+			continue
+		}
+
+		for j := 0; j < len(country.Manufacturers); j++ {
+			manufacturer := country.Manufacturers[j]
+
+			if len(manufacturer.WMICode) > 0 && strings.HasPrefix(wmi, manufacturer.WMICode) {
+				return &manufacturer, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no manufacturer found for %s", wmi)
+}
+
+//KnownWMIs returns the WMI codes of every manufacturer in the reference data, sorted.
+func KnownWMIs() []string {
+	var result []string
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		result = append(result, m.WMICode)
+	})
+
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+//NegativeTTL is how long lookups which found nothing are remembered, so decodes of unknown manufacturers don't hit the store every time.
+//Imports and edits of the reference data clear the cache. 0 disables it.
+var NegativeTTL = time.Minute
+
+type negativeEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+//negativeCache remembers lookups which found nothing
+type negativeCache struct {
+	mu      sync.Mutex
+	entries map[string]negativeEntry
+}
+
+var notFound = &negativeCache{}
+
+func (c *negativeCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+func (c *negativeCache) put(key string, value interface{}) {
+	if NegativeTTL <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]negativeEntry)
+	}
+
+	c.entries[key] = negativeEntry{value: value, expires: time.Now().Add(NegativeTTL)}
+}
+
+//reset is called when the reference data changes
+func (c *negativeCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNegativeCache(t *testing.T) {
+	defer func(ttl time.Duration) { NegativeTTL = ttl }(NegativeTTL)
+
+	c := &negativeCache{}
+	c.put("wmi:XXX", "missing")
+
+	if v, ok := c.get("wmi:XXX"); !ok || v != "missing" {
+		t.Error("expected the miss to be cached")
+	}
+
+	c.reset()
+
+	if _, ok := c.get("wmi:XXX"); ok {
+		t.Error("expected reset to clear the cache")
+	}
+
+	NegativeTTL = time.Millisecond
+	c.put("wmi:XXX", "missing")
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.get("wmi:XXX"); ok {
+		t.Error("expected the miss to expire")
+	}
+
+	NegativeTTL = 0
+	c.put("wmi:XXX", "missing")
+
+	if _, ok := c.get("wmi:XXX"); ok {
+		t.Error("expected nothing to be cached when the TTL is 0")
+	}
+}
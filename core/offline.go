@@ -0,0 +1,47 @@
+package core
+
+import (
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//ParseOffline validates the VIN and returns everything that can be learned from the characters alone;
+//the segments, possible years of manufacture and the region. It never touches the datastore.
+func ParseOffline(fullvin string) (*VIN, error) {
+	fullvin = vinmath.Canonical(fullvin)
+	err := ValidateVIN(fullvin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vin := &VIN{
+		Full: fullvin,
+	}
+
+	err = vin.parse()
+
+	if err != nil {
+		return nil, err
+	}
+
+	vin.WMInfo.Region = vinmath.Region(fullvin[:1])
+
+	return vin, nil
+}
+
+//parse populates the fields which only depend on the characters of the VIN
+func (m *VIN) parse() error {
+	m.Unique, m.Serial = vinmath.UniqueSerial(m.Full)
+	m.VDSInfo = vds.VDSInfo{Code: vinmath.Segment(m.Full).VDS}
+
+	years, err := vinmath.Years(m.Full[9:10])
+
+	if err != nil {
+		return err
+	}
+
+	m.Years = years
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//ProvenanceOverride marks the fields of a VIN which were set by an Override
+const ProvenanceOverride = "manual override"
+
+//ErrOverrideNotFound is returned when the VIN has no Override
+var ErrOverrideNotFound = errors.New("override not found")
+
+//overrideFields are the fields an Override can pin, by name
+var overrideFields = map[string]func(m *VIN, value string) error{
+	"manufacturer": func(m *VIN, value string) error { m.WMInfo.Manufacturer = value; return nil },
+	"country":      func(m *VIN, value string) error { m.WMInfo.Country = value; return nil },
+	"vehicletype":  func(m *VIN, value string) error { m.WMInfo.VehicleType = value; return nil },
+	"vehicleclass": func(m *VIN, value string) error { m.WMInfo.VehicleClass = value; return nil },
+	"bodystyle":    func(m *VIN, value string) error { m.VDSInfo.BodyStyle = value; return nil },
+	"chassis":      func(m *VIN, value string) error { m.VDSInfo.Chassis = value; return nil },
+	"plant":        func(m *VIN, value string) error { m.VDSInfo.Plant = value; return nil },
+	"plantcountry": func(m *VIN, value string) error { m.PlantCountry = value; return nil },
+	"steering": func(m *VIN, value string) error {
+		value = strings.ToUpper(value)
+
+		if value != vds.LHD && value != vds.RHD {
+			return fmt.Errorf("steering %s is not %s or %s", value, vds.LHD, vds.RHD)
+		}
+
+		m.VDSInfo.SteeringPosition = value
+		return nil
+	},
+	"series": func(m *VIN, value string) error { m.Series.Spec = value; return nil },
+	"fuel":   func(m *VIN, value string) error { m.Series.Platform.Engine.FuelType = value; return nil },
+	"year": func(m *VIN, value string) error {
+		year, err := strconv.Atoi(value)
+
+		if err != nil {
+			return fmt.Errorf("year %s is not a number", value)
+		}
+
+		m.Years = []int{year}
+		return nil
+	},
+}
+
+//Override pins corrected fields of a VIN which the reference data decodes wrong, ie. factory special editions.
+type Override struct {
+	Full      string `hsk:"size(17)"`
+	Fields    map[string]string
+	Reason    string
+	UpdatedAt time.Time
+	Checksum  string //Hash of the content, see VerifyIntegrity
+}
+
+func (m Override) Valid() (bool, error) {
+	if len(m.Fields) == 0 {
+		return false, errors.New("override has no fields")
+	}
+
+	err := m.apply(&VIN{})
+
+	if err != nil {
+		return false, err
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+//apply sets the fields of the VIN, their provenance is marked as ProvenanceOverride
+func (m Override) apply(v *VIN) error {
+	var names []string
+
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		set, ok := overrideFields[strings.ToLower(name)]
+
+		if !ok {
+			return fmt.Errorf("%s can't be overridden", name)
+		}
+
+		err := set(v, m.Fields[name])
+
+		if err != nil {
+			return err
+		}
+
+		if v.Provenance == nil {
+			v.Provenance = make(map[string]string)
+		}
+
+		v.Provenance[strings.ToLower(name)] = ProvenanceOverride
+	}
+
+	return nil
+}
+
+//applyOverride is the last step of a decode
+func applyOverride(m *VIN) error {
+	if ctx.Overrides == nil {
+		return nil
+	}
+
+	rec, err := ctx.Overrides.FindFirst(byOverride(m.Full))
+
+	if err != nil {
+		return nil
+	}
+
+	return rec.Data().(*Override).apply(m)
+}
+
+//SetOverride adds or replaces the Override of the VIN, the stored record is corrected as well.
+func SetOverride(o Override) error {
+	o.Full = vinmath.Canonical(o.Full)
+	o.UpdatedAt = time.Now()
+
+	if ok, err := o.Valid(); !ok {
+		return err
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+	defer ctx.Overrides.Save()
+
+	unlock := vinLocks.lock(o.Full)
+	defer unlock()
+
+	o.Checksum = o.checksum()
+	rec, err := ctx.Overrides.FindFirst(byOverride(o.Full))
+
+	if err == nil {
+		err = rec.Set(o)
+
+		if err != nil {
+			return err
+		}
+
+		err = ctx.Overrides.Update(rec)
+	} else {
+		err = ctx.Overrides.Create(o).Error
+	}
+
+	if err != nil {
+		return err
+	}
+
+	stored, err := findVIN(o.Full)
+
+	if err != nil {
+		return nil
+	}
+
+	prev := *stored.Data().(*VIN)
+	obj := prev
+	err = o.apply(&obj)
+
+	if err != nil {
+		return err
+	}
+
+	obj.Version++
+	obj.seal()
+
+	err = stored.Set(obj)
+
+	if err != nil {
+		return err
+	}
+
+	defer ctx.VIN.Save()
+	err = ctx.VIN.Update(stored)
+
+	if err != nil {
+		return err
+	}
+
+	stats.replace(prev, obj)
+
+	return nil
+}
+
+//GetOverride returns the Override of the VIN
+func GetOverride(fullvin string) (*Override, error) {
+	rec, err := ctx.Overrides.FindFirst(byOverride(vinmath.Canonical(fullvin)))
+
+	if err != nil {
+		return nil, ErrOverrideNotFound
+	}
+
+	return rec.Data().(*Override), nil
+}
+
+//GetAllOverrides returns a page of the overrides
+func GetAllOverrides(page, size int) husk.Collection {
+	return ctx.Overrides.Find(page, size, husk.Everything())
+}
+
+//RemoveOverride deletes the Override, VINs decoded afterwards use the reference data again.
+func RemoveOverride(fullvin string) error {
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	rec, err := ctx.Overrides.FindFirst(byOverride(vinmath.Canonical(fullvin)))
+
+	if err != nil {
+		return ErrOverrideNotFound
+	}
+
+	defer ctx.Overrides.Save()
+	return ctx.Overrides.Delete(rec.GetKey())
+}
+
+type overrideFilter func(obj *Override) bool
+
+func (f overrideFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*Override))
+}
+
+func byOverride(fullvin string) overrideFilter {
+	return func(obj *Override) bool {
+		return obj.Full == fullvin
+	}
+}
@@ -0,0 +1,44 @@
+package core
+
+import "testing"
+
+func TestOverride_Apply(t *testing.T) {
+	v := VIN{Years: []int{2015, 2016}}
+	v.Series.Spec = "F30"
+
+	o := Override{Full: "WBA8E9G50GNT00001", Fields: map[string]string{"Series": "F80 M3 CS", "year": "2018"}}
+
+	ok, err := o.Valid()
+
+	if !ok {
+		t.Fatal(err)
+	}
+
+	err = o.apply(&v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Series.Spec != "F80 M3 CS" || v.ModelYear() != 2018 || len(v.Years) != 1 {
+		t.Errorf("fields weren't overridden %+v", v)
+	}
+
+	if v.Provenance["series"] != ProvenanceOverride || v.Provenance["year"] != ProvenanceOverride {
+		t.Errorf("expected the provenance to be marked, got %v", v.Provenance)
+	}
+}
+
+func TestOverride_Invalid(t *testing.T) {
+	invalid := []Override{
+		{Full: "WBA8E9G50GNT00001"},
+		{Full: "WBA8E9G50GNT00001", Fields: map[string]string{"colour": "red"}},
+		{Full: "WBA8E9G50GNT00001", Fields: map[string]string{"year": "new"}},
+	}
+
+	for _, o := range invalid {
+		if ok, _ := o.Valid(); ok {
+			t.Errorf("expected %v to be invalid", o.Fields)
+		}
+	}
+}
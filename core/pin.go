@@ -0,0 +1,74 @@
+package core
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//ClassPIN is the Class of ISO 10261 PINs of agricultural and off-road equipment
+const ClassPIN = "PIN"
+
+//pinSteps are the decodeSteps which apply to PINs, they have no year code and the VDS analyzers don't know the MDS.
+var pinSteps = stepsNamed("wmi")
+
+//BuildInfoMode is BuildInfo for the identifiers of the validation mode, PINs are decoded when the mode is vinmath.Agricultural.
+func BuildInfoMode(fullvin string, mode vinmath.Mode) (*VIN, error) {
+	if mode != vinmath.Agricultural {
+		return BuildInfo(fullvin)
+	}
+
+	pin, err := newPIN(fullvin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	raise(EventDecoded, *pin)
+
+	return pin, nil
+}
+
+//newPIN decodes the manufacturer of the PIN, the serial is the Machine Indicator Section when it's numeric.
+func newPIN(fullpin string) (*VIN, error) {
+	fullpin = vinmath.Canonical(fullpin)
+	err := vinmath.ValidatePIN(fullpin)
+
+	if err != nil {
+		return nil, err
+	}
+
+	seg := vinmath.SegmentPIN(fullpin)
+	serial, _ := strconv.Atoi(seg.MIS)
+
+	pin := &VIN{
+		Full:   fullpin,
+		Class:  ClassPIN,
+		Unique: seg.WMI + seg.MDS + seg.CheckChar,
+		Serial: serial,
+	}
+
+	err = runSteps(context.Background(), pin, pinSteps)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pin, applyOverride(pin)
+}
+
+//stepsNamed returns the decodeSteps with the names
+func stepsNamed(names ...string) []decodeStep {
+	var result []decodeStep
+
+	for _, s := range decodeSteps {
+		for _, name := range names {
+			if s.name == name {
+				result = append(result, s)
+			}
+		}
+	}
+
+	return result
+}
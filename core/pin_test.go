@@ -0,0 +1,15 @@
+package core
+
+import "testing"
+
+func TestPINSteps_NoYear(t *testing.T) {
+	if len(pinSteps) != 1 || pinSteps[0].name != "wmi" {
+		t.Fatalf("expected only the wmi step, got %v", pinSteps)
+	}
+
+	for _, s := range pinSteps {
+		if len(s.after) > 0 {
+			t.Errorf("%s depends on steps which don't run for PINs", s.name)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//decodeStep is one part of deconstruct. It only runs after the steps it depends on succeeded,
+//and may only set fields of the VIN which no other step sets. Caveats are reported with warn.
+//A step runs on a copy of the VIN, apply copies the fields it sets to the result.
+type decodeStep struct {
+	name  string
+	stage string //Budgeted stage of the step, see DecodeBudget
+	after []string
+	run   func(m *VIN, warn func(Warning)) error
+	apply func(dst, src *VIN)
+}
+
+//decodeSteps are the steps of deconstruct, steps which don't depend on each other run at the same time.
+var decodeSteps = []decodeStep{
+	{name: "year", run: func(m *VIN, warn func(Warning)) error {
+		years, err := vinmath.Years(m.Full[9:10])
+
+		if err != nil {
+			return undecodableError{err}
+		}
+
+		m.Years = years
+
+		if len(years) > 1 {
+			warn(newWarning(WarnAmbiguousYear, "year code %s could be any of %v", m.Full[9:10], years))
+		}
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		dst.Years = src.Years
+	}},
+	{name: "checkdigit", run: func(m *VIN, warn func(Warning)) error {
+		if check := vinmath.CheckDigit(m.Full); m.Full[8:9] != check {
+			warn(newWarning(WarnCheckDigit, "check digit %s should be %s", m.Full[8:9], check))
+		}
+
+		return nil
+	}},
+	{name: "wmi", stage: StageStore, run: func(m *VIN, warn func(Warning)) error {
+		wmiInfo, err := FindWMInfo(m.Unique)
+
+		if err != nil {
+			return err
+		}
+
+		m.WMInfo = wmiInfo
+
+		if len(wmiInfo.Suggestions) > 0 {
+			warn(newWarning(WarnFuzzyWMI, "no manufacturer for %s, similar WMIs %v", m.Unique[:3], wmiInfo.Suggestions))
+		}
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		dst.WMInfo = src.WMInfo
+	}},
+	{name: "vds", stage: StagePlugin, after: []string{"year", "wmi"}, run: func(m *VIN, warn func(Warning)) error {
+		vdsInfo, err := vds.FindVDSInfo(m.WMInfo.Manufacturer, m.Unique, m.Years)
+
+		if err != nil {
+			return undecodableError{err}
+		}
+
+		m.VDSInfo = *vdsInfo
+
+		if !vds.HasAnalyzer(m.WMInfo.Manufacturer) {
+			warn(newWarning(WarnRawVDS, "no VDS analyzer for %s, only the raw VDS %s is kept", m.WMInfo.Manufacturer, m.VDSInfo.Raw))
+		}
+
+		if !ratedByWeight(m.WMInfo.Class()) {
+			m.VDSInfo.Truck = nil
+		}
+
+		if m.WMInfo.Class() != Bus.String() {
+			m.VDSInfo.Bus = nil
+		} else if len(m.VDSInfo.BodyStyle) == 0 {
+			m.VDSInfo.BodyStyle = busBodyStyle(m.VDSInfo)
+		}
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		dst.VDSInfo = src.VDSInfo
+	}},
+	{name: "series", stage: StageStore, after: []string{"vds", "plant"}, run: func(m *VIN, warn func(Warning)) error {
+		m.Series, m.VDSAlternatives, _ = findSeries(m.Unique, m.VDSInfo, m.Years, m.markets()...)
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		dst.Series = src.Series
+		dst.VDSAlternatives = src.VDSAlternatives
+	}},
+	{name: "plant", stage: StageStore, after: []string{"wmi", "vds"}, run: func(m *VIN, warn func(Warning)) error {
+		m.PlantCountry = plantCountry(*m)
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		dst.PlantCountry = src.PlantCountry
+	}},
+	{name: "serialrange", stage: StageStore, after: []string{"year"}, run: func(m *VIN, warn func(Warning)) error {
+		if !serialInRange(*m) {
+			m.flag(FlagSerialOutOfRange)
+		}
+
+		return nil
+	}, apply: func(dst, src *VIN) {
+		for _, f := range src.Flags {
+			dst.flag(f)
+		}
+	}},
+}
+
+//stepResult is returned by a step, which may finish after its budget was exceeded
+type stepResult struct {
+	err      error
+	warnings []Warning
+}
+
+//runSteps runs every step as soon as its dependencies are done. A failed step only skips the steps that depend on it,
+//the error of the first failed step, in the order they are listed, is returned.
+//Warnings are added to the VIN in the order of the steps.
+//Steps which exceed the DecodeBudget are skipped with a WarnPartial, they don't fail the decode.
+//Every step which runs is traced as a span of SpanStep and its name.
+func runSteps(parent context.Context, m *VIN, steps []decodeStep) error {
+	limits := currentBudget()
+	expired := make(chan struct{})
+
+	if limits.Total > 0 {
+		timer := time.AfterFunc(limits.Total, func() { close(expired) })
+		defer timer.Stop()
+	}
+
+	var mu sync.Mutex //guards m while steps copy and apply it
+	done := make(map[string]chan struct{})
+	errs := make([]error, len(steps))
+	warnings := make([][]Warning, len(steps))
+
+	for _, s := range steps {
+		done[s.name] = make(chan struct{})
+	}
+
+	var failed sync.Map
+	var wg sync.WaitGroup
+	wg.Add(len(steps))
+
+	for i, s := range steps {
+		go func(i int, s decodeStep) {
+			defer wg.Done()
+			defer close(done[s.name])
+
+			for _, dep := range s.after {
+				wait, ok := done[dep]
+
+				if !ok {
+					errs[i] = fmt.Errorf("%s depends on unknown step %s", s.name, dep)
+					failed.Store(s.name, true)
+					return
+				}
+
+				<-wait
+
+				if _, isFailed := failed.Load(dep); isFailed {
+					failed.Store(s.name, true)
+					return
+				}
+			}
+
+			_, span := startSpan(parent, SpanStep+s.name)
+
+			mu.Lock()
+			work := *m
+			mu.Unlock()
+
+			result := make(chan stepResult, 1)
+
+			go func() {
+				r := stepResult{}
+				r.err = s.run(&work, func(w Warning) {
+					r.warnings = append(r.warnings, w)
+				})
+
+				result <- r
+			}()
+
+			var stepLimit <-chan time.Time
+
+			if limit := limits.Stages[s.stage]; limit > 0 {
+				timer := time.NewTimer(limit)
+				defer timer.Stop()
+				stepLimit = timer.C
+			}
+
+			select {
+			case r := <-result:
+				errs[i], warnings[i] = r.err, r.warnings
+				span.End(r.err)
+			case <-stepLimit:
+				warnings[i] = []Warning{newWarning(WarnPartial, "%s exceeded its budget of %s", s.name, limits.Stages[s.stage])}
+				span.End(errors.New(warnings[i][0].Message))
+				failed.Store(s.name, true)
+				return
+			case <-expired:
+				warnings[i] = []Warning{newWarning(WarnPartial, "%s exceeded the decode budget of %s", s.name, limits.Total)}
+				span.End(errors.New(warnings[i][0].Message))
+				failed.Store(s.name, true)
+				return
+			}
+
+			if errs[i] != nil {
+				failed.Store(s.name, true)
+				return
+			}
+
+			if s.apply != nil {
+				mu.Lock()
+				s.apply(m, &work)
+				mu.Unlock()
+			}
+		}(i, s)
+	}
+
+	wg.Wait()
+
+	for _, w := range warnings {
+		m.Warnings = append(m.Warnings, w...)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunSteps_Order(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	step := func(name string, after ...string) decodeStep {
+		return decodeStep{name: name, after: after, run: func(m *VIN, warn func(Warning)) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			order = append(order, name)
+			return nil
+		}}
+	}
+
+	err := runSteps(context.Background(), &VIN{}, []decodeStep{step("c", "a", "b"), step("a"), step("b", "a")})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Errorf("expected [a b c], got %v", order)
+	}
+}
+
+func TestRunSteps_FailureIsolated(t *testing.T) {
+	ran := make(map[string]bool)
+	var mu sync.Mutex
+	step := func(name string, err error, after ...string) decodeStep {
+		return decodeStep{name: name, after: after, run: func(m *VIN, warn func(Warning)) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			ran[name] = true
+			return err
+		}}
+	}
+
+	failure := errors.New("wmi not found")
+	err := runSteps(context.Background(), &VIN{}, []decodeStep{
+		step("wmi", failure),
+		step("year", nil),
+		step("vds", nil, "wmi", "year"),
+		step("serial", nil, "year"),
+	})
+
+	if err != failure {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+
+	if ran["vds"] {
+		t.Error("vds ran after wmi failed")
+	}
+
+	if !ran["year"] || !ran["serial"] {
+		t.Errorf("independent steps didn't run, %v", ran)
+	}
+}
+
+func TestRunSteps_Warnings(t *testing.T) {
+	warning := func(code string) decodeStep {
+		return decodeStep{name: code, run: func(m *VIN, warn func(Warning)) error {
+			warn(newWarning(code, "%s happened", code))
+			return nil
+		}}
+	}
+
+	v := &VIN{}
+	err := runSteps(context.Background(), v, []decodeStep{warning(WarnAmbiguousYear), warning(WarnCheckDigit)})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(v.Warnings) != 2 || v.Warnings[0].Code != WarnAmbiguousYear || v.Warnings[1].Code != WarnCheckDigit {
+		t.Errorf("expected warnings in step order, got %v", v.Warnings)
+	}
+}
+
+func TestRunSteps_Budget(t *testing.T) {
+	SetDecodeBudget(DecodeBudget{Total: time.Second, Stages: map[string]time.Duration{StagePlugin: 10 * time.Millisecond}})
+	defer SetDecodeBudget(DecodeBudget{})
+
+	release := make(chan struct{})
+	defer close(release)
+
+	steps := []decodeStep{
+		{name: "year", run: func(m *VIN, warn func(Warning)) error {
+			m.Years = []int{2016}
+			return nil
+		}, apply: func(dst, src *VIN) {
+			dst.Years = src.Years
+		}},
+		{name: "vds", stage: StagePlugin, after: []string{"year"}, run: func(m *VIN, warn func(Warning)) error {
+			<-release
+			m.VDSInfo.Chassis = "F30"
+			return nil
+		}, apply: func(dst, src *VIN) {
+			dst.VDSInfo = src.VDSInfo
+		}},
+		{name: "series", after: []string{"vds"}, run: func(m *VIN, warn func(Warning)) error {
+			t.Error("series ran after vds exceeded its budget")
+			return nil
+		}},
+	}
+
+	v := &VIN{}
+	err := runSteps(context.Background(), v, steps)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.ModelYear() != 2016 || len(v.VDSInfo.Chassis) > 0 {
+		t.Errorf("expected only the year to be decoded, got %+v", v)
+	}
+
+	if !v.Partial() {
+		t.Errorf("expected a partial warning, got %v", v.Warnings)
+	}
+}
+
+func TestParseDecodeBudget(t *testing.T) {
+	b, err := ParseDecodeBudget("total=300ms, store=50ms,plugin=100ms")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.Total != 300*time.Millisecond || b.Stages[StageStore] != 50*time.Millisecond || b.Stages[StagePlugin] != 100*time.Millisecond {
+		t.Errorf("unexpected budget %+v", b)
+	}
+
+	_, err = ParseDecodeBudget("vpic=1s")
+
+	if err == nil {
+		t.Error("expected an error for an unknown stage")
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//ErrPlateNotFound is returned when the provider doesn't know the plate
+var ErrPlateNotFound = errors.New("plate not found")
+
+//PlateLookup resolves a licence plate to a VIN, region is the registering country or state.
+type PlateLookup interface {
+	LookupPlate(plate, region string) (string, error)
+}
+
+var plateLookup PlateLookup
+
+//SetPlateLookup sets the provider used by DecodePlate
+func SetPlateLookup(p PlateLookup) {
+	plateLookup = p
+}
+
+//DecodePlate resolves the plate with the configured PlateLookup, and decodes the VIN.
+func DecodePlate(plate, region string) (*VIN, error) {
+	if plateLookup == nil {
+		return nil, errors.New("no plate lookup is configured")
+	}
+
+	_, span := startSpan(context.Background(), SpanPlate)
+	span.SetAttribute("region", region)
+	vin, err := plateLookup.LookupPlate(plate, region)
+	span.End(err)
+
+	if err != nil {
+		return nil, err
+	}
+
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	err = ValidateVIN(vin)
+
+	if err != nil {
+		return nil, fmt.Errorf("provider returned %s: %s", vin, err)
+	}
+
+	return BuildInfo(vin)
+}
+
+//HTTPPlateLookup calls a provider's JSON API.
+//URL may contain {plate} and {region}, which are replaced with the escaped values.
+//VINField is the path to the VIN in the response, nested fields are separated by dots. ie. result.vehicle.vin
+type HTTPPlateLookup struct {
+	URL      string
+	Token    string //Sent as a bearer token, when it's set
+	VINField string
+	client   *http.Client
+}
+
+//NewHTTPPlateLookup returns a PlateLookup for the provider at urlTemplate
+func NewHTTPPlateLookup(urlTemplate, token, vinField string) *HTTPPlateLookup {
+	if len(vinField) == 0 {
+		vinField = "vin"
+	}
+
+	return &HTTPPlateLookup{
+		URL:      urlTemplate,
+		Token:    token,
+		VINField: vinField,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (p *HTTPPlateLookup) LookupPlate(plate, region string) (string, error) {
+	r := strings.NewReplacer("{plate}", url.PathEscape(plate), "{region}", url.PathEscape(region))
+	req, err := http.NewRequest(http.MethodGet, r.Replace(p.URL), nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(p.Token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	resp, err := p.client.Do(req)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrPlateNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("plate provider returned %s", resp.Status)
+	}
+
+	var body interface{}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, name := range strings.Split(p.VINField, ".") {
+		obj, ok := body.(map[string]interface{})
+
+		if !ok {
+			return "", ErrPlateNotFound
+		}
+
+		body = obj[name]
+	}
+
+	vin, ok := body.(string)
+
+	if !ok || len(vin) == 0 {
+		return "", ErrPlateNotFound
+	}
+
+	return vin, nil
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPPlateLookup_NestedField(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ZA/CA123456" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`{"result": {"vehicle": {"vin": "5NPEU46F77H259112"}}}`))
+	}))
+	defer srv.Close()
+
+	p := NewHTTPPlateLookup(srv.URL+"/{region}/{plate}", "", "result.vehicle.vin")
+	vin, err := p.LookupPlate("CA123456", "ZA")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin != "5NPEU46F77H259112" {
+		t.Errorf("expected 5NPEU46F77H259112, got %s", vin)
+	}
+
+	_, err = p.LookupPlate("CA000000", "ZA")
+
+	if err != ErrPlateNotFound {
+		t.Errorf("expected ErrPlateNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//IndexHealth compares the VIN index to the stored VINs
+type IndexHealth struct {
+	VINs    int
+	Indexed int
+	Healthy bool
+}
+
+//PreflightReport summarises the reference data the service starts with
+type PreflightReport struct {
+	WMIs           int
+	VDSRecords     int
+	Coverage       float64 //Share of the manufacturers which VDS can be decoded
+	Manufacturers  []ManufacturerCoverage
+	DatasetVersion string //Hash of the regions and VDS records, it changes with every import or edit
+	Index          IndexHealth
+}
+
+//Preflight reports the WMI count, VDS coverage per manufacturer, dataset version and the health of the VIN index.
+func Preflight() PreflightReport {
+	coverage := Coverage()
+	result := PreflightReport{
+		WMIs:          coverage.Covered + coverage.Uncovered,
+		Manufacturers: coverage.Manufacturers,
+	}
+
+	if result.WMIs > 0 {
+		result.Coverage = float64(coverage.Covered) / float64(result.WMIs)
+	}
+
+	regions, _ := Local().Regions()
+	records, _ := Local().VDSRecords()
+	result.VDSRecords = len(records)
+	result.DatasetVersion = datasetVersion(regions, records)
+
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		result.Index.VINs++
+	})
+
+	index.RLock()
+	result.Index.Indexed = len(index.keys)
+	index.RUnlock()
+
+	result.Index.Healthy = result.Index.VINs == result.Index.Indexed
+
+	return result
+}
+
+//Check returns an error when the coverage is below the minimum, or the index is out of sync
+func (r PreflightReport) Check(minCoverage float64) error {
+	if !r.Index.Healthy {
+		return fmt.Errorf("index has %d of %d VINs", r.Index.Indexed, r.Index.VINs)
+	}
+
+	if r.Coverage < minCoverage {
+		return fmt.Errorf("coverage of %.2f is below the minimum of %.2f", r.Coverage, minCoverage)
+	}
+
+	return nil
+}
+
+func (r PreflightReport) String() string {
+	return fmt.Sprintf("dataset %s: %d WMIs, %d VDS records, %.0f%% covered, %d of %d VINs indexed",
+		r.DatasetVersion, r.WMIs, r.VDSRecords, r.Coverage*100, r.Index.Indexed, r.Index.VINs)
+}
+
+//datasetVersion is the first 12 characters of the hash of the reference data
+func datasetVersion(regions []Region, records []VDSRecord) string {
+	data, err := json.Marshal(struct {
+		Regions []Region
+		VDS     []VDSRecord
+	}{regions, records})
+
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+//preflight logs the report, and fails when it doesn't pass Check
+func preflight(minCoverage float64) error {
+	report := Preflight()
+	log.Println("preflight", report)
+
+	return report.Check(minCoverage)
+}
@@ -0,0 +1,37 @@
+package core
+
+import "testing"
+
+func TestPreflightReport_Check(t *testing.T) {
+	r := PreflightReport{Coverage: 0.4, Index: IndexHealth{VINs: 10, Indexed: 10, Healthy: true}}
+
+	if err := r.Check(0.3); err != nil {
+		t.Error(err)
+	}
+
+	if err := r.Check(0.5); err == nil {
+		t.Error("expected the coverage to be too low")
+	}
+
+	r.Index = IndexHealth{VINs: 10, Indexed: 9}
+
+	if err := r.Check(0); err == nil {
+		t.Error("expected the index to be unhealthy")
+	}
+}
+
+func TestDatasetVersion(t *testing.T) {
+	regions := []Region{{Name: "Africa", StartChar: "A", EndChar: "H"}}
+	records := []VDSRecord{{WMICode: "WVW", Code: "ZZZ1J", SeriesSpec: "Mk4"}}
+	v := datasetVersion(regions, records)
+
+	if len(v) != 12 || v != datasetVersion(regions, records) {
+		t.Fatalf("expected a stable version, got %s", v)
+	}
+
+	records[0].SeriesSpec = "Mk5"
+
+	if datasetVersion(regions, records) == v {
+		t.Error("expected the version to change with the data")
+	}
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//QuarantineEnabled keeps the VINs which couldn't be decoded, so they can be retried once the reference data improves
+var QuarantineEnabled = false
+
+//undecodableError is an error of the VIN itself, ie. a year code or VDS which can't be read
+type undecodableError struct {
+	error
+}
+
+//undecodable returns true when the VIN can't be decoded with the current reference data. Only these VINs are quarantined,
+//errors of the store or the context are temporary and the VIN is decoded again on the next request.
+func undecodable(err error) bool {
+	if err == ErrReferenceNotFound {
+		return true
+	}
+
+	_, ok := err.(undecodableError)
+
+	return ok
+}
+
+//undecoded returns why the decoded VIN should be retried later, when its manufacturer or VDS analyzer is unknown.
+func undecoded(v VIN) error {
+	if len(v.WMInfo.Manufacturer) == 0 {
+		return fmt.Errorf("no manufacturer for %s", v.Full[:3])
+	}
+
+	for _, w := range v.Warnings {
+		if w.Code == WarnRawVDS {
+			return errors.New(w.Message)
+		}
+	}
+
+	return nil
+}
+
+//QuarantinedVIN is a valid VIN which couldn't be decoded
+type QuarantinedVIN struct {
+	Full          string `hsk:"size(17)"`
+	Reason        string
+	Retries       int
+	Resolved      bool
+	QuarantinedAt time.Time
+	LastTried     time.Time
+	Checksum      string //Hash of the content, see VerifyIntegrity
+}
+
+func (m QuarantinedVIN) Valid() (bool, error) {
+	return husk.ValidateStruct(&m)
+}
+
+//quarantine adds the VIN to the quarantine table, unless it's already waiting there.
+func quarantine(fullvin string, reason error) (husk.Recorder, error) {
+	done, err := writing()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer done()
+
+	item, err := ctx.Quarantine.FindFirst(byQuarantinedVIN(fullvin))
+
+	if err == nil {
+		return item, nil
+	}
+
+	obj := QuarantinedVIN{
+		Full:          fullvin,
+		Reason:        reason.Error(),
+		QuarantinedAt: time.Now(),
+	}
+
+	obj.Checksum = obj.checksum()
+	cset := ctx.Quarantine.Create(obj)
+
+	if cset.Error != nil {
+		return nil, cset.Error
+	}
+
+	defer ctx.Quarantine.Save()
+	return cset.Record, nil
+}
+
+func GetQuarantinedVIN(key Key) (*QuarantinedVIN, error) {
+	rec, err := ctx.Quarantine.FindByKey(key.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Data().(*QuarantinedVIN), nil
+}
+
+//GetAllQuarantined returns the VINs which haven't been resolved
+func GetAllQuarantined(page, size int) husk.Collection {
+	return ctx.Quarantine.Find(page, size, byUnresolved())
+}
+
+//errQuarantineResolved is returned when a resolved quarantine is retried or resolved again
+var errQuarantineResolved = errors.New("quarantine is already resolved")
+
+//errRetryPartial is returned when the retry exceeded the DecodeBudget, it isn't counted
+var errRetryPartial = errors.New("retry exceeded the decode budget")
+
+//RetryQuarantined decodes the VIN again. When it succeeds the VIN is created and the quarantine is resolved,
+//otherwise the retry is counted and the reason updated.
+func RetryQuarantined(key Key) (husk.Recorder, error) {
+	rec, err := ctx.Quarantine.FindByKey(key.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	obj := *rec.Data().(*QuarantinedVIN)
+
+	if obj.Resolved {
+		return nil, errQuarantineResolved
+	}
+
+	vin, decodeErr := newVIN(obj.Full)
+
+	//A temporary error isn't counted as a retry
+	if decodeErr != nil && !undecodable(decodeErr) {
+		return nil, decodeErr
+	}
+
+	if decodeErr == nil && vin.Partial() {
+		return nil, errRetryPartial
+	}
+
+	if decodeErr == nil {
+		decodeErr = undecoded(*vin)
+	}
+
+	var result husk.Recorder
+
+	if decodeErr == nil {
+		vin.Source = "quarantine"
+		result, err = vin.Create()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = changeQuarantine(key, func(obj *QuarantinedVIN) error {
+		if obj.Resolved {
+			return errQuarantineResolved
+		}
+
+		obj.Retries++
+		obj.LastTried = time.Now()
+
+		if decodeErr == nil {
+			obj.Resolved = true
+		} else {
+			obj.Reason = decodeErr.Error()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, decodeErr
+}
+
+//ResolveQuarantined marks the VIN as resolved without decoding it, for VINs which will never decode.
+func ResolveQuarantined(key Key) error {
+	return changeQuarantine(key, func(obj *QuarantinedVIN) error {
+		obj.Resolved = true
+		return nil
+	})
+}
+
+//changeQuarantine writes a copy of the quarantined VIN with the change, the record is read and written under its lock.
+func changeQuarantine(key Key, change func(obj *QuarantinedVIN) error) error {
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	unlock := recordLocks.lock("quarantine/" + key.String())
+	defer unlock()
+
+	rec, err := ctx.Quarantine.FindByKey(key.key)
+
+	if err != nil {
+		return err
+	}
+
+	obj := *rec.Data().(*QuarantinedVIN)
+	err = change(&obj)
+
+	if err != nil {
+		return err
+	}
+
+	obj.Checksum = obj.checksum()
+	err = rec.Set(obj)
+
+	if err != nil {
+		return err
+	}
+
+	defer ctx.Quarantine.Save()
+	return ctx.Quarantine.Update(rec)
+}
+
+type quarantineFilter func(obj *QuarantinedVIN) bool
+
+func (f quarantineFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*QuarantinedVIN))
+}
+
+func byQuarantinedVIN(fullvin string) quarantineFilter {
+	return func(obj *QuarantinedVIN) bool {
+		return obj.Full == fullvin && !obj.Resolved
+	}
+}
+
+func byUnresolved() quarantineFilter {
+	return func(obj *QuarantinedVIN) bool {
+		return !obj.Resolved
+	}
+}
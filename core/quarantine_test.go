@@ -0,0 +1,204 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func TestQuarantine_Once(t *testing.T) {
+	first, err := quarantine("KMHCG45C41U000001", errors.New("no decoder"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := quarantine("KMHCG45C41U000001", errors.New("no decoder"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.GetKey() != again.GetKey() {
+		t.Error("expected a waiting VIN to be quarantined once")
+	}
+
+	if reason := first.Data().(*QuarantinedVIN).Reason; reason != "no decoder" {
+		t.Errorf("expected the reason to be kept, got %q", reason)
+	}
+}
+
+func TestRetryQuarantined(t *testing.T) {
+	rec, err := quarantine(expectations.Full, errors.New("no decoder"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := KeyOf(rec.GetKey())
+	created, err := RetryQuarantined(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Data().(*VIN).Full != expectations.Full {
+		t.Errorf("expected %s to be created, got %+v", expectations.Full, created.Data())
+	}
+
+	obj, err := GetQuarantinedVIN(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !obj.Resolved || obj.Retries != 1 {
+		t.Errorf("expected the quarantine to be resolved after a retry, got %+v", obj)
+	}
+
+	_, err = RetryQuarantined(key)
+
+	if err != errQuarantineResolved {
+		t.Errorf("expected a resolved quarantine to be refused, got %v", err)
+	}
+}
+
+func TestResolveQuarantined(t *testing.T) {
+	rec, err := quarantine("KMHCG45C41U000002", errors.New("no decoder"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := KeyOf(rec.GetKey())
+	err = ResolveQuarantined(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj, err := GetQuarantinedVIN(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !obj.Resolved || obj.Retries != 0 {
+		t.Errorf("expected the quarantine to be resolved without a retry, got %+v", obj)
+	}
+
+	if _, err := ctx.Quarantine.FindFirst(byQuarantinedVIN("KMHCG45C41U000002")); err == nil {
+		t.Error("expected the resolved VIN to not be waiting")
+	}
+}
+
+func TestBuildInfo_QuarantineUnknownWMI(t *testing.T) {
+	QuarantineEnabled = true
+	defer func() { QuarantineEnabled = false }()
+
+	full := "9ZZAB12C_5M000001"
+	full = full[:8] + vinmath.CheckDigit(full) + full[9:]
+
+	vin, err := BuildInfo(full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vin.WMInfo.Manufacturer) != 0 {
+		t.Fatalf("expected %s to have no manufacturer, got %s", full[:3], vin.WMInfo.Manufacturer)
+	}
+
+	rec, err := ctx.Quarantine.FindFirst(byQuarantinedVIN(full))
+
+	if err != nil {
+		t.Fatal("expected the VIN without a manufacturer to be quarantined")
+	}
+
+	if reason := rec.Data().(*QuarantinedVIN).Reason; reason != "no manufacturer for 9ZZ" {
+		t.Errorf("expected the missing manufacturer as reason, got %q", reason)
+	}
+
+	_, err = RetryQuarantined(KeyOf(rec.GetKey()))
+
+	if err == nil {
+		t.Error("expected the retry to fail while the manufacturer is unknown")
+	}
+}
+
+func TestBuildInfo_QuarantineDecoded(t *testing.T) {
+	QuarantineEnabled = true
+	defer func() { QuarantineEnabled = false }()
+
+	_, err := BuildInfo(expectations.Full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ctx.Quarantine.FindFirst(byQuarantinedVIN(expectations.Full)); err == nil {
+		t.Error("expected a decoded VIN not to be quarantined")
+	}
+}
+
+func TestBuildInfo_QuarantineTemporary(t *testing.T) {
+	QuarantineEnabled = true
+	defer func() { QuarantineEnabled = false }()
+
+	SetStore(unavailableStore{})
+	defer SetStore(nil)
+
+	full := "9ZYAB12C_5M000001"
+	full = full[:8] + vinmath.CheckDigit(full) + full[9:]
+
+	if _, err := BuildInfo(full); err == nil {
+		t.Fatal("expected the decode to fail while the store is unavailable")
+	}
+
+	if _, err := ctx.Quarantine.FindFirst(byQuarantinedVIN(full)); err == nil {
+		t.Error("expected a store error not to be quarantined")
+	}
+
+	if _, err := BuildInfo("WBA8E9G50UN012345"); err == nil || !undecodable(err) {
+		t.Errorf("expected a year code which can't be read to be undecodable, got %v", err)
+	}
+}
+
+//slowStore takes longer than the budget of the store stage to find a region
+type slowStore struct {
+	localStore
+}
+
+func (s slowStore) FindRegion(uniquevin string) (Region, error) {
+	time.Sleep(50 * time.Millisecond)
+	return s.localStore.FindRegion(uniquevin)
+}
+
+func TestBuildInfo_QuarantinePartial(t *testing.T) {
+	QuarantineEnabled = true
+	defer func() { QuarantineEnabled = false }()
+
+	SetStore(slowStore{})
+	defer SetStore(nil)
+
+	SetDecodeBudget(DecodeBudget{Stages: map[string]time.Duration{StageStore: time.Millisecond}})
+	defer SetDecodeBudget(DecodeBudget{})
+
+	full := "9ZXAB12C_5M000001"
+	full = full[:8] + vinmath.CheckDigit(full) + full[9:]
+	vin, err := BuildInfo(full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !vin.Partial() {
+		t.Fatalf("expected the slow store to exceed the budget, got %+v", vin.Warnings)
+	}
+
+	if _, err := ctx.Quarantine.FindFirst(byQuarantinedVIN(full)); err == nil {
+		t.Error("expected a partial decode not to be quarantined")
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//bodyLayoutNames are matched against the body style of the VDS and the layout of the platform's body
+var bodyLayoutNames = [...]string{
+	"Sedan",
+	"Coupe",
+	"Hatchback",
+	"Van",
+	"Pickup",
+	"Station Wagon",
+	"Convertible",
+	"SUV",
+	"Fastback",
+	"Single Deck Bus",
+	"Double Deck Bus"}
+
+//Query is a filter over the stored VINs, built from conditions which must all match.
+//It's a husk filter, so it can be passed to any table search.
+//
+//	core.Where().Manufacturer("BMW").YearBetween(2015, 2020).Body(core.SUV).All()
+type Query struct {
+	conds []vinFilter
+}
+
+//Where starts a Query which matches every VIN
+func Where() Query {
+	return Query{}
+}
+
+//and returns a copy of the query with the condition added, so queries can be branched.
+func (q Query) and(cond vinFilter) Query {
+	conds := make([]vinFilter, len(q.conds), len(q.conds)+1)
+	copy(conds, q.conds)
+
+	return Query{conds: append(conds, cond)}
+}
+
+//Manufacturer matches manufacturer names which start with the name, ignoring case
+func (q Query) Manufacturer(name string) Query {
+	name = strings.ToLower(name)
+
+	return q.and(func(obj *VIN) bool {
+		return strings.HasPrefix(strings.ToLower(obj.WMInfo.Manufacturer), name)
+	})
+}
+
+//WMI matches VINs which start with the code
+func (q Query) WMI(code string) Query {
+	code = strings.ToUpper(code)
+
+	return q.and(func(obj *VIN) bool {
+		return strings.HasPrefix(obj.Full, code)
+	})
+}
+
+//Country matches the country of the WMI
+func (q Query) Country(name string) Query {
+	return q.and(func(obj *VIN) bool {
+		return strings.EqualFold(obj.WMInfo.Country, name)
+	})
+}
+
+//Region matches the region of the WMI
+func (q Query) Region(name string) Query {
+	return q.and(func(obj *VIN) bool {
+		return strings.EqualFold(obj.WMInfo.Region, name)
+	})
+}
+
+//YearBetween matches VINs with a model year from and to, including both
+func (q Query) YearBetween(from, to int) Query {
+	return q.and(func(obj *VIN) bool {
+		year := obj.ModelYear()
+
+		return year >= from && year <= to
+	})
+}
+
+//Body matches VINs with the body layout, as decoded from the VDS or the platform
+func (q Query) Body(layout BodyLayout) Query {
+	if layout < 0 || layout >= len(bodyLayoutNames) {
+		return q.and(func(obj *VIN) bool {
+			return false
+		})
+	}
+
+	name := strings.ToLower(bodyLayoutNames[layout])
+
+	return q.and(func(obj *VIN) bool {
+		return strings.Contains(strings.ToLower(obj.VDSInfo.BodyStyle), name) ||
+			strings.EqualFold(obj.Series.Platform.Body.Layout, name)
+	})
+}
+
+//Source matches VINs which were ingested from the source
+func (q Query) Source(source string) Query {
+	return q.and(func(obj *VIN) bool {
+		return obj.Source == source
+	})
+}
+
+//CreatedBetween matches VINs created from and before to
+func (q Query) CreatedBetween(from, to time.Time) Query {
+	return q.and(func(obj *VIN) bool {
+		return !obj.CreatedAt.Before(from) && obj.CreatedAt.Before(to)
+	})
+}
+
+//UpdatedBetween matches VINs which last changed from and before to
+func (q Query) UpdatedBetween(from, to time.Time) Query {
+	return q.and(func(obj *VIN) bool {
+		return !obj.UpdatedAt.Before(from) && obj.UpdatedAt.Before(to)
+	})
+}
+
+//DecodedBetween matches VINs which were last decoded from and before to
+func (q Query) DecodedBetween(from, to time.Time) Query {
+	return q.and(func(obj *VIN) bool {
+		return !obj.LastDecodedAt.Before(from) && obj.LastDecodedAt.Before(to)
+	})
+}
+
+//Flagged matches VINs which carry the flag
+func (q Query) Flagged(flag string) Query {
+	return q.and(func(obj *VIN) bool {
+		for _, f := range obj.Flags {
+			if f == flag {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+func (q Query) matches(obj *VIN) bool {
+	for _, cond := range q.conds {
+		if !cond(obj) {
+			return false
+		}
+	}
+
+	return true
+}
+
+//Filter makes the Query a husk filter
+func (q Query) Filter(obj husk.Dataer) bool {
+	return q.matches(obj.(*VIN))
+}
+
+//Find returns a page of the matching VIN records
+func (q Query) Find(page, size int) husk.Collection {
+	return ctx.VIN.Find(page, size, q)
+}
+
+//All returns every matching VIN
+func (q Query) All() []VIN {
+	var result []VIN
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		if v := obj.(*VIN); q.matches(v) {
+			result = append(result, *v)
+		}
+	})
+
+	return result
+}
+
+//Count returns the number of matching VINs
+func (q Query) Count() int {
+	result := 0
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		if q.matches(obj.(*VIN)) {
+			result++
+		}
+	})
+
+	return result
+}
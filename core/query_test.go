@@ -0,0 +1,48 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestQuery_Matches(t *testing.T) {
+	x5 := &VIN{Full: "5UXKR0C58F0P00001", Years: []int{2015}, WMInfo: WMInfo{Manufacturer: "BMW"}, VDSInfo: vds.VDSInfo{BodyStyle: "SUV"}}
+	m3 := &VIN{Full: "WBS8M9C56G5G00001", Years: []int{2016}, WMInfo: WMInfo{Manufacturer: "BMW"}, VDSInfo: vds.VDSInfo{BodyStyle: "Sedan 4 Door"}}
+	old := &VIN{Full: "5UXFA13585LY00001", Years: []int{2005}, WMInfo: WMInfo{Manufacturer: "BMW"}, VDSInfo: vds.VDSInfo{BodyStyle: "SUV"}}
+	camry := &VIN{Full: "4T1BF1FK5GU000001", Years: []int{2016}, WMInfo: WMInfo{Manufacturer: "Toyota"}, VDSInfo: vds.VDSInfo{BodyStyle: "Sedan"}}
+	bus := vds.VDSInfo{Bus: &vds.BusInfo{Deck: vds.DoubleDeck}}
+	day := time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC)
+	seen := &VIN{Full: "JTDKN3DU5A0000001", CreatedAt: day, UpdatedAt: day.Add(time.Hour), LastDecodedAt: day.AddDate(0, 1, 0)}
+	decker := &VIN{Full: "SFD5DBRR5KGX00001", Years: []int{2019}, WMInfo: WMInfo{Manufacturer: "Alexander Dennis", VehicleType: Bus.String()}, VDSInfo: vds.VDSInfo{BodyStyle: busBodyStyle(bus)}}
+
+	q := Where().Manufacturer("bmw").YearBetween(2015, 2020)
+	suv := q.Body(SUV)
+
+	cases := []struct {
+		q        Query
+		v        *VIN
+		expected bool
+	}{
+		{suv, x5, true},
+		{suv, m3, false},
+		{suv, old, false},
+		{suv, camry, false},
+		{q, m3, true},
+		{q.Body(Sedan), m3, true},
+		{Where(), camry, true},
+		{Where().WMI("4t1"), camry, true},
+		{Where().Body(DoubleDeckBus), decker, true},
+		{Where().Body(Sedan), decker, false},
+		{Where().UpdatedBetween(day, day.AddDate(0, 0, 1)), seen, true},
+		{Where().DecodedBetween(day, day.AddDate(0, 0, 1)), seen, false},
+		{Where().DecodedBetween(day.AddDate(0, 1, 0), day.AddDate(0, 2, 0)), seen, true},
+	}
+
+	for i, c := range cases {
+		if actual := c.q.matches(c.v); actual != c.expected {
+			t.Errorf("%d: %s expected %v, got %v", i, c.v.Full, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+//ErrQuotaExceeded is returned when a caller reached its hard limit for the day
+var ErrQuotaExceeded = errors.New("daily decode quota exceeded")
+
+//QuotaLimit is the number of decodes a caller may do per day, zero is unlimited.
+//Reaching the Soft limit is only logged, decodes fail after the Hard limit.
+type QuotaLimit struct {
+	Soft int
+	Hard int
+}
+
+//QuotaStore keeps the number of decodes per caller per day
+type QuotaStore interface {
+	//Add increments the caller's count for the day by n, and returns the new count
+	Add(caller, day string, n int) (int, error)
+	Get(caller, day string) (int, error)
+}
+
+//QuotaDay is a caller's usage on a day
+type QuotaDay struct {
+	Date string
+	Used int
+}
+
+//QuotaUsage is reported by the usage API
+type QuotaUsage struct {
+	Caller string
+	Limit  QuotaLimit
+	Days   []QuotaDay
+}
+
+var (
+	quotaMu      sync.RWMutex
+	quotaStore   QuotaStore = newMemoryQuotaStore()
+	quotaLimits             = make(map[string]QuotaLimit)
+	defaultQuota QuotaLimit
+)
+
+//SetQuotaStore replaces the in-memory store, so usage can be shared between instances
+func SetQuotaStore(s QuotaStore) {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+
+	quotaStore = s
+}
+
+//SetQuota sets the limit for a caller, callers without a limit use the default
+func SetQuota(caller string, limit QuotaLimit) {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+
+	quotaLimits[caller] = limit
+}
+
+//SetDefaultQuota sets the limit of callers who don't have their own
+func SetDefaultQuota(limit QuotaLimit) {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+
+	defaultQuota = limit
+}
+
+func quotaFor(caller string) QuotaLimit {
+	if limit, ok := quotaLimits[caller]; ok {
+		return limit
+	}
+
+	return defaultQuota
+}
+
+//ChargeDecode counts a decode for the caller.
+//ErrQuotaExceeded is returned, and nothing is counted, when the caller already reached the hard limit.
+//The decode is added before the limit is checked, concurrent decodes can't all pass the check with the last one left.
+func ChargeDecode(caller string) error {
+	quotaMu.RLock()
+	defer quotaMu.RUnlock()
+
+	limit := quotaFor(caller)
+	day := time.Now().Format(dayFormat)
+	used, err := quotaStore.Add(caller, day, 1)
+
+	if err != nil {
+		return err
+	}
+
+	if limit.Hard > 0 && used > limit.Hard {
+		_, err = quotaStore.Add(caller, day, -1)
+
+		if err != nil {
+			return err
+		}
+
+		return ErrQuotaExceeded
+	}
+
+	if limit.Soft > 0 && used == limit.Soft {
+		log.Printf("warning: %s reached the soft limit of %d decodes\n", caller, limit.Soft)
+	}
+
+	return nil
+}
+
+//Usage returns the caller's decodes for the last number of days, starting with today.
+func Usage(caller string, days int) (QuotaUsage, error) {
+	quotaMu.RLock()
+	defer quotaMu.RUnlock()
+
+	result := QuotaUsage{Caller: caller, Limit: quotaFor(caller)}
+	now := time.Now()
+
+	for i := 0; i < days; i++ {
+		day := now.AddDate(0, 0, -i).Format(dayFormat)
+		used, err := quotaStore.Get(caller, day)
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Days = append(result.Days, QuotaDay{Date: day, Used: used})
+	}
+
+	return result, nil
+}
+
+//memoryQuotaStore only keeps the counts of today, use a shared QuotaStore for the usage of previous days.
+type memoryQuotaStore struct {
+	sync.Mutex
+	today  string
+	counts map[string]int
+}
+
+func newMemoryQuotaStore() *memoryQuotaStore {
+	return &memoryQuotaStore{counts: make(map[string]int)}
+}
+
+func (s *memoryQuotaStore) Add(caller, day string, n int) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.prune(time.Now().Format(dayFormat))
+	s.counts[caller+"/"+day] += n
+
+	return s.counts[caller+"/"+day], nil
+}
+
+func (s *memoryQuotaStore) Get(caller, day string) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.counts[caller+"/"+day], nil
+}
+
+//prune removes the counts of the days before today, when the day changed since the last decode
+func (s *memoryQuotaStore) prune(today string) {
+	if s.today == today {
+		return
+	}
+
+	s.today = today
+
+	for k := range s.counts {
+		if k[strings.LastIndex(k, "/")+1:] < today {
+			delete(s.counts, k)
+		}
+	}
+}
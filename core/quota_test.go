@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChargeDecode_HardLimit(t *testing.T) {
+	SetQuotaStore(newMemoryQuotaStore())
+	SetQuota("tier-1", QuotaLimit{Soft: 1, Hard: 2})
+
+	for i := 0; i < 2; i++ {
+		err := ChargeDecode("tier-1")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := ChargeDecode("tier-1")
+
+	if err != ErrQuotaExceeded {
+		t.Errorf("expected ErrQuotaExceeded, got %v", err)
+	}
+
+	usage, err := Usage("tier-1", 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Days[0].Used != 2 {
+		t.Errorf("expected 2 decodes, got %d", usage.Days[0].Used)
+	}
+}
+
+func TestChargeDecode_Concurrent(t *testing.T) {
+	SetQuotaStore(newMemoryQuotaStore())
+	SetQuota("tier-2", QuotaLimit{Hard: 3})
+
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		go func() {
+			errs <- ChargeDecode("tier-2")
+		}()
+	}
+
+	charged := 0
+
+	for i := 0; i < 10; i++ {
+		if <-errs == nil {
+			charged++
+		}
+	}
+
+	if charged != 3 {
+		t.Errorf("expected 3 decodes to be charged, got %d", charged)
+	}
+
+	usage, err := Usage("tier-2", 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Days[0].Used != 3 {
+		t.Errorf("expected 3 decodes, got %d", usage.Days[0].Used)
+	}
+}
+
+func TestMemoryQuotaStore_Prune(t *testing.T) {
+	s := newMemoryQuotaStore()
+	s.counts["tier-1/2020-01-01"] = 5
+
+	_, err := s.Add("tier-1", time.Now().Format(dayFormat), 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := s.counts["tier-1/2020-01-01"]; ok {
+		t.Error("expected the days before today to be removed")
+	}
+}
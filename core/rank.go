@@ -0,0 +1,172 @@
+package core
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Candidate is a possible VIN read by OCR, with the evidence for it
+type Candidate struct {
+	VIN           string
+	Score         int
+	CheckDigit    bool //The check digit is valid
+	KnownWMI      bool //The WMI is in the reference data
+	PlausibleYear bool //The year code is a valid model year
+	Corrections   int  //Characters replaced with the character OCR usually confuses them with
+	Reads         int  //Number of candidates which resulted in this VIN
+}
+
+const (
+	scoreCheckDigit = 50
+	scoreKnownWMI   = 30
+	scoreYear       = 20
+	scoreRead       = 10
+	scoreCorrection = 5
+)
+
+//ocrConfusions are characters OCR often reads as each other
+var ocrConfusions = map[byte]string{
+	'0': "D",
+	'D': "0",
+	'1': "L",
+	'L': "1",
+	'2': "Z",
+	'Z': "2",
+	'4': "A",
+	'A': "4",
+	'5': "S",
+	'S': "5",
+	'6': "G",
+	'G': "6",
+	'7': "T",
+	'T': "7",
+	'8': "B",
+	'B': "8",
+	'U': "V",
+	'V': "U",
+}
+
+//RankCandidates scores the OCR readings of a VIN, using the check digit, the WMI and the model year.
+//Readings which fail the check digit are corrected by replacing a single commonly confused character.
+//The most likely VIN is first, candidates which can't be a VIN aren't returned.
+func RankCandidates(ocrCandidates []string) []Candidate {
+	return rankCandidates(ocrCandidates, func(uniquevin string) bool {
+		_, err := FindManufacturer(uniquevin)
+		return err == nil
+	})
+}
+
+func rankCandidates(ocrCandidates []string, knownWMI func(uniquevin string) bool) []Candidate {
+	found := make(map[string]*Candidate)
+
+	for _, raw := range ocrCandidates {
+		vin := normalizeOCR(raw)
+
+		if len(vin) != 17 {
+			continue
+		}
+
+		for _, c := range correct(vin) {
+			curr, ok := found[c.VIN]
+
+			if !ok {
+				curr = &c
+				found[c.VIN] = curr
+			}
+
+			if c.Corrections < curr.Corrections {
+				curr.Corrections = c.Corrections
+			}
+
+			curr.Reads++
+		}
+	}
+
+	var result []Candidate
+
+	for _, c := range found {
+		c.CheckDigit = vinmath.CheckDigit(c.VIN) == c.VIN[8:9]
+		c.KnownWMI = knownWMI(c.VIN)
+		c.PlausibleYear = plausibleYear(c.VIN[9:10])
+		c.Score = scoreRead*(c.Reads-1) - scoreCorrection*c.Corrections
+
+		if c.CheckDigit {
+			c.Score += scoreCheckDigit
+		}
+
+		if c.KnownWMI {
+			c.Score += scoreKnownWMI
+		}
+
+		if c.PlausibleYear {
+			c.Score += scoreYear
+		}
+
+		result = append(result, *c)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+
+		return result[i].VIN < result[j].VIN
+	})
+
+	return result
+}
+
+//normalizeOCR removes spaces and replaces the characters which aren't allowed in a VIN with the digits they look like
+func normalizeOCR(raw string) string {
+	r := strings.NewReplacer(" ", "", "-", "", "I", "1", "O", "0", "Q", "0")
+	return r.Replace(strings.ToUpper(strings.TrimSpace(raw)))
+}
+
+//correct returns the VIN, and when its check digit fails every VIN a single OCR confusion away that passes it.
+func correct(vin string) []Candidate {
+	result := []Candidate{{VIN: vin}}
+
+	if !validChars(vin) || vinmath.CheckDigit(vin) == vin[8:9] {
+		return result
+	}
+
+	b := []byte(vin)
+
+	for i := range b {
+		orig := b[i]
+
+		for _, alt := range []byte(ocrConfusions[orig]) {
+			b[i] = alt
+			fixed := string(b)
+
+			if vinmath.CheckDigit(fixed) == fixed[8:9] {
+				result = append(result, Candidate{VIN: fixed, Corrections: 1})
+			}
+		}
+
+		b[i] = orig
+	}
+
+	return result
+}
+
+func validChars(vin string) bool {
+	for i := 0; i < len(vin); i++ {
+		c := vin[i]
+
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+
+	return !strings.ContainsAny(vin, "IOQ")
+}
+
+//plausibleYear returns true when the year code is allowed in position 10, and isn't in the future.
+func plausibleYear(code string) bool {
+	years, err := vinmath.Years(code)
+
+	return err == nil && len(years) > 0
+}
@@ -0,0 +1,35 @@
+package core
+
+import "testing"
+
+func TestRankCandidates_CorrectsConfusion(t *testing.T) {
+	known := func(uniquevin string) bool {
+		return uniquevin[:3] == "5NP"
+	}
+
+	//B is read instead of 8, and S instead of 5
+	candidates := []string{"5NPEU46F77H2S9112", "5NPEU46F77H259112", "SNPEU46F77H259112"}
+	result := rankCandidates(candidates, known)
+
+	if len(result) == 0 {
+		t.Fatal("no candidates")
+	}
+
+	best := result[0]
+
+	if best.VIN != "5NPEU46F77H259112" {
+		t.Errorf("expected 5NPEU46F77H259112, got %+v", result)
+	}
+
+	if !best.CheckDigit || !best.KnownWMI || best.Reads != 3 {
+		t.Errorf("unexpected %+v", best)
+	}
+}
+
+func TestRankCandidates_SkipsLength(t *testing.T) {
+	result := rankCandidates([]string{"5NPEU46F77H25911"}, func(string) bool { return true })
+
+	if len(result) != 0 {
+		t.Errorf("expected nothing, got %+v", result)
+	}
+}
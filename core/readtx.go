@@ -0,0 +1,75 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ReadTx reads every table as it was when the transaction started.
+//Writes wait until the transaction is done, so it must not write and should be short.
+type ReadTx struct {
+	localStore
+}
+
+var (
+	viewMu sync.Mutex
+	views  int
+)
+
+//View runs fn in a read transaction, so a report across VINs, flags and quarantine sees a consistent snapshot while imports are running.
+//Views share the snapshot, they can run at the same time and within each other.
+func View(fn func(tx ReadTx) error) error {
+	viewMu.Lock()
+
+	//The first view waits for the writes, and the last one lets them continue
+	if views == 0 {
+		snapshot.Lock()
+	}
+
+	views++
+	viewMu.Unlock()
+
+	defer func() {
+		viewMu.Lock()
+		views--
+
+		if views == 0 {
+			snapshot.Unlock()
+		}
+
+		viewMu.Unlock()
+	}()
+
+	return fn(ReadTx{})
+}
+
+//GetVIN returns the VIN stored with the key
+func (tx ReadTx) GetVIN(key Key) (*VIN, error) {
+	return GetVIN(key)
+}
+
+//FindVIN returns the stored VIN
+func (tx ReadTx) FindVIN(fullvin string) (*VIN, error) {
+	return GetByFullVIN(fullvin)
+}
+
+//Quarantined returns the VINs in quarantine, including the resolved ones
+func (tx ReadTx) Quarantined() []QuarantinedVIN {
+	var result []QuarantinedVIN
+	scan(ctx.Quarantine, func(obj husk.Dataer) {
+		result = append(result, *obj.(*QuarantinedVIN))
+	})
+
+	return result
+}
+
+//SerialRanges returns the published serial ranges
+func (tx ReadTx) SerialRanges() []SerialRange {
+	var result []SerialRange
+	scan(ctx.Serials, func(obj husk.Dataer) {
+		result = append(result, *obj.(*SerialRange))
+	})
+
+	return result
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+//within fails the test when fn doesn't return in a second
+func within(t *testing.T, fn func()) {
+	done := make(chan struct{})
+
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the views not to deadlock")
+	}
+}
+
+func TestView_BlocksWrites(t *testing.T) {
+	written := make(chan error, 1)
+
+	err := View(func(tx ReadTx) error {
+		go func() {
+			_, err := VIN{Full: "SALGA2FE4HA000001", Unique: "SALGA2FE4HA", Serial: 1}.Create()
+			written <- err
+		}()
+
+		select {
+		case <-written:
+			t.Error("expected the write to wait for the view")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-written:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the write to continue after the view")
+	}
+}
+
+func TestView_Nested(t *testing.T) {
+	within(t, func() {
+		View(func(tx ReadTx) error {
+			return View(func(inner ReadTx) error {
+				return nil
+			})
+		})
+	})
+}
+
+func TestView_Concurrent(t *testing.T) {
+	started := make(chan struct{})
+
+	within(t, func() {
+		go View(func(tx ReadTx) error {
+			close(started)
+			return nil
+		})
+
+		//The outer view waits for the other one to run, which it can only do when views share the snapshot
+		View(func(tx ReadTx) error {
+			<-started
+			return nil
+		})
+	})
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//RecallRange is the serials of a plant and model year affected by a recall, as published in the recall notice.
+type RecallRange struct {
+	Campaign  string //Campaign number of the recall, ie. 21V123000
+	Title     string
+	WMICode   string `hsk:"size(3)"`
+	PlantCode string //Position 11, empty when the recall applies to every plant
+	Year      int    //Model year, zero when the recall applies to every year
+	Start     int
+	End       int
+	Source    string //Where the recall was published
+}
+
+func (m RecallRange) Valid() (bool, error) {
+	if len(m.Campaign) == 0 {
+		return false, fmt.Errorf("recall of %s has no campaign", m.WMICode)
+	}
+
+	if m.Start > m.End {
+		return false, fmt.Errorf("range starts at %d after it ends at %d", m.Start, m.End)
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m RecallRange) String() string {
+	return fmt.Sprintf("%s %s%s %d %d-%d", m.Campaign, m.WMICode, m.PlantCode, m.Year, m.Start, m.End)
+}
+
+//covers returns true when the VIN's serial is in the range
+func (m RecallRange) covers(v VIN) bool {
+	if len(v.Unique) < 11 || m.WMICode != v.Unique[:3] || m.Start > v.Serial || v.Serial > m.End {
+		return false
+	}
+
+	if len(m.PlantCode) > 0 && m.PlantCode != v.Unique[10:11] {
+		return false
+	}
+
+	if m.Year == 0 {
+		return true
+	}
+
+	for _, y := range v.Years {
+		if y == m.Year {
+			return true
+		}
+	}
+
+	return false
+}
+
+//ImportRecallRanges adds the recall ranges which aren't in the registry yet
+func ImportRecallRanges(items []RecallRange, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []RecallRange
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+		item.PlantCode = strings.ToUpper(item.PlantCode)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.Recalls.FindFirst(byRecallRange(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.Recalls.Save()
+
+	for _, item := range adds {
+		cset := ctx.Recalls.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//FindRecalls decodes the VIN and returns the recalls with a range which contains its serial.
+func FindRecalls(fullvin string) (*VIN, []RecallRange, error) {
+	vin, err := GetByFullVIN(fullvin)
+
+	if err != nil {
+		vin, err = BuildInfo(fullvin)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var result []RecallRange
+	scan(ctx.Recalls, func(obj husk.Dataer) {
+		r := obj.(*RecallRange)
+
+		if r.covers(*vin) {
+			result = append(result, *r)
+		}
+	})
+
+	return vin, result, nil
+}
+
+type recallFilter func(obj *RecallRange) bool
+
+func (f recallFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*RecallRange))
+}
+
+func byRecallRange(rec RecallRange) recallFilter {
+	return func(obj *RecallRange) bool {
+		return obj.Campaign == rec.Campaign && obj.WMICode == rec.WMICode && obj.PlantCode == rec.PlantCode &&
+			obj.Year == rec.Year && obj.Start == rec.Start && obj.End == rec.End
+	}
+}
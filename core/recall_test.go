@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+func TestRecallRange_Covers(t *testing.T) {
+	vin := VIN{Unique: "5NPEU46F77H", Serial: 259112, Years: []int{2007}}
+
+	cases := []struct {
+		name   string
+		recall RecallRange
+		covers bool
+	}{
+		{"in range", RecallRange{WMICode: "5NP", PlantCode: "H", Year: 2007, Start: 259000, End: 259200}, true},
+		{"every plant and year", RecallRange{WMICode: "5NP", Start: 259112, End: 259112}, true},
+		{"other plant", RecallRange{WMICode: "5NP", PlantCode: "U", Year: 2007, Start: 259000, End: 259200}, false},
+		{"other year", RecallRange{WMICode: "5NP", PlantCode: "H", Year: 2008, Start: 259000, End: 259200}, false},
+		{"after the serial", RecallRange{WMICode: "5NP", PlantCode: "H", Year: 2007, Start: 259113, End: 259200}, false},
+	}
+
+	for _, c := range cases {
+		if c.recall.covers(vin) != c.covers {
+			t.Errorf("%s: expected %v", c.name, c.covers)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//referenceSet is an immutable copy of the regions and VDS records, decodes read it instead of the store.
+type referenceSet struct {
+	regions []Region
+	vds     map[string][]VDSRecord //By WMI and code
+}
+
+var (
+	inMemory  int32
+	reference atomic.Value //*referenceSet
+)
+
+//LoadReferenceInMemory copies the WMI and VDS reference data to memory, so decode lookups are map and slice access without store reads.
+//The copy is replaced whenever the reference data is imported or edited.
+func LoadReferenceInMemory() error {
+	atomic.StoreInt32(&inMemory, 1)
+
+	return loadReference()
+}
+
+func loadReference() error {
+	var regions []Region
+	scan(ctx.Regions, func(obj husk.Dataer) {
+		regions = append(regions, *obj.(*Region))
+	})
+
+	//The store edits the countries and manufacturers of a region in place, the copy may not share them
+	data, err := json.Marshal(regions)
+
+	if err != nil {
+		return err
+	}
+
+	set := &referenceSet{vds: make(map[string][]VDSRecord)}
+	err = json.Unmarshal(data, &set.regions)
+
+	if err != nil {
+		return err
+	}
+
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		r := *obj.(*VDSRecord)
+		set.vds[r.WMICode+r.Code] = append(set.vds[r.WMICode+r.Code], r)
+	})
+
+	reference.Store(set)
+
+	return nil
+}
+
+//currentReference returns the in memory reference data, nil when it isn't loaded
+func currentReference() *referenceSet {
+	if atomic.LoadInt32(&inMemory) == 0 {
+		return nil
+	}
+
+	set, _ := reference.Load().(*referenceSet)
+
+	return set
+}
+
+//referenceChanged is deferred by every write of the regions or VDS records
+func referenceChanged() {
+	notFound.reset()
+
+	if atomic.LoadInt32(&inMemory) == 1 {
+		err := loadReference()
+
+		if err != nil {
+			panic(fmt.Errorf("reference data can't be reloaded: %s", err))
+		}
+	}
+}
+
+//regionByCode returns the in memory region of the VIN, ErrReferenceNotFound when there is none
+func (s *referenceSet) regionByCode(uniquevin string) (*Region, error) {
+	for i := range s.regions {
+		if s.regions[i].HasCode(uniquevin[:1]) {
+			return &s.regions[i], nil
+		}
+	}
+
+	return nil, ErrReferenceNotFound
+}
+
+//findVDSRecords returns the records for the code, which were active in one of the years
+func (s *referenceSet) findVDSRecords(wmi, code string, years []int) []VDSRecord {
+	var result []VDSRecord
+	match := byVDSYears(wmi, code, years)
+	records := s.vds[wmi+code]
+
+	for i := range records {
+		if match(&records[i]) {
+			result = append(result, records[i])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,43 @@
+package core
+
+import "testing"
+
+func TestReferenceSet(t *testing.T) {
+	set := &referenceSet{
+		regions: []Region{{Name: "Africa", StartChar: "A", EndChar: "H"}, {Name: "Europe", StartChar: "S", EndChar: "Z"}},
+		vds: map[string][]VDSRecord{
+			"WVWZZZ": {
+				{WMICode: "WVW", Code: "ZZZ", SeriesSpec: "Mk4", StartYear: 1998, EndYear: 2004},
+				{WMICode: "WVW", Code: "ZZZ", SeriesSpec: "Mk5", StartYear: 2005, EndYear: 2009},
+			},
+		},
+	}
+
+	r, err := set.regionByCode("WVWZZZ1JZXW")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Name != "Europe" {
+		t.Errorf("expected Europe, got %s", r.Name)
+	}
+
+	if _, err := set.regionByCode("MAT"); err == nil {
+		t.Error("expected no region for M")
+	}
+
+	recs := set.findVDSRecords("WVW", "ZZZ", []int{2006})
+
+	if len(recs) != 1 || recs[0].SeriesSpec != "Mk5" {
+		t.Errorf("expected Mk5, got %v", recs)
+	}
+
+	if recs := set.findVDSRecords("WVW", "ZZZ", []int{2004, 2005}); len(recs) != 2 {
+		t.Errorf("expected both records, got %v", recs)
+	}
+
+	if recs := set.findVDSRecords("WVW", "ZZZ", []int{2015}); len(recs) != 0 {
+		t.Error("expected no record outside the years")
+	}
+}
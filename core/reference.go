@@ -0,0 +1,445 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//FieldError is a validation failure of a single field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+//ValidationError lists every field which failed validation, so a form can show them all at once.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	var msgs []string
+
+	for _, f := range e {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+
+	return strings.Join(msgs, ", ")
+}
+
+//fieldErrors collects FieldErrors, err returns nil when there are none.
+type fieldErrors []FieldError
+
+func (e *fieldErrors) check(ok bool, field, msg string, args ...interface{}) {
+	if !ok {
+		*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(msg, args...)})
+	}
+}
+
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	return ValidationError(e)
+}
+
+//ErrReferenceNotFound is returned when the reference record to edit doesn't exist
+var ErrReferenceNotFound = errors.New("reference record not found")
+
+//ReferencePage is a page of reference records, with the total number of records which matched.
+type ReferencePage struct {
+	Total int
+	Items interface{}
+}
+
+//pageBounds returns the slice bounds of the page, an empty range when the page is out of bounds.
+func pageBounds(total, page, size int) (int, int) {
+	if page < 1 || size < 1 {
+		return 0, 0
+	}
+
+	start := (page - 1) * size
+
+	if start > total {
+		start = total
+	}
+
+	end := start + size
+
+	if end > total {
+		end = total
+	}
+
+	return start, end
+}
+
+//ManufacturerRow is a manufacturer with the region and country it's registered in.
+//Version is the Version of the region, edits of the manufacturer have to send it.
+type ManufacturerRow struct {
+	Region  string
+	Country string
+	Version int
+	Manufacturer
+}
+
+//SeriesRow is a series with the WMI and plant it's built by
+type SeriesRow struct {
+	WMICode   string
+	PlantCode string
+	Version   int //Version of the region
+	Series
+}
+
+//PlatformRow is a platform of a manufacturer
+type PlatformRow struct {
+	WMICode string
+	Version int //Version of the region
+	Platform
+}
+
+//BodyRow is the body of a platform
+type BodyRow struct {
+	WMICode      string
+	PlatformCode string
+	Version      int //Version of the region
+	Body
+}
+
+//matchesWMI is true when no WMI is given, or the code starts with it
+func matchesWMI(code, wmi string) bool {
+	return len(wmi) == 0 || strings.HasPrefix(code, strings.ToUpper(wmi))
+}
+
+//ListManufacturers returns a page of manufacturers, search matches the start of the WMI or any part of the name.
+func ListManufacturers(search string, page, size int) ReferencePage {
+	var rows []ManufacturerRow
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		if len(search) == 0 || matchesWMI(m.WMICode, search) || strings.Contains(strings.ToLower(m.Name), strings.ToLower(search)) {
+			rows = append(rows, ManufacturerRow{Region: region.Name, Country: country.Name, Version: region.Version, Manufacturer: m})
+		}
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].WMICode < rows[j].WMICode
+	})
+
+	start, end := pageBounds(len(rows), page, size)
+
+	return ReferencePage{Total: len(rows), Items: rows[start:end]}
+}
+
+//ListSeries returns a page of the series of manufacturers which match the WMI
+func ListSeries(wmi string, page, size int) ReferencePage {
+	var rows []SeriesRow
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		if !matchesWMI(m.WMICode, wmi) {
+			return
+		}
+
+		for _, plant := range m.AssemblyPlants {
+			for _, s := range plant.Series {
+				rows = append(rows, SeriesRow{WMICode: m.WMICode, PlantCode: plant.Code, Version: region.Version, Series: s})
+			}
+		}
+	})
+
+	start, end := pageBounds(len(rows), page, size)
+
+	return ReferencePage{Total: len(rows), Items: rows[start:end]}
+}
+
+//ListPlatforms returns a page of the platforms of manufacturers which match the WMI, platforms shared by series are listed once.
+func ListPlatforms(wmi string, page, size int) ReferencePage {
+	rows := platforms(wmi)
+	start, end := pageBounds(len(rows), page, size)
+
+	return ReferencePage{Total: len(rows), Items: rows[start:end]}
+}
+
+func platforms(wmi string) []PlatformRow {
+	var rows []PlatformRow
+	seen := make(map[string]bool)
+	eachManufacturer(func(region *Region, country Country, m Manufacturer) {
+		if !matchesWMI(m.WMICode, wmi) {
+			return
+		}
+
+		for _, plant := range m.AssemblyPlants {
+			for _, s := range plant.Series {
+				id := m.WMICode + "/" + s.Platform.Code
+
+				if len(s.Platform.Code) > 0 && !seen[id] {
+					seen[id] = true
+					rows = append(rows, PlatformRow{WMICode: m.WMICode, Version: region.Version, Platform: s.Platform})
+				}
+			}
+		}
+	})
+
+	return rows
+}
+
+//ListBodies returns a page of the bodies of the platforms of manufacturers which match the WMI
+func ListBodies(wmi string, page, size int) ReferencePage {
+	var rows []BodyRow
+
+	for _, p := range platforms(wmi) {
+		if len(p.Body.Code) > 0 {
+			rows = append(rows, BodyRow{WMICode: p.WMICode, PlatformCode: p.Code, Version: p.Version, Body: p.Body})
+		}
+	}
+
+	start, end := pageBounds(len(rows), page, size)
+
+	return ReferencePage{Total: len(rows), Items: rows[start:end]}
+}
+
+//ListVDS returns a page of the VDS records which match the WMI
+func ListVDS(wmi string, page, size int) ReferencePage {
+	var rows []VDSRecord
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		if rec := obj.(*VDSRecord); matchesWMI(rec.WMICode, wmi) {
+			rows = append(rows, *rec)
+		}
+	})
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].WMICode != rows[j].WMICode {
+			return rows[i].WMICode < rows[j].WMICode
+		}
+
+		return rows[i].Code < rows[j].Code
+	})
+
+	start, end := pageBounds(len(rows), page, size)
+
+	return ReferencePage{Total: len(rows), Items: rows[start:end]}
+}
+
+func validateYears(errs *fieldErrors, start, end int) {
+	errs.check(start >= 0, "StartYear", "can't be negative")
+	errs.check(end == 0 || start <= end, "EndYear", "%d is before the start year %d", end, start)
+}
+
+func validateManufacturer(m Manufacturer) error {
+	errs := fieldErrors{}
+	errs.check(len(m.WMICode) >= 2 && len(m.WMICode) <= 3, "WMICode", "must be 2 or 3 characters")
+	errs.check(len(strings.TrimSpace(m.Name)) > 0, "Name", "is required")
+	errs.check(m.VehicleType >= 0 && int(m.VehicleType) < len(vehTypes), "VehicleType", "is unknown")
+
+	return errs.err()
+}
+
+func validateSeries(s Series) error {
+	errs := fieldErrors{}
+	errs.check(len(strings.TrimSpace(s.Spec)) > 0, "Spec", "is required")
+	validateYears(&errs, s.StartYear, s.EndYear)
+
+	return errs.err()
+}
+
+func validatePlatform(p Platform) error {
+	errs := fieldErrors{}
+	errs.check(len(strings.TrimSpace(p.Code)) > 0, "Code", "is required")
+	validateYears(&errs, p.StartYear, p.EndYear)
+
+	return errs.err()
+}
+
+func validateBody(b Body) error {
+	errs := fieldErrors{}
+	errs.check(len(strings.TrimSpace(b.Code)) > 0, "Code", "is required")
+	errs.check(b.Doors >= 0, "Doors", "can't be negative")
+	validateYears(&errs, b.StartYear, b.EndYear)
+
+	return errs.err()
+}
+
+func validateVDS(rec VDSRecord) error {
+	errs := fieldErrors{}
+	errs.check(len(rec.WMICode) == 3, "WMICode", "must be 3 characters")
+	errs.check(len(rec.Code) == 5, "Code", "must be 5 characters")
+	errs.check(len(strings.TrimSpace(rec.SeriesSpec)) > 0, "SeriesSpec", "is required")
+	validateYears(&errs, rec.StartYear, rec.EndYear)
+
+	return errs.err()
+}
+
+//editManufacturer calls fn with the manufacturer registered for the WMI, and saves the region when fn succeeds.
+//The region is changed like Region.Update, version is the Version of the region that was read, see ManufacturerRow.
+func editManufacturer(wmi string, version int, fn func(m *Manufacturer) error) error {
+	wmi = strings.ToUpper(wmi)
+
+	if len(wmi) < 2 {
+		return ErrReferenceNotFound
+	}
+
+	rec, err := ctx.Regions.FindFirst(byUniqueVIN(wmi))
+
+	if err != nil {
+		return ErrReferenceNotFound
+	}
+
+	return changeRegion(KeyOf(rec.GetKey()), version, func(region *Region) error {
+		cIdx := region.countryIndex(wmi)
+
+		if cIdx == -1 {
+			return ErrReferenceNotFound
+		}
+
+		country := &region.Countries[cIdx]
+
+		for i := range country.Manufacturers {
+			if country.Manufacturers[i].WMICode == wmi {
+				return fn(&country.Manufacturers[i])
+			}
+		}
+
+		return ErrReferenceNotFound
+	})
+}
+
+//UpdateManufacturer replaces the name, description and vehicle type of the manufacturer, when its region hasn't been changed since it was read.
+func UpdateManufacturer(wmi string, version int, item Manufacturer) error {
+	item.WMICode = strings.ToUpper(wmi)
+	err := validateManufacturer(item)
+
+	if err != nil {
+		return err
+	}
+
+	return editManufacturer(wmi, version, func(m *Manufacturer) error {
+		m.Name = item.Name
+		m.Description = item.Description
+		m.VehicleType = item.VehicleType
+
+		return nil
+	})
+}
+
+//UpdateSeries replaces the series with the spec, built at the plant
+func UpdateSeries(wmi, plant, spec string, version int, item Series) error {
+	err := validateSeries(item)
+
+	if err != nil {
+		return err
+	}
+
+	return editManufacturer(wmi, version, func(m *Manufacturer) error {
+		for i := range m.AssemblyPlants {
+			p := &m.AssemblyPlants[i]
+
+			if p.Code != plant {
+				continue
+			}
+
+			for j := range p.Series {
+				if p.Series[j].Spec == spec {
+					p.Series[j] = item
+					return nil
+				}
+			}
+		}
+
+		return ErrReferenceNotFound
+	})
+}
+
+//eachPlatform calls fn for every series of the manufacturer on the platform, it fails when there are none.
+func eachPlatform(m *Manufacturer, code string, fn func(s *Series)) error {
+	found := false
+
+	for i := range m.AssemblyPlants {
+		for j := range m.AssemblyPlants[i].Series {
+			s := &m.AssemblyPlants[i].Series[j]
+
+			if s.Platform.Code == code {
+				found = true
+				fn(s)
+			}
+		}
+	}
+
+	if !found {
+		return ErrReferenceNotFound
+	}
+
+	return nil
+}
+
+//UpdatePlatform replaces the platform on every series of the manufacturer which uses it
+func UpdatePlatform(wmi, code string, version int, item Platform) error {
+	err := validatePlatform(item)
+
+	if err != nil {
+		return err
+	}
+
+	return editManufacturer(wmi, version, func(m *Manufacturer) error {
+		return eachPlatform(m, code, func(s *Series) {
+			s.Platform = item
+		})
+	})
+}
+
+//UpdateBody replaces the body of the platform
+func UpdateBody(wmi, platform string, version int, item Body) error {
+	err := validateBody(item)
+
+	if err != nil {
+		return err
+	}
+
+	return editManufacturer(wmi, version, func(m *Manufacturer) error {
+		return eachPlatform(m, platform, func(s *Series) {
+			s.Platform.Body = item
+		})
+	})
+}
+
+//UpdateVDS replaces the VDS record, when it hasn't been changed since it was read.
+func UpdateVDS(key Key, item VDSRecord) error {
+	item.WMICode = strings.ToUpper(item.WMICode)
+	item.Code = strings.ToUpper(item.Code)
+	err := validateVDS(item)
+
+	if err != nil {
+		return err
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	unlock := recordLocks.lock("vds/" + key.String())
+	defer unlock()
+
+	rec, err := ctx.VDS.FindByKey(key.key)
+
+	if err != nil {
+		return ErrReferenceNotFound
+	}
+
+	err = checkVersion(rec.Data().(*VDSRecord).Version, item.Version)
+
+	if err != nil {
+		return err
+	}
+
+	item.Version++
+	err = rec.Set(item)
+
+	if err != nil {
+		return err
+	}
+
+	defer referenceChanged()
+	defer ctx.VDS.Save()
+	return ctx.VDS.Update(rec)
+}
@@ -0,0 +1,163 @@
+package core
+
+import "testing"
+
+func TestValidateManufacturer_AllFields(t *testing.T) {
+	err := validateManufacturer(Manufacturer{WMICode: "W", VehicleType: 99})
+	verr, ok := err.(ValidationError)
+
+	if !ok {
+		t.Fatalf("expected a ValidationError, got %v", err)
+	}
+
+	fields := make(map[string]bool)
+
+	for _, f := range verr {
+		fields[f.Field] = true
+	}
+
+	for _, f := range []string{"WMICode", "Name", "VehicleType"} {
+		if !fields[f] {
+			t.Errorf("expected %s to fail, got %v", f, verr)
+		}
+	}
+}
+
+func TestValidateVDS_Years(t *testing.T) {
+	err := validateVDS(VDSRecord{WMICode: "WBA", Code: "8E910", SeriesSpec: "F30", StartYear: 2015, EndYear: 2012})
+
+	if err == nil || err.(ValidationError)[0].Field != "EndYear" {
+		t.Errorf("expected EndYear to fail, got %v", err)
+	}
+
+	err = validateVDS(VDSRecord{WMICode: "WBA", Code: "8E910", SeriesSpec: "F30", StartYear: 2012, EndYear: 2019})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPageBounds(t *testing.T) {
+	cases := [][5]int{
+		{10, 1, 3, 0, 3},
+		{10, 4, 3, 9, 10},
+		{10, 5, 3, 10, 10},
+		{10, 0, 3, 0, 0},
+	}
+
+	for _, c := range cases {
+		start, end := pageBounds(c[0], c[1], c[2])
+
+		if start != c[3] || end != c[4] {
+			t.Errorf("%v: got %d-%d", c, start, end)
+		}
+	}
+}
+
+func TestUpdateManufacturer_Copy(t *testing.T) {
+	before, err := localStore{}.FindRegion("1HD")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := manufacturerOf(before, "1HD")
+	changed := prev
+	changed.Name = "Harley-Davidson USA"
+
+	err = UpdateManufacturer("1HD", before.Version, changed)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer UpdateManufacturer("1HD", before.Version+1, prev)
+
+	err = UpdateManufacturer("1HD", before.Version, prev)
+
+	if err != ErrVersionConflict {
+		t.Errorf("expected an edit of the region which was read before to conflict, got %v", err)
+	}
+
+	after, err := localStore{}.FindRegion("1HD")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if after.Version != before.Version+1 {
+		t.Errorf("expected version %d, got %d", before.Version+1, after.Version)
+	}
+
+	if name := manufacturerOf(before, "1HD").Name; name != prev.Name {
+		t.Errorf("expected the region which was read before the edit to keep %q, got %q", prev.Name, name)
+	}
+
+	if name := manufacturerOf(after, "1HD").Name; name != changed.Name {
+		t.Errorf("expected %q, got %q", changed.Name, name)
+	}
+}
+
+func TestUpdateManufacturer_Concurrent(t *testing.T) {
+	before, err := localStore{}.FindRegion("1HD")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := manufacturerOf(before, "1HD")
+	names := []string{"Harley-Davidson A", "Harley-Davidson B", "Harley-Davidson C", "Harley-Davidson D"}
+	errs := make(chan error, len(names))
+
+	for _, name := range names {
+		item := prev
+		item.Name = name
+
+		go func() {
+			errs <- UpdateManufacturer("1HD", before.Version, item)
+		}()
+	}
+
+	saved := 0
+
+	for range names {
+		err := <-errs
+
+		switch err {
+		case nil:
+			saved++
+		case ErrVersionConflict:
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+
+	if saved != 1 {
+		t.Fatalf("expected one edit of version %d to be saved, got %d", before.Version, saved)
+	}
+
+	defer UpdateManufacturer("1HD", before.Version+1, prev)
+
+	after, err := localStore{}.FindRegion("1HD")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if after.Version != before.Version+1 {
+		t.Errorf("expected version %d, got %d", before.Version+1, after.Version)
+	}
+}
+
+//manufacturerOf returns the manufacturer of the WMI in the region
+func manufacturerOf(r Region, wmi string) Manufacturer {
+	for _, c := range r.Countries {
+		for _, m := range c.Manufacturers {
+			if m.WMICode == wmi {
+				return m
+			}
+		}
+	}
+
+	return Manufacturer{}
+}
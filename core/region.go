@@ -1,9 +1,8 @@
 package core
 
 import (
-	"strconv"
-
 	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/vinmath"
 )
 
 type Region struct {
@@ -11,6 +10,9 @@ type Region struct {
 	StartChar string
 	EndChar   string
 	Countries []Country
+	Version   int
+	//CheckDigit is the scheme which decides whether the region's VINs need a valid check digit, see CheckDigitRuleFor
+	CheckDigit *CheckDigitScheme `json:",omitempty"`
 }
 
 func (m Region) Valid() (bool, error) {
@@ -25,8 +27,8 @@ func (r *Region) HasCode(regionCode string) bool {
 	return s <= v && v <= e
 }
 
-func GetRegion(key husk.Key) (*Region, error) {
-	rec, err := ctx.Regions.FindByKey(key)
+func GetRegion(key Key) (*Region, error) {
+	rec, err := ctx.Regions.FindByKey(key.key)
 
 	if err != nil {
 		return nil, err
@@ -39,42 +41,147 @@ func GetAllRegions(page, size int) husk.Collection {
 	return ctx.Regions.Find(page, size, husk.Everything())
 }
 
+//GetRegionByCode returns the region of the VIN's first character, from memory when the reference data is loaded
 func GetRegionByCode(uniquevin string) (*Region, error) {
-	record, err := ctx.Regions.FindFirst(byUniqueVIN(uniquevin))
+	if set := currentReference(); set != nil {
+		return set.regionByCode(uniquevin)
+	}
+
+	region, err := currentStore().FindRegion(uniquevin)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return record.Data().(*Region), nil
+	return &region, nil
 }
 
 func getCharWeight(char string) int {
-	if val, err := strconv.Atoi(char); err == nil {
-		if val == 0 {
-			return 36
-		}
+	return vinmath.CharOrder(char)
+}
+
+//Update saves the region, when it hasn't been changed since it was read.
+func (p Region) Update(key Key) error {
+	return changeRegion(key, p.Version, func(r *Region) error {
+		*r = p
+		return nil
+	})
+}
+
+//changeRegion calls fn with a copy of the region, and saves it when fn succeeds and the region hasn't been changed since version was read.
+//The version is checked and written under the lock of the record, another writer can't pass the same check.
+func changeRegion(key Key, version int, fn func(r *Region) error) error {
+	done, err := writing()
 
-		return val + 26
+	if err != nil {
+		return err
 	}
 
-	//Alpha chars will return their index in the alphabet
-	return int(char[0] % 32)
-}
+	defer done()
+
+	unlock := recordLocks.lock("regions/" + key.String())
+	defer unlock()
+
+	rec, err := ctx.Regions.FindByKey(key.key)
+
+	if err != nil {
+		return err
+	}
+
+	region := rec.Data().(*Region).clone()
+	err = checkVersion(region.Version, version)
+
+	if err != nil {
+		return err
+	}
+
+	err = fn(&region)
+
+	if err != nil {
+		return err
+	}
 
-func (p Region) Update(key husk.Key) error {
-	reg, err := ctx.Regions.FindByKey(key)
+	region.Version = version + 1
+	err = writeRegion(rec, region)
 
 	if err != nil {
 		return err
 	}
 
-	err = reg.Set(p)
+	defer referenceChanged()
+	ctx.Regions.Save()
+
+	return nil
+}
+
+//writeRegion replaces the stored region with the changed copy, the stored region is kept when the write fails.
+//The caller has to hold writing and the lock of the region.
+func writeRegion(rec husk.Recorder, region Region) error {
+	prev := rec.Data()
+	err := rec.Set(region)
+
+	if err == nil {
+		err = ctx.Regions.Update(rec)
+	}
 
 	if err != nil {
+		rec.Set(prev)
 		return err
 	}
 
-	defer ctx.Regions.Save()
-	return ctx.Regions.Update(reg)
+	return nil
+}
+
+//clone returns a copy of the region which shares no countries, manufacturers, plants or series with it.
+//Decodes keep reading the stored region while the copy is changed.
+func (r Region) clone() Region {
+	r.Countries = append(r.Countries[:0:0], r.Countries...)
+	r.CheckDigit = r.CheckDigit.clone()
+
+	for i := range r.Countries {
+		c := &r.Countries[i]
+		c.CheckDigit = c.CheckDigit.clone()
+		c.Manufacturers = append(c.Manufacturers[:0:0], c.Manufacturers...)
+
+		for j := range c.Manufacturers {
+			m := &c.Manufacturers[j]
+			m.AssemblyPlants = append(m.AssemblyPlants[:0:0], m.AssemblyPlants...)
+
+			for k := range m.AssemblyPlants {
+				p := &m.AssemblyPlants[k]
+				p.Series = append(p.Series[:0:0], p.Series...)
+			}
+		}
+	}
+
+	return r
+}
+
+//countryIndex returns the index of the Country the WMI is assigned to, or -1 when there is none.
+func (r *Region) countryIndex(wmi string) int {
+	regionCode := wmi[:1]
+	countryCode := wmi[1:2]
+
+	for i := 0; i < len(r.Countries); i++ {
+		country := r.Countries[i]
+
+		if country.RegionCode == regionCode && country.HasCode(countryCode) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//eachManufacturer calls fn for every manufacturer in the reference data
+func eachManufacturer(fn func(region *Region, country Country, manufacturer Manufacturer)) {
+	scan(ctx.Regions, func(obj husk.Dataer) {
+		region := obj.(*Region)
+
+		for _, country := range region.Countries {
+			for _, manufacturer := range country.Manufacturers {
+				fn(region, country, manufacturer)
+			}
+		}
+	})
 }
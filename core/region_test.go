@@ -0,0 +1,50 @@
+package core
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestRegion_Update_Conflict(t *testing.T) {
+	rec, err := ctx.Regions.FindFirst(byUniqueVIN("1HG"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := KeyOf(rec.GetKey())
+	region := *rec.Data().(*Region)
+	errs := make(chan error, 50)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-start
+			errs <- region.Update(key)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+
+	written := 0
+
+	for err := range errs {
+		switch err {
+		case nil:
+			written++
+		case ErrVersionConflict:
+		default:
+			t.Fatal(err)
+		}
+	}
+
+	if written != 1 {
+		t.Errorf("expected one writer to pass the version check, got %d", written)
+	}
+}
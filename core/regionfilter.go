@@ -17,3 +17,9 @@ func byUniqueVIN(uniquevin string) regionFilter {
 		return obj.HasCode(regionChar)
 	}
 }
+
+func byRegionName(name string) regionFilter {
+	return func(obj *Region) bool {
+		return obj.Name == name
+	}
+}
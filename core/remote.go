@@ -0,0 +1,192 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+type remoteStore struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+//NewRemoteStore returns the Store of another deployment, read through its /sync endpoints.
+//The token is sent as a bearer token, and needs the Admin role.
+func NewRemoteStore(baseURL, token string) Store {
+	return remoteStore{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+func (s remoteStore) VINsSince(since time.Time) ([]VIN, error) {
+	var result []VIN
+	err := s.get("/sync/vins?since="+url.QueryEscape(since.Format(time.RFC3339)), &result)
+
+	return result, err
+}
+
+func (s remoteStore) Regions() ([]Region, error) {
+	var result []Region
+	err := s.get("/sync/regions", &result)
+
+	return result, err
+}
+
+func (s remoteStore) VDSRecords() ([]VDSRecord, error) {
+	var result []VDSRecord
+	err := s.get("/sync/vds", &result)
+
+	return result, err
+}
+
+//ErrReadOnly is returned when a VIN is created in the Store of another deployment
+var ErrReadOnly = errors.New("store is read-only")
+
+//The lookups of a remote store read the whole table from its /sync endpoints, they're meant for tests and tools, not decodes.
+
+func (s remoteStore) FindVIN(fullvin string) (VIN, error) {
+	fullvin = vinmath.Canonical(fullvin)
+	vins, err := s.FindVINs(func(v VIN) bool { return v.Full == fullvin }, 1, 1)
+
+	if err != nil {
+		return VIN{}, err
+	}
+
+	if len(vins) == 0 {
+		return VIN{}, ErrVINNotFound
+	}
+
+	return vins[0], nil
+}
+
+func (s remoteStore) CreateVIN(v VIN) (VIN, error) {
+	return VIN{}, ErrReadOnly
+}
+
+func (s remoteStore) FindVINs(filter func(v VIN) bool, page, size int) ([]VIN, error) {
+	all, err := s.VINsSince(time.Time{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []VIN
+
+	for _, v := range all {
+		if filter == nil || filter(v) {
+			result = append(result, v)
+		}
+	}
+
+	return paged(result, page, size), nil
+}
+
+func (s remoteStore) FindRegion(uniquevin string) (Region, error) {
+	regions, err := s.Regions()
+
+	if err != nil {
+		return Region{}, err
+	}
+
+	for _, r := range regions {
+		if len(uniquevin) > 0 && r.HasCode(uniquevin[:1]) {
+			return r, nil
+		}
+	}
+
+	return Region{}, ErrReferenceNotFound
+}
+
+func (s remoteStore) FindVDS(wmi, code string, years []int) (VDSRecord, error) {
+	records, err := s.VDSRecords()
+
+	if err != nil {
+		return VDSRecord{}, err
+	}
+
+	match := byVDSYears(wmi, code, years)
+
+	for _, r := range records {
+		if match(&r) {
+			return r, nil
+		}
+	}
+
+	return VDSRecord{}, ErrReferenceNotFound
+}
+
+func (s remoteStore) FindVDSRecords(wmi, code string, years []int) ([]VDSRecord, error) {
+	records, err := s.VDSRecords()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []VDSRecord
+	match := byVDSYears(wmi, code, years)
+
+	for i := range records {
+		if match(&records[i]) {
+			result = append(result, records[i])
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, ErrReferenceNotFound
+	}
+
+	return result, nil
+}
+
+func (s remoteStore) get(path string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, s.baseURL+path, nil)
+
+	if err != nil {
+		return err
+	}
+
+	if len(s.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", path, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return err
+	}
+
+	//The API wraps results in a Data envelope
+	envelope := struct {
+		Data json.RawMessage
+	}{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) && json.Unmarshal(body, &envelope) == nil && len(envelope.Data) > 0 {
+		body = envelope.Data
+	}
+
+	return json.Unmarshal(body, result)
+}
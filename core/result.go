@@ -0,0 +1,77 @@
+package core
+
+import (
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Result is a decoded VIN split in what the identifier says by itself, and what was found in the reference data.
+//Consumers can tell a field which can't be wrong, ie. the year code, from one which depends on the reference data being complete.
+type Result struct {
+	Parsed  Parsed
+	Decoded Decoded
+}
+
+//Parsed is read from the characters of the VIN, it doesn't need reference data
+type Parsed struct {
+	Full            string
+	Class           string `json:",omitempty"` //ClassPIN for PINs, empty for VINs
+	Segments        vinmath.Segments
+	Unique          string //Positions 1 to 11
+	Serial          int    //Positions 12 to 17, 0 when the serial was purged
+	CheckDigitValid bool   //The check digit matches ISO 3779, exempt WMIs and optional regions are decoded without it
+	Region          string //Of the first character
+	Years           []int  `json:",omitempty"` //The model years the year code stands for
+	VDS             vds.RawVDS
+}
+
+//Decoded is found in the reference data by the parsed identifier, fields the reference data doesn't have are empty
+type Decoded struct {
+	WMInfo          WMInfo
+	VDSInfo         vds.VDSInfo
+	Series          Series
+	VDSAlternatives []VDSCandidate    `json:",omitempty"`
+	PlantCountry    string            `json:",omitempty"`
+	Warnings        []Warning         `json:",omitempty"`
+	Provenance      map[string]string `json:",omitempty"`
+}
+
+//Result returns the parsed and decoded views of the VIN
+func (m VIN) Result() Result {
+	return Result{
+		Parsed: m.parsed(),
+		Decoded: Decoded{
+			WMInfo:          m.WMInfo,
+			VDSInfo:         m.VDSInfo,
+			Series:          m.Series,
+			VDSAlternatives: m.VDSAlternatives,
+			PlantCountry:    m.PlantCountry,
+			Warnings:        m.Warnings,
+			Provenance:      m.Provenance,
+		},
+	}
+}
+
+func (m VIN) parsed() Parsed {
+	result := Parsed{
+		Full:   m.Full,
+		Class:  m.Class,
+		Unique: m.Unique,
+		Serial: m.Serial,
+	}
+
+	if len(m.Full) != 17 {
+		return result
+	}
+
+	result.Segments = vinmath.Segment(m.Full)
+	result.CheckDigitValid = len(m.HashedVIN) == 0 && vinmath.CheckDigit(m.Full) == result.Segments.CheckDigit
+	result.Region = vinmath.Region(m.Full[:1])
+	result.VDS = vds.NewRawVDS(m.Full[3:9])
+
+	if m.Class != ClassPIN {
+		result.Years, _ = vinmath.Years(result.Segments.YearCode)
+	}
+
+	return result
+}
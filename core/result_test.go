@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestVIN_Result(t *testing.T) {
+	v := VIN{
+		Full:         "5NPEU46F77H259112",
+		Unique:       "5NPEU46F77H",
+		Serial:       259112,
+		Years:        []int{2007},
+		WMInfo:       WMInfo{Manufacturer: "Hyundai", Country: "United States"},
+		VDSInfo:      vds.VDSInfo{Code: "EU46F7", BodyStyle: "Sedan"},
+		PlantCountry: "United States",
+	}
+
+	result := v.Result()
+	parsed := result.Parsed
+
+	if parsed.Segments.WMI != "5NP" || parsed.Segments.YearCode != "7" || parsed.Serial != 259112 {
+		t.Errorf("expected the segments and serial of the VIN, got %+v", parsed)
+	}
+
+	if !parsed.CheckDigitValid {
+		t.Error("expected the check digit to be valid")
+	}
+
+	if parsed.Region != "North America" || parsed.VDS.String() != "EU46F7" {
+		t.Errorf("expected the region and VDS of the characters, got %s and %s", parsed.Region, parsed.VDS)
+	}
+
+	if len(parsed.Years) == 0 {
+		t.Errorf("expected the years of the year code, got %v", parsed.Years)
+	}
+
+	if result.Decoded.WMInfo.Manufacturer != "Hyundai" || result.Decoded.VDSInfo.BodyStyle != "Sedan" || result.Decoded.PlantCountry != "United States" {
+		t.Errorf("expected the decoded fields, got %+v", result.Decoded)
+	}
+}
+
+func TestVIN_Result_CheckDigit(t *testing.T) {
+	v := VIN{Full: "5NPEU46F07H259112"}
+
+	if v.Result().Parsed.CheckDigitValid {
+		t.Error("expected a wrong check digit to be invalid")
+	}
+
+	v.Full = "5NPEU46F77H" + maskedSerial
+	v.HashedVIN = "purged"
+
+	if v.Result().Parsed.CheckDigitValid {
+		t.Error("expected the check digit of a purged VIN to be invalid")
+	}
+}
+
+func TestVIN_Result_PIN(t *testing.T) {
+	v := VIN{Full: "1RW8320RCDD062154", Class: ClassPIN}
+
+	if years := v.Result().Parsed.Years; len(years) > 0 {
+		t.Errorf("expected a PIN to have no model years, got %v", years)
+	}
+}
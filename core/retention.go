@@ -0,0 +1,392 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//Fields a RetentionPolicy can purge
+const (
+	//RetainSerial replaces the serial of the VIN with a hash, the rest of the record is kept for aggregates
+	RetainSerial = "serial"
+	//RetainAttributes removes the custom attributes
+	RetainAttributes = "attributes"
+	//RetainRecord deletes the whole record
+	RetainRecord = "record"
+)
+
+//maskedSerial replaces the serial of anonymised VINs, so Full keeps its length
+const maskedSerial = "******"
+
+//RetentionPolicy purges a field once the record is older than After
+type RetentionPolicy struct {
+	Field string
+	After time.Duration
+}
+
+//RetentionReport is the number of records changed by ApplyRetention
+type RetentionReport struct {
+	Anonymised        int
+	AttributesRemoved int
+	Deleted           int
+	Related           int //Quarantined VINs, overrides, hulls and disputes which were deleted or anonymised
+}
+
+//RetentionKey is the secret used to hash VINs, without it hashes of VINs can be reversed by trying every serial.
+var RetentionKey []byte
+
+//ErrNoRetentionKey is returned when serials would be anonymised without a RetentionKey
+var ErrNoRetentionKey = errors.New("serials can't be anonymised without a retention key")
+
+//HashVIN returns the keyed hash which replaces the full VIN of anonymised records.
+//The same VIN always has the same hash, so distinct vehicles can still be counted.
+func HashVIN(fullvin string) string {
+	mac := hmac.New(sha256.New, RetentionKey)
+	mac.Write([]byte(fullvin))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//ParseRetention reads policies like "serial=5y,attributes=90d,record=10y".
+//Ages are in years (y), days (d) or Go durations.
+func ParseRetention(s string) ([]RetentionPolicy, error) {
+	var result []RetentionPolicy
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		if len(part) == 0 {
+			continue
+		}
+
+		kv := strings.SplitN(part, "=", 2)
+
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("retention %s needs a field=age", part)
+		}
+
+		field := strings.TrimSpace(kv[0])
+
+		if field != RetainSerial && field != RetainAttributes && field != RetainRecord {
+			return nil, fmt.Errorf("unable to retain field %s", field)
+		}
+
+		age, err := parseAge(strings.TrimSpace(kv[1]))
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, RetentionPolicy{Field: field, After: age})
+	}
+
+	return result, nil
+}
+
+func parseAge(s string) (time.Duration, error) {
+	day := 24 * time.Hour
+
+	for suffix, unit := range map[string]time.Duration{"y": 365 * day, "d": day} {
+		if strings.HasSuffix(s, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(s, suffix))
+
+			if err != nil {
+				return 0, fmt.Errorf("invalid age %s", s)
+			}
+
+			return time.Duration(n) * unit, nil
+		}
+	}
+
+	return time.ParseDuration(s)
+}
+
+//ApplyRetention purges the fields of records which are older than their policy allows
+func ApplyRetention(policies []RetentionPolicy, now time.Time) (RetentionReport, error) {
+	result := RetentionReport{}
+
+	if len(policies) == 0 {
+		return result, nil
+	}
+
+	for _, p := range policies {
+		if p.Field == RetainSerial && len(RetentionKey) == 0 {
+			return result, ErrNoRetentionKey
+		}
+	}
+
+	err := retainRelated(policies, now, &result)
+
+	if err != nil {
+		return result, err
+	}
+
+	var records []husk.Recorder
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+
+		for _, p := range policies {
+			if now.Sub(v.CreatedAt) > p.After && !retained(v, p.Field) {
+				rec, err := findVIN(v.id())
+
+				if err == nil {
+					records = append(records, rec)
+				}
+
+				return
+			}
+		}
+	})
+
+	if len(records) == 0 {
+		return result, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return result, err
+	}
+
+	defer done()
+	defer ctx.VIN.Save()
+
+	for _, rec := range records {
+		err = retain(rec, policies, now, &result)
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+//retain purges the fields of a copy of the stored VIN, the record and its index only change once the copy is written.
+//The caller has to hold writing.
+func retain(rec husk.Recorder, policies []RetentionPolicy, now time.Time, result *RetentionReport) error {
+	unlock := vinLocks.lock(rec.Data().(*VIN).Full)
+	defer unlock()
+
+	stored := *rec.Data().(*VIN)
+	v := stored
+	deleted := false
+
+	for _, p := range policies {
+		if now.Sub(v.CreatedAt) <= p.After || retained(&v, p.Field) {
+			continue
+		}
+
+		switch p.Field {
+		case RetainRecord:
+			deleted = true
+		case RetainSerial:
+			v.HashedVIN = HashVIN(v.Full)
+			v.Full = v.Full[:11] + maskedSerial
+			v.Serial = 0
+			result.Anonymised++
+		case RetainAttributes:
+			v.Attributes = nil
+			result.AttributesRemoved++
+		}
+	}
+
+	if deleted {
+		err := ctx.VIN.Delete(rec.GetKey())
+
+		if err != nil {
+			return err
+		}
+
+		index.remove(stored)
+		stats.remove(stored)
+		result.Deleted++
+		return nil
+	}
+
+	v.seal()
+	err := rec.Set(v)
+
+	if err == nil {
+		err = ctx.VIN.Update(rec)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	index.remove(stored)
+	indexVIN(v, rec.GetKey())
+	stats.replace(stored, v)
+
+	return nil
+}
+
+//retainRelated purges the VINs which are kept outside the VIN table, once the serial or the record of the VIN would be purged.
+//Quarantined VINs, overrides and hulls are deleted, an anonymised override would apply to every anonymised VIN with the same prefix.
+//Disputes keep their WMI and VDS for the metrics, until the record is deleted.
+func retainRelated(policies []RetentionPolicy, now time.Time, result *RetentionReport) error {
+	serial := retentionAge(policies, RetainSerial)
+	record := retentionAge(policies, RetainRecord)
+	purge := record
+
+	if serial > 0 && (purge == 0 || serial < purge) {
+		purge = serial
+	}
+
+	if purge == 0 {
+		return nil
+	}
+
+	expired := func(at time.Time, age time.Duration) bool {
+		return age > 0 && now.Sub(at) > age
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	purges := []struct {
+		table  husk.Tabler
+		filter husk.Filterer
+	}{
+		{ctx.Quarantine, quarantineFilter(func(obj *QuarantinedVIN) bool { return expired(obj.QuarantinedAt, purge) })},
+		{ctx.Overrides, overrideFilter(func(obj *Override) bool { return expired(obj.UpdatedAt, purge) })},
+		{ctx.Hulls, hullFilter(func(obj *Hull) bool { return expired(obj.CreatedAt, purge) })},
+		{ctx.Disputes, disputeFilter(func(obj *Dispute) bool { return expired(obj.CreatedAt, record) })},
+	}
+
+	for _, p := range purges {
+		n, err := purgeWhere(p.table, p.filter)
+		result.Related += n
+
+		if err != nil {
+			return err
+		}
+	}
+
+	defer ctx.Disputes.Save()
+
+	for {
+		rec, err := ctx.Disputes.FindFirst(disputeFilter(func(obj *Dispute) bool {
+			return expired(obj.CreatedAt, serial) && !strings.HasSuffix(obj.Full, maskedSerial)
+		}))
+
+		if err != nil {
+			return nil
+		}
+
+		d := *rec.Data().(*Dispute)
+		d.Full = uniqueOf(d.Full) + maskedSerial
+		d.Checksum = d.checksum()
+		err = rec.Set(d)
+
+		if err == nil {
+			err = ctx.Disputes.Update(rec)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		result.Related++
+	}
+}
+
+//purgeWhere deletes the records of the table which match the filter, and returns how many were deleted.
+//The caller has to hold writing.
+func purgeWhere(t husk.Tabler, filter husk.Filterer) (int, error) {
+	n := 0
+
+	for {
+		rec, err := t.FindFirst(filter)
+
+		if err != nil {
+			break
+		}
+
+		err = t.Delete(rec.GetKey())
+
+		if err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	if n > 0 {
+		t.Save()
+	}
+
+	return n, nil
+}
+
+//retentionAge returns the age after which the field is purged, zero when it's never purged
+func retentionAge(policies []RetentionPolicy, field string) time.Duration {
+	for _, p := range policies {
+		if p.Field == field {
+			return p.After
+		}
+	}
+
+	return 0
+}
+
+//retained returns true when the field has already been purged
+func retained(v *VIN, field string) bool {
+	switch field {
+	case RetainSerial:
+		return len(v.HashedVIN) > 0
+	case RetainAttributes:
+		return len(v.Attributes) == 0
+	}
+
+	return false
+}
+
+//StartRetention applies the policies every interval, until the returned func is called.
+func StartRetention(policies []RetentionPolicy, interval time.Duration) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			report, err := ApplyRetention(policies, time.Now())
+
+			if err != nil {
+				log.Println("retention", err)
+			} else if report != (RetentionReport{}) {
+				log.Printf("retention: %+v\n", report)
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
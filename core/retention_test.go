@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/hin"
+)
+
+func TestParseRetention(t *testing.T) {
+	result, err := ParseRetention("serial=5y, attributes=90d,record=87600h")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []RetentionPolicy{
+		{Field: RetainSerial, After: 5 * 365 * 24 * time.Hour},
+		{Field: RetainAttributes, After: 90 * 24 * time.Hour},
+		{Field: RetainRecord, After: 87600 * time.Hour},
+	}
+
+	if len(result) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	for i, p := range expected {
+		if result[i] != p {
+			t.Errorf("expected %v, got %v", p, result[i])
+		}
+	}
+}
+
+func TestParseRetention_UnknownField(t *testing.T) {
+	_, err := ParseRetention("colour=1y")
+
+	if err == nil {
+		t.Error("expecting error")
+	}
+}
+
+func TestApplyRetention_NoKey(t *testing.T) {
+	key := RetentionKey
+	RetentionKey = nil
+	defer func() { RetentionKey = key }()
+
+	_, err := ApplyRetention([]RetentionPolicy{{Field: RetainSerial, After: time.Hour}}, time.Now())
+
+	if err != ErrNoRetentionKey {
+		t.Errorf("expected serials to be refused without a key, got %v", err)
+	}
+}
+
+func TestApplyRetention_Related(t *testing.T) {
+	key := RetentionKey
+	RetentionKey = []byte("related")
+	defer func() { RetentionKey = key }()
+
+	old := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	h, err := hin.Parse("XYZ0001AK910")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created := []husk.CreateSet{
+		ctx.Quarantine.Create(QuarantinedVIN{Full: "JN1AZ4EH7DM430130", QuarantinedAt: old}),
+		ctx.Quarantine.Create(QuarantinedVIN{Full: "JN1AZ4EH7DM430131", QuarantinedAt: time.Now()}),
+		ctx.Overrides.Create(Override{Full: "JN1AZ4EH7DM430132", Fields: map[string]string{"series": "370Z"}, UpdatedAt: old}),
+		ctx.Hulls.Create(Hull{HIN: h, CreatedAt: old}),
+		ctx.Disputes.Create(Dispute{Full: "JN1AZ4EH7DM430133", Field: "series", CreatedAt: old}),
+		ctx.Disputes.Create(Dispute{Full: "JN1AZ4EH7DM430134", Field: "series", CreatedAt: old.AddDate(-20, 0, 0)}),
+	}
+
+	for _, cset := range created {
+		if cset.Error != nil {
+			t.Fatal(cset.Error)
+		}
+	}
+
+	policies := []RetentionPolicy{{Field: RetainSerial, After: 20 * 365 * 24 * time.Hour}, {Field: RetainRecord, After: 30 * 365 * 24 * time.Hour}}
+	report, err := ApplyRetention(policies, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Related != 5 {
+		t.Errorf("expected 5 related records to be purged, got %+v", report)
+	}
+
+	for i, kept := range []bool{false, true, false, false, true, false} {
+		_, err := []husk.Tabler{ctx.Quarantine, ctx.Quarantine, ctx.Overrides, ctx.Hulls, ctx.Disputes, ctx.Disputes}[i].FindByKey(created[i].Record.GetKey())
+
+		if (err == nil) != kept {
+			t.Errorf("#%d: expected kept to be %v, got %v", i, kept, err)
+		}
+	}
+
+	d, err := ctx.Disputes.FindByKey(created[4].Record.GetKey())
+
+	if err == nil && d.Data().(*Dispute).Full != "JN1AZ4EH7DM"+maskedSerial {
+		t.Errorf("expected the serial of the dispute to be masked, got %s", d.Data().(*Dispute).Full)
+	}
+}
@@ -0,0 +1,16 @@
+package core
+
+import "github.com/louisevanderlith/husk"
+
+//scanner is a filter that never matches, it's used to visit every record in a table.
+type scanner func(obj husk.Dataer)
+
+func (f scanner) Filter(obj husk.Dataer) bool {
+	f(obj)
+	return false
+}
+
+//scan calls fn with the data of every record in the table
+func scan(t husk.Tabler, fn func(obj husk.Dataer)) {
+	t.Find(1, 1, scanner(fn))
+}
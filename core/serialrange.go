@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+const (
+	//FlagSerialOutOfRange marks VINs with a serial outside the published production range of the plant and year
+	FlagSerialOutOfRange = "serial-out-of-range"
+)
+
+//SerialRange is a published range of serial numbers, produced by a plant in a model year.
+type SerialRange struct {
+	WMICode   string `hsk:"size(3)"`
+	PlantCode string //Position 11, empty when the range applies to every plant
+	Year      int
+	Start     int
+	End       int
+	Source    string    //Where the range was published
+	BuiltFrom time.Time //Production date of the first serial, optional
+	BuiltTo   time.Time //Production date of the last serial, optional
+}
+
+func (m SerialRange) Valid() (bool, error) {
+	if m.Start > m.End {
+		return false, fmt.Errorf("range starts at %d after it ends at %d", m.Start, m.End)
+	}
+
+	if m.BuiltFrom.After(m.BuiltTo) && !m.BuiltTo.IsZero() {
+		return false, fmt.Errorf("range was built from %s after %s", m.BuiltFrom.Format("2006-01-02"), m.BuiltTo.Format("2006-01-02"))
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m SerialRange) String() string {
+	return fmt.Sprintf("%s%s %d %d-%d", m.WMICode, m.PlantCode, m.Year, m.Start, m.End)
+}
+
+//Contains returns true when the serial is in the range
+func (m SerialRange) Contains(serial int) bool {
+	return m.Start <= serial && serial <= m.End
+}
+
+//ImportSerialRanges adds the ranges which aren't in the registry yet
+func ImportSerialRanges(items []SerialRange, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []SerialRange
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+		item.PlantCode = strings.ToUpper(item.PlantCode)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.Serials.FindFirst(bySerialRange(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.Serials.Save()
+
+	for _, item := range adds {
+		cset := ctx.Serials.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//serialInRange returns false when ranges are published for the plant and years of the VIN, and the serial isn't in any of them.
+//VINs without published ranges are always in range.
+func serialInRange(v VIN) bool {
+	wmi := v.Full[:3]
+	plant := v.Full[10:11]
+	known := false
+	inRange := false
+
+	scan(ctx.Serials, func(obj husk.Dataer) {
+		r := obj.(*SerialRange)
+
+		if r.WMICode != wmi || (len(r.PlantCode) > 0 && r.PlantCode != plant) || !containsYear(v.Years, r.Year) {
+			return
+		}
+
+		known = true
+
+		if r.Contains(v.Serial) {
+			inRange = true
+		}
+	})
+
+	return !known || inRange
+}
+
+func containsYear(years []int, year int) bool {
+	for _, y := range years {
+		if y == year {
+			return true
+		}
+	}
+
+	return false
+}
+
+type serialFilter func(obj *SerialRange) bool
+
+func (f serialFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*SerialRange))
+}
+
+func bySerialRange(rec SerialRange) serialFilter {
+	return func(obj *SerialRange) bool {
+		return obj.WMICode == rec.WMICode && obj.PlantCode == rec.PlantCode && obj.Year == rec.Year &&
+			obj.Start == rec.Start && obj.End == rec.End
+	}
+}
@@ -0,0 +1,82 @@
+package core
+
+import "testing"
+
+func TestSerialRange_Valid(t *testing.T) {
+	if ok, _ := (SerialRange{WMICode: "KMH", Year: 2001, Start: 900, End: 500}).Valid(); ok {
+		t.Error("expected a range which starts after it ends to be invalid")
+	}
+
+	r := SerialRange{WMICode: "KMH", Year: 2001, Start: 500, End: 900}
+
+	if ok, err := r.Valid(); !ok {
+		t.Fatal(err)
+	}
+
+	if !r.Contains(500) || !r.Contains(900) || r.Contains(901) {
+		t.Errorf("expected %s to contain its bounds only", r)
+	}
+}
+
+func TestImportSerialRanges(t *testing.T) {
+	decoded, err := BuildInfo("KMHCG45C41U000001")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := []SerialRange{
+		{WMICode: "kmh", PlantCode: "u", Year: decoded.ModelYear(), Start: 500, End: 900, Source: "test"},
+		{WMICode: "KMH", Year: decoded.ModelYear(), Start: 900, End: 500},
+	}
+
+	diff, err := ImportSerialRanges(items, ImportOptions{DryRun: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Adds) != 1 || len(diff.Conflicts) != 1 {
+		t.Fatalf("expected an add and a conflict, got %+v", diff)
+	}
+
+	if len(decoded.Flags) != 0 {
+		t.Errorf("expected a VIN without published ranges not to be flagged, got %v", decoded.Flags)
+	}
+
+	_, err = ImportSerialRanges(items, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err = ImportSerialRanges(items, ImportOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff.Adds) != 0 {
+		t.Errorf("expected the range to be added once, got %+v", diff)
+	}
+
+	outside, err := BuildInfo("KMHCG45C41U000001")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !outside.flagged(FlagSerialOutOfRange) {
+		t.Errorf("expected a serial outside the range to be flagged, got %v", outside.Flags)
+	}
+
+	inside, err := BuildInfo("KMHCG45C41U000600")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inside.flagged(FlagSerialOutOfRange) {
+		t.Errorf("expected a serial in the range not to be flagged, got %v", inside.Flags)
+	}
+}
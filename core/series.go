@@ -1,6 +1,11 @@
 package core
 
-import "github.com/louisevanderlith/husk"
+import (
+	"fmt"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/core/vds"
+)
 
 type Series struct {
 	Platform  Platform
@@ -12,3 +17,59 @@ type Series struct {
 func (m Series) Valid() (bool, error) {
 	return husk.ValidateStruct(&m)
 }
+
+//FindSeries returns the Series identified by the VDS.
+//A matching VDSRecord is used first, then the chassis code decoded from the VDS.
+//When the chassis isn't in the reference data, a Series with only the Platform code is returned.
+func FindSeries(uniquevin string, info vds.VDSInfo, years []int) (Series, bool) {
+	result, _, ok := findSeries(uniquevin, info, years)
+
+	return result, ok
+}
+
+//findSeries is FindSeries for the VDS records ranked by the markets, see RankVDS.
+//The VDS records which matched but weren't used are returned as alternatives.
+func findSeries(uniquevin string, info vds.VDSInfo, years []int, markets ...string) (Series, []VDSCandidate, bool) {
+	var series []Series
+	manufacturer, err := FindManufacturer(uniquevin)
+
+	if err == nil {
+		for _, plant := range manufacturer.AssemblyPlants {
+			series = append(series, plant.Series...)
+		}
+	}
+
+	key := fmt.Sprintf("vds:%s%s%v", uniquevin[:3], info.Code, years)
+
+	var alternatives []VDSCandidate
+
+	if _, miss := notFound.get(key); !miss {
+		candidates := findVDSCandidates(uniquevin[:3], info.Code, years, markets...)
+
+		if len(candidates) == 0 {
+			notFound.put(key, true)
+		}
+
+		for i, c := range candidates {
+			for _, s := range series {
+				if s.Spec == c.Record.SeriesSpec {
+					return s, append(candidates[:i:i], candidates[i+1:]...), true
+				}
+			}
+		}
+
+		alternatives = candidates
+	}
+
+	if len(info.Chassis) == 0 {
+		return Series{}, alternatives, false
+	}
+
+	for _, s := range series {
+		if s.Platform.Code == info.Chassis {
+			return s, alternatives, true
+		}
+	}
+
+	return Series{Platform: Platform{Code: info.Chassis}}, alternatives, true
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"time"
+
+	"github.com/louisevanderlith/vin/config"
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//DefaultMode is the validation mode of requests which don't name one
+var DefaultMode = vinmath.Strict
+
+//NewService applies the settings and creates the context, it's called once at startup instead of CreateContext.
+//The Preflight report is logged, an error is returned when the coverage is below the configured minimum.
+func NewService(cfg config.Config) error {
+	mode, err := vinmath.ParseMode(cfg.Validation.Mode)
+
+	if err != nil {
+		return err
+	}
+
+	chars, err := vinmath.ParseCharPolicy(cfg.Validation.Chars)
+
+	if err != nil {
+		return err
+	}
+
+	budget, err := ParseDecodeBudget(cfg.Decoders.Budget)
+
+	if err != nil {
+		return err
+	}
+
+	retention, err := ParseRetention(cfg.Store.Retention)
+
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Store.Seed) > 0 {
+		seedFile = cfg.Store.Seed
+	}
+
+	if cfg.Cache.BloomSize > 0 {
+		bloomMinimum = cfg.Cache.BloomSize
+	}
+
+	NegativeTTL = time.Duration(cfg.Cache.NegativeTTL)
+	QuarantineEnabled = cfg.Store.Quarantine
+	FuzzyWMI = cfg.Decoders.FuzzyWMI
+	DefaultMode = mode
+	DefaultCharPolicy = chars
+	RetentionKey = []byte(cfg.Keys.RetentionKey)
+	CloneWindow = time.Duration(cfg.Store.CloneWindow)
+
+	SetDecodeBudget(budget)
+	SetDefaultQuota(QuotaLimit{Soft: cfg.Quota.Soft, Hard: cfg.Quota.Hard})
+	SetCheckDigitExempt(cfg.Validation.CheckDigitExempt...)
+	vds.Disable(cfg.Decoders.Disabled...)
+
+	if len(cfg.Keys.PlateURL) > 0 {
+		SetPlateLookup(NewHTTPPlateLookup(cfg.Keys.PlateURL, cfg.Keys.PlateToken, cfg.Keys.PlateField))
+	}
+
+	CreateContext()
+
+	if cfg.Cache.ReferenceInMemory {
+		err = LoadReferenceInMemory()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(retention) > 0 {
+		OnClose(StartRetention(retention, 24*time.Hour))
+	}
+
+	return preflight(cfg.Store.MinCoverage)
+}
+
+//ParseMode returns the validation mode for the name, an empty name is the DefaultMode.
+func ParseMode(name string) (vinmath.Mode, error) {
+	if len(name) == 0 {
+		return DefaultMode, nil
+	}
+
+	return vinmath.ParseMode(name)
+}
@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ServiceInterval is a maintenance task of a manufacturer, optionally only for a Series or engine.
+type ServiceInterval struct {
+	WMICode    string `hsk:"size(3)"`
+	SeriesSpec string //Empty when the interval applies to every Series
+	EngineCode string //Empty when the interval applies to every engine
+	Task       string `hsk:"min(2)"` //ie. Oil and filter, Spark plugs, Timing belt
+	Months     int    //0 when the task is only due by distance
+	Distance   int    //Kilometres, 0 when the task is only due by time
+}
+
+func (m ServiceInterval) Valid() (bool, error) {
+	if m.Months <= 0 && m.Distance <= 0 {
+		return false, fmt.Errorf("%s needs an interval in months or kilometres", m.Task)
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m ServiceInterval) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s %s", m.WMICode, m.SeriesSpec, m.EngineCode, m.Task))
+}
+
+//specificity is higher for intervals of an engine than of a Series, and -1 when the interval doesn't apply to the VIN.
+func (m ServiceInterval) specificity(v VIN) int {
+	if len(v.Unique) < 3 || m.WMICode != v.Unique[:3] {
+		return -1
+	}
+
+	result := 0
+
+	if len(m.SeriesSpec) > 0 {
+		if m.SeriesSpec != v.Series.Spec {
+			return -1
+		}
+
+		result++
+	}
+
+	if len(m.EngineCode) > 0 {
+		if !strings.EqualFold(m.EngineCode, v.Series.Platform.Engine.Code) {
+			return -1
+		}
+
+		result += 2
+	}
+
+	return result
+}
+
+//ImportServiceIntervals adds the intervals which aren't in the registry yet
+func ImportServiceIntervals(items []ServiceInterval, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []ServiceInterval
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.Services.FindFirst(byServiceInterval(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.Services.Save()
+
+	for _, item := range adds {
+		cset := ctx.Services.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//ServiceSchedule returns the maintenance tasks of the decoded VIN.
+//Intervals of its engine replace those of its Series, which replace those of the manufacturer.
+func ServiceSchedule(v VIN) []ServiceInterval {
+	var intervals []ServiceInterval
+	scan(ctx.Services, func(obj husk.Dataer) {
+		intervals = append(intervals, *obj.(*ServiceInterval))
+	})
+
+	return schedule(v, intervals)
+}
+
+func schedule(v VIN, intervals []ServiceInterval) []ServiceInterval {
+	tasks := make(map[string]ServiceInterval)
+	best := make(map[string]int)
+
+	for _, in := range intervals {
+		score := in.specificity(v)
+		task := strings.ToLower(in.Task)
+
+		if prev, ok := best[task]; score < 0 || (ok && prev >= score) {
+			continue
+		}
+
+		tasks[task] = in
+		best[task] = score
+	}
+
+	var result []ServiceInterval
+
+	for _, in := range tasks {
+		result = append(result, in)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Task < result[j].Task
+	})
+
+	return result
+}
+
+type serviceFilter func(obj *ServiceInterval) bool
+
+func (f serviceFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*ServiceInterval))
+}
+
+func byServiceInterval(rec ServiceInterval) serviceFilter {
+	return func(obj *ServiceInterval) bool {
+		return obj.WMICode == rec.WMICode && obj.SeriesSpec == rec.SeriesSpec && obj.EngineCode == rec.EngineCode &&
+			strings.EqualFold(obj.Task, rec.Task)
+	}
+}
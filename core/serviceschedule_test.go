@@ -0,0 +1,28 @@
+package core
+
+import "testing"
+
+func TestSchedule(t *testing.T) {
+	v := VIN{Unique: "WBA8E9G5"}
+	v.Series.Spec = "F30"
+	v.Series.Platform.Engine.Code = "B48"
+
+	intervals := []ServiceInterval{
+		{WMICode: "WBA", Task: "Oil and filter", Months: 12, Distance: 15000},
+		{WMICode: "WBA", EngineCode: "B48", Task: "Oil and filter", Months: 24, Distance: 30000},
+		{WMICode: "WBA", SeriesSpec: "F30", Task: "Oil and filter", Months: 12, Distance: 20000},
+		{WMICode: "WBA", Task: "Brake fluid", Months: 24},
+		{WMICode: "WBA", EngineCode: "N47", Task: "Timing chain", Distance: 200000},
+		{WMICode: "WDD", Task: "Spark plugs", Distance: 60000},
+	}
+
+	result := schedule(v, intervals)
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 tasks, got %+v", result)
+	}
+
+	if result[0].Task != "Brake fluid" || result[1].Distance != 30000 {
+		t.Errorf("expected the interval of the engine to replace the others, got %+v", result)
+	}
+}
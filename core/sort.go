@@ -0,0 +1,156 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//SortBy is the order in which lists of VINs are returned
+type SortBy int
+
+const (
+	SortCreated SortBy = iota
+	SortVIN
+	SortModelYear
+	SortManufacturer
+	SortUpdated
+	SortDecoded
+)
+
+var sortNames = [...]string{
+	"created",
+	"vin",
+	"year",
+	"manufacturer",
+	"updated",
+	"decoded"}
+
+func (s SortBy) String() string {
+	return sortNames[s]
+}
+
+//ParseSortBy returns the SortBy for the name, an empty name sorts by created date.
+func ParseSortBy(name string) (SortBy, error) {
+	if len(name) == 0 {
+		return SortCreated, nil
+	}
+
+	for i, v := range sortNames {
+		if strings.EqualFold(v, name) {
+			return SortBy(i), nil
+		}
+	}
+
+	return SortCreated, fmt.Errorf("unable to sort by %s", name)
+}
+
+//ParseSortOrder returns whether the order is descending, an empty order is ascending.
+func ParseSortOrder(order string) (bool, error) {
+	switch strings.ToLower(order) {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	}
+
+	return false, fmt.Errorf("unable to order %s", order)
+}
+
+//storedVIN is a VIN with the key of its record, anonymised VINs share their masked number and are told apart by the key.
+type storedVIN struct {
+	VIN
+	rec husk.Recorder
+}
+
+//sortVINS orders the VINs, ties are broken by the full VIN so the order is the same on every call.
+func sortVINS(vins []VIN, sortBy SortBy) {
+	sort.SliceStable(vins, func(i, j int) bool {
+		return compareVINS(vins[i], vins[j], sortBy) < 0
+	})
+}
+
+//sortStored orders the stored VINs, ties are broken by the full VIN and then the record key, so the order is the same on every call.
+func sortStored(vins []storedVIN, sortBy SortBy, descending bool) {
+	sort.SliceStable(vins, func(i, j int) bool {
+		c := compareVINS(vins[i].VIN, vins[j].VIN, sortBy)
+
+		if c == 0 {
+			c = strings.Compare(vins[i].rec.GetKey().String(), vins[j].rec.GetKey().String())
+		}
+
+		if descending {
+			return c > 0
+		}
+
+		return c < 0
+	})
+}
+
+//compareVINS returns -1 when a comes before b, 1 when it comes after b, and 0 when they have the same full VIN.
+func compareVINS(a, b VIN, sortBy SortBy) int {
+	switch sortBy {
+	case SortCreated:
+		if c := compareTimes(a.CreatedAt, b.CreatedAt); c != 0 {
+			return c
+		}
+	case SortUpdated:
+		if c := compareTimes(a.UpdatedAt, b.UpdatedAt); c != 0 {
+			return c
+		}
+	case SortDecoded:
+		if c := compareTimes(a.LastDecodedAt, b.LastDecodedAt); c != 0 {
+			return c
+		}
+	case SortModelYear:
+		if a.ModelYear() != b.ModelYear() {
+			if a.ModelYear() < b.ModelYear() {
+				return -1
+			}
+
+			return 1
+		}
+	case SortManufacturer:
+		if c := strings.Compare(a.WMInfo.Manufacturer, b.WMInfo.Manufacturer); c != 0 {
+			return c
+		}
+	}
+
+	return strings.Compare(a.Full, b.Full)
+}
+
+func compareTimes(a, b time.Time) int {
+	if a.Before(b) {
+		return -1
+	}
+
+	if a.After(b) {
+		return 1
+	}
+
+	return 0
+}
+
+//paged returns the items on the page, pages start at 1
+func paged(vins []VIN, page, size int) []VIN {
+	if page < 1 || size < 1 {
+		return nil
+	}
+
+	start := (page - 1) * size
+
+	if start >= len(vins) {
+		return nil
+	}
+
+	end := start + size
+
+	if end > len(vins) {
+		end = len(vins)
+	}
+
+	return vins[start:end]
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortVINS(t *testing.T) {
+	now := time.Now()
+	vins := []VIN{
+		{Full: "JN1AZ4EH7DM430130", Years: []int{2013}, WMInfo: WMInfo{Manufacturer: "Nissan"}, CreatedAt: now, UpdatedAt: now.Add(2 * time.Hour), LastDecodedAt: now.Add(time.Hour)},
+		{Full: "AAVZZZ6RZDU000001", Years: []int{2013}, WMInfo: WMInfo{Manufacturer: "Volkswagen"}, CreatedAt: now.Add(time.Hour), UpdatedAt: now, LastDecodedAt: now.Add(2 * time.Hour)},
+		{Full: "WBA8E9G50GNT00001", Years: []int{2016}, WMInfo: WMInfo{Manufacturer: "BMW"}, CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(time.Hour), LastDecodedAt: now},
+	}
+
+	expect := map[SortBy][]string{
+		SortCreated:      {"WBA8E9G50GNT00001", "JN1AZ4EH7DM430130", "AAVZZZ6RZDU000001"},
+		SortVIN:          {"AAVZZZ6RZDU000001", "JN1AZ4EH7DM430130", "WBA8E9G50GNT00001"},
+		SortModelYear:    {"AAVZZZ6RZDU000001", "JN1AZ4EH7DM430130", "WBA8E9G50GNT00001"},
+		SortManufacturer: {"WBA8E9G50GNT00001", "JN1AZ4EH7DM430130", "AAVZZZ6RZDU000001"},
+		SortUpdated:      {"AAVZZZ6RZDU000001", "WBA8E9G50GNT00001", "JN1AZ4EH7DM430130"},
+		SortDecoded:      {"WBA8E9G50GNT00001", "JN1AZ4EH7DM430130", "AAVZZZ6RZDU000001"},
+	}
+
+	for sortBy, order := range expect {
+		sorted := append([]VIN(nil), vins...)
+		sortVINS(sorted, sortBy)
+
+		if got := fullVINS(sorted); !reflect.DeepEqual(got, order) {
+			t.Errorf("%s: expected %v, got %v", sortBy, order, got)
+		}
+
+		stored := make([]storedVIN, len(vins))
+
+		for i, v := range vins {
+			stored[i] = storedVIN{VIN: v}
+		}
+
+		sortStored(stored, sortBy, true)
+		var desc []VIN
+
+		for _, v := range stored {
+			desc = append(desc, v.VIN)
+		}
+
+		reversed := []string{order[2], order[1], order[0]}
+
+		if got := fullVINS(desc); !reflect.DeepEqual(got, reversed) {
+			t.Errorf("%s descending: expected %v, got %v", sortBy, reversed, got)
+		}
+	}
+}
+
+func TestSortStored_TieOnKey(t *testing.T) {
+	masked := VIN{Full: "WBA8E9G50GN" + maskedSerial}
+	var stored []storedVIN
+
+	for i := 0; i < 3; i++ {
+		cset := ctx.VIN.Create(masked)
+
+		if cset.Error != nil {
+			t.Fatal(cset.Error)
+		}
+
+		defer ctx.VIN.Delete(cset.Record.GetKey())
+		stored = append([]storedVIN{{VIN: masked, rec: cset.Record}}, stored...)
+	}
+
+	var keys []string
+
+	for _, v := range []bool{false, true} {
+		sortStored(stored, SortVIN, v)
+
+		var order []string
+
+		for _, s := range stored {
+			order = append(order, s.rec.GetKey().String())
+		}
+
+		keys = append(keys, order...)
+	}
+
+	for i := 0; i < 3; i++ {
+		if keys[i] != keys[5-i] {
+			t.Errorf("expected the descending order to reverse the keys, got %v", keys)
+		}
+	}
+
+	if keys[0] > keys[1] || keys[1] > keys[2] {
+		t.Errorf("expected VINs with the same number to be ordered by their key, got %v", keys[:3])
+	}
+}
+
+func TestParseSortBy(t *testing.T) {
+	for name, expect := range map[string]SortBy{"": SortCreated, "VIN": SortVIN, "year": SortModelYear, "decoded": SortDecoded} {
+		sortBy, err := ParseSortBy(name)
+
+		if err != nil || sortBy != expect {
+			t.Errorf("%q: expected %s, got %s %v", name, expect, sortBy, err)
+		}
+	}
+
+	if _, err := ParseSortBy("colour"); err == nil {
+		t.Error("expected an unknown sort to fail")
+	}
+
+	if _, err := ParseSortOrder("sideways"); err == nil {
+		t.Error("expected an unknown order to fail")
+	}
+}
+
+func fullVINS(vins []VIN) []string {
+	var result []string
+
+	for _, v := range vins {
+		result = append(result, v.Full)
+	}
+
+	return result
+}
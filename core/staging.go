@@ -0,0 +1,75 @@
+package core
+
+import (
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//StagedManufacturer is a WMI found by a reference data sync, waiting for review before it is imported.
+type StagedManufacturer struct {
+	Manufacturer
+	Country  string
+	Source   string
+	StagedAt time.Time
+}
+
+func (m StagedManufacturer) Valid() (bool, error) {
+	return husk.ValidateStruct(&m)
+}
+
+//StageManufacturer adds the manufacturer to the staging table, unless its WMI is already staged.
+func StageManufacturer(m StagedManufacturer) (husk.Recorder, error) {
+	done, err := writing()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer done()
+
+	item, err := ctx.Staging.FindFirst(byStagedWMI(m.WMICode))
+
+	if err == nil {
+		return item, nil
+	}
+
+	if m.StagedAt.IsZero() {
+		m.StagedAt = time.Now()
+	}
+
+	cset := ctx.Staging.Create(m)
+
+	if cset.Error != nil {
+		return nil, cset.Error
+	}
+
+	defer ctx.Staging.Save()
+	return cset.Record, nil
+}
+
+func GetStagedManufacturer(key Key) (*StagedManufacturer, error) {
+	rec, err := ctx.Staging.FindByKey(key.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Data().(*StagedManufacturer), nil
+}
+
+func GetAllStagedManufacturers(page, size int) husk.Collection {
+	return ctx.Staging.Find(page, size, husk.Everything())
+}
+
+type stagedFilter func(obj *StagedManufacturer) bool
+
+func (f stagedFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*StagedManufacturer))
+}
+
+func byStagedWMI(wmi string) stagedFilter {
+	return func(obj *StagedManufacturer) bool {
+		return obj.WMICode == wmi
+	}
+}
@@ -0,0 +1,27 @@
+package core
+
+import "testing"
+
+func TestStageManufacturer(t *testing.T) {
+	staged := StagedManufacturer{Manufacturer: Manufacturer{WMICode: "7FC", Name: "Rivian"}, Country: "United States", Source: "vPIC"}
+	first, err := StageManufacturer(staged)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Data().(*StagedManufacturer).StagedAt.IsZero() {
+		t.Error("expected StagedAt to be set")
+	}
+
+	staged.Name = "Rivian Automotive"
+	second, err := StageManufacturer(staged)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.GetKey() != first.GetKey() || second.Data().(*StagedManufacturer).Name != "Rivian" {
+		t.Errorf("expected the WMI to be staged once, got %+v", second.Data())
+	}
+}
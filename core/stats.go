@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//Statistics are the number of stored VINs per manufacturer, model year, region and body type
+type Statistics struct {
+	Total         int
+	Manufacturers map[string]int
+	ModelYears    map[int]int
+	Regions       map[string]int
+	BodyTypes     map[string]int
+}
+
+type statCounter struct {
+	sync.RWMutex
+	data      Statistics
+	ingestion map[string]map[string]int
+	sources   map[string]bool
+	//counts made while the statistics are rebuilt, they're applied to the rebuilt counts before they're published
+	pending []statChange
+}
+
+//statChange is a count of a VIN, n is -1 when it was removed
+type statChange struct {
+	v VIN
+	n int
+}
+
+//statsMu serializes rebuilds of the statistics, so only one of them collects the pending counts
+var statsMu sync.Mutex
+
+var stats = newStatCounter()
+
+func newStatCounter() *statCounter {
+	return &statCounter{
+		data: Statistics{
+			Manufacturers: make(map[string]int),
+			ModelYears:    make(map[int]int),
+			Regions:       make(map[string]int),
+			BodyTypes:     make(map[string]int),
+		},
+		ingestion: make(map[string]map[string]int),
+		sources:   make(map[string]bool),
+	}
+}
+
+//Stats returns the counts of all stored VINs. They are kept up to date as VINs are created.
+func Stats() Statistics {
+	stats.RLock()
+	defer stats.RUnlock()
+
+	result := Statistics{
+		Total:         stats.data.Total,
+		Manufacturers: make(map[string]int),
+		ModelYears:    make(map[int]int),
+		Regions:       make(map[string]int),
+		BodyTypes:     make(map[string]int),
+	}
+
+	for k, v := range stats.data.Manufacturers {
+		result.Manufacturers[k] = v
+	}
+
+	for k, v := range stats.data.ModelYears {
+		result.ModelYears[k] = v
+	}
+
+	for k, v := range stats.data.Regions {
+		result.Regions[k] = v
+	}
+
+	for k, v := range stats.data.BodyTypes {
+		result.BodyTypes[k] = v
+	}
+
+	return result
+}
+
+func (s *statCounter) add(v VIN) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.count(v, 1)
+}
+
+//remove takes the VIN which was deleted out of the counts
+func (s *statCounter) remove(v VIN) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.count(v, -1)
+}
+
+//replace moves the counts of the stored VIN to the VIN it was updated to
+func (s *statCounter) replace(prev, v VIN) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.count(prev, -1)
+	s.count(v, 1)
+}
+
+//count adds n to the counts of the VIN, counts which reach zero are removed. The caller has to hold the lock.
+func (s *statCounter) count(v VIN, n int) {
+	if s.pending != nil {
+		s.pending = append(s.pending, statChange{v: v, n: n})
+	}
+
+	s.data.Total += n
+
+	countKey(s.data.Manufacturers, v.WMInfo.Manufacturer, n)
+	countKey(s.data.Regions, v.WMInfo.Region, n)
+	countKey(s.data.BodyTypes, v.VDSInfo.BodyStyle, n)
+	s.data.ModelYears[v.ModelYear()] += n
+
+	if s.data.ModelYears[v.ModelYear()] == 0 {
+		delete(s.data.ModelYears, v.ModelYear())
+	}
+
+	day := v.CreatedAt.UTC().Format(dayFormat)
+
+	if _, ok := s.ingestion[day]; !ok {
+		s.ingestion[day] = make(map[string]int)
+	}
+
+	s.ingestion[day][v.Source] += n
+	s.sources[v.Source] = true
+}
+
+//rebuildStats counts every stored VIN, it's only required when the tables are loaded or records were deleted.
+//VINs which are counted while the table is scanned are applied to the rebuilt counts before they replace the current ones.
+func rebuildStats() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	stats.collect()
+	counter := newStatCounter()
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		counter.add(*obj.(*VIN))
+	})
+
+	stats.publish(counter)
+}
+
+//collect keeps the counts which are made from now on, until rebuilt counts are published
+func (s *statCounter) collect() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.pending = []statChange{}
+}
+
+//publish replaces the counts with the rebuilt ones, after the pending counts were applied to them
+func (s *statCounter) publish(rebuilt *statCounter) {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, c := range s.pending {
+		rebuilt.count(c.v, c.n)
+	}
+
+	s.data = rebuilt.data
+	s.ingestion = rebuilt.ingestion
+	s.sources = rebuilt.sources
+	s.pending = nil
+}
+
+//countKey adds n to the count of the key, and removes it when it reaches zero
+func countKey(counts map[string]int, key string, n int) {
+	counts[key] += n
+
+	if counts[key] == 0 {
+		delete(counts, key)
+	}
+}
+
+const dayFormat = "2006-01-02"
+
+//IngestionDay is the number of VINs created on a day, per source
+type IngestionDay struct {
+	Date    string
+	Sources map[string]int
+}
+
+//MaxIngestionDays is the longest range of IngestionStats
+const MaxIngestionDays = 366
+
+//ErrIngestionRange is returned when the range of IngestionStats is longer than MaxIngestionDays
+var ErrIngestionRange = fmt.Errorf("ingestion statistics cover at most %d days", MaxIngestionDays)
+
+//IngestionStats returns the VINs created per source for every day between from and to, days are grouped in UTC.
+//Every known source is listed on each day, so a source which stopped sending shows up as zero.
+func IngestionStats(from, to time.Time) ([]IngestionDay, error) {
+	from = utcDay(from)
+	to = utcDay(to)
+
+	if to.Sub(from) >= MaxIngestionDays*24*time.Hour {
+		return nil, ErrIngestionRange
+	}
+
+	stats.RLock()
+	defer stats.RUnlock()
+
+	var result []IngestionDay
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		day := IngestionDay{
+			Date:    d.Format(dayFormat),
+			Sources: make(map[string]int),
+		}
+
+		for src := range stats.sources {
+			day.Sources[src] = stats.ingestion[day.Date][src]
+		}
+
+		result = append(result, day)
+	}
+
+	return result, nil
+}
+
+//utcDay returns the start of the time's day in UTC
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
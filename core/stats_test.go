@@ -0,0 +1,130 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestIngestionStats_Days(t *testing.T) {
+	from := time.Date(2020, 2, 27, 15, 0, 0, 0, time.UTC)
+	days, err := IngestionStats(from, from.AddDate(0, 0, 2))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(days) != 3 || days[0].Date != "2020-02-27" || days[2].Date != "2020-02-29" {
+		t.Errorf("expected the three days, got %+v", days)
+	}
+}
+
+func TestIngestionStats_Range(t *testing.T) {
+	from := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := IngestionStats(from, time.Now()); err != ErrIngestionRange {
+		t.Errorf("expected the range to be rejected, got %v", err)
+	}
+
+	from = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	days, err := IngestionStats(from, from.AddDate(0, 0, MaxIngestionDays-1))
+
+	if err != nil || len(days) != MaxIngestionDays {
+		t.Errorf("expected %d days, got %d %v", MaxIngestionDays, len(days), err)
+	}
+}
+
+func TestIngestionStats_Sources(t *testing.T) {
+	zone := time.FixedZone("SAST", 2*60*60)
+	vins := []VIN{
+		{Full: "JN1AZ4EH7DM430122", Source: "dealer", CreatedAt: time.Date(1999, 3, 4, 23, 30, 0, 0, zone)},
+		{Full: "JN1AZ4EH7DM430123", Source: "dealer", CreatedAt: time.Date(1999, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{Full: "JN1AZ4EH7DM430124", Source: "auction", CreatedAt: time.Date(1999, 3, 5, 1, 0, 0, 0, zone)},
+	}
+
+	for _, v := range vins {
+		stats.add(v)
+		defer stats.remove(v)
+	}
+
+	//The range ends early on the 4th, the whole day is still counted
+	days, err := IngestionStats(time.Date(1999, 3, 3, 12, 0, 0, 0, time.UTC), time.Date(1999, 3, 4, 0, 30, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(days) != 2 || days[1].Date != "1999-03-04" {
+		t.Fatalf("expected the 3rd and 4th, got %+v", days)
+	}
+
+	if days[0].Sources["dealer"] != 0 || days[1].Sources["dealer"] != 2 || days[1].Sources["auction"] != 1 {
+		t.Errorf("expected the VINs to be counted per source on their UTC day, got %+v", days)
+	}
+}
+
+func TestStats_Breakdown(t *testing.T) {
+	before := Stats()
+	v := VIN{
+		Full:    "JN1AZ4EH7DM430121",
+		Unique:  "JN1AZ4EH7DM",
+		Serial:  430121,
+		Years:   []int{1987},
+		WMInfo:  WMInfo{Manufacturer: "Stats Make", Region: "Stats Region"},
+		VDSInfo: vds.VDSInfo{BodyStyle: "Stats Body"},
+	}
+
+	rec, err := v.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := Stats()
+
+	if after.Total != before.Total+1 {
+		t.Errorf("expected total %d, got %d", before.Total+1, after.Total)
+	}
+
+	if after.Manufacturers["Stats Make"] != 1 || after.Regions["Stats Region"] != 1 || after.BodyTypes["Stats Body"] != 1 {
+		t.Errorf("expected the VIN to be counted per manufacturer, region and body, got %+v", after)
+	}
+
+	if after.ModelYears[1987] != before.ModelYears[1987]+1 {
+		t.Errorf("expected %d VINs of 1987, got %d", before.ModelYears[1987]+1, after.ModelYears[1987])
+	}
+
+	err = changeVIN(rec, func(obj *VIN) {
+		obj.WMInfo.Manufacturer = "Stats Other"
+		obj.Years = []int{1988}
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := Stats()
+
+	if _, ok := changed.Manufacturers["Stats Make"]; ok || changed.Manufacturers["Stats Other"] != 1 {
+		t.Errorf("expected the changed VIN to move to its new manufacturer, got %v", changed.Manufacturers)
+	}
+
+	if changed.ModelYears[1987] != before.ModelYears[1987] || changed.Total != after.Total {
+		t.Errorf("expected the changed VIN to move to its new year, got %v", changed.ModelYears)
+	}
+}
+
+func TestStatCounter_Publish(t *testing.T) {
+	s := newStatCounter()
+	s.add(VIN{WMInfo: WMInfo{Manufacturer: "Kept"}})
+	s.collect()
+
+	//Created while the table is scanned
+	s.add(VIN{WMInfo: WMInfo{Manufacturer: "Created"}})
+	s.publish(newStatCounter())
+
+	if s.data.Total != 1 || s.data.Manufacturers["Created"] != 1 {
+		t.Errorf("expected the VIN counted during the rebuild to be kept, got %+v", s.data)
+	}
+}
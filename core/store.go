@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Store is a source of VIN and reference records, either this deployment or another instance of it.
+//Implementations have the same semantics, see storetest.RunConformance.
+type Store interface {
+	//VINsSince returns the VINs created or changed after since
+	VINsSince(since time.Time) ([]VIN, error)
+	Regions() ([]Region, error)
+	VDSRecords() ([]VDSRecord, error)
+	//FindVIN returns the stored VIN, ErrVINNotFound when it isn't stored
+	FindVIN(fullvin string) (VIN, error)
+	//CreateVIN stores the VIN and returns it, a VIN which is already stored is returned without adding it again
+	CreateVIN(v VIN) (VIN, error)
+	//FindVINs returns a page of the VINs which match the filter, in the order they were stored. Pages start at 1.
+	FindVINs(filter func(v VIN) bool, page, size int) ([]VIN, error)
+	//FindRegion returns the region of the VIN's first character, ErrReferenceNotFound when there is none
+	FindRegion(uniquevin string) (Region, error)
+	//FindVDS returns the record of the VDS code which was active in one of the years, ErrReferenceNotFound when there is none
+	FindVDS(wmi, code string, years []int) (VDSRecord, error)
+	//FindVDSRecords returns every record of the VDS code which was active in one of the years, ErrReferenceNotFound when there is none
+	FindVDSRecords(wmi, code string, years []int) ([]VDSRecord, error)
+}
+
+var (
+	storeMu     sync.RWMutex
+	decodeStore Store = localStore{}
+)
+
+//SetStore replaces the Store decodes look the reference data up in, nil restores Local.
+//It's meant for tests, ie. to decode against a degraded store.
+func SetStore(s Store) {
+	if s == nil {
+		s = localStore{}
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+
+	decodeStore = s
+}
+
+func currentStore() Store {
+	storeMu.RLock()
+	defer storeMu.RUnlock()
+
+	return decodeStore
+}
+
+type localStore struct{}
+
+//Local returns the Store of this deployment
+func Local() Store {
+	return localStore{}
+}
+
+func (localStore) VINsSince(since time.Time) ([]VIN, error) {
+	var result []VIN
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+
+		if v.CreatedAt.After(since) || v.UpdatedAt.After(since) {
+			result = append(result, *v)
+		}
+	})
+
+	return result, nil
+}
+
+func (localStore) Regions() ([]Region, error) {
+	var result []Region
+	scan(ctx.Regions, func(obj husk.Dataer) {
+		result = append(result, *obj.(*Region))
+	})
+
+	return result, nil
+}
+
+func (localStore) VDSRecords() ([]VDSRecord, error) {
+	var result []VDSRecord
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		result = append(result, *obj.(*VDSRecord))
+	})
+
+	return result, nil
+}
+
+func (localStore) FindVIN(fullvin string) (VIN, error) {
+	rec, err := findVIN(vinmath.Canonical(fullvin))
+
+	if err != nil {
+		return VIN{}, err
+	}
+
+	return *rec.Data().(*VIN), nil
+}
+
+func (localStore) CreateVIN(v VIN) (VIN, error) {
+	_, stored, err := v.create(context.Background())
+
+	return stored, err
+}
+
+func (localStore) FindVINs(filter func(v VIN) bool, page, size int) ([]VIN, error) {
+	var all []VIN
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := *obj.(*VIN)
+
+		if filter == nil || filter(v) {
+			all = append(all, v)
+		}
+	})
+
+	return paged(all, page, size), nil
+}
+
+func (localStore) FindRegion(uniquevin string) (Region, error) {
+	if len(uniquevin) == 0 {
+		return Region{}, errors.New("vin is empty")
+	}
+
+	rec, err := ctx.Regions.FindFirst(byUniqueVIN(uniquevin))
+
+	if err != nil {
+		return Region{}, ErrReferenceNotFound
+	}
+
+	return *rec.Data().(*Region), nil
+}
+
+func (localStore) FindVDS(wmi, code string, years []int) (VDSRecord, error) {
+	rec, err := ctx.VDS.FindFirst(byVDSYears(wmi, code, years))
+
+	if err != nil {
+		return VDSRecord{}, ErrReferenceNotFound
+	}
+
+	return *rec.Data().(*VDSRecord), nil
+}
+
+func (localStore) FindVDSRecords(wmi, code string, years []int) ([]VDSRecord, error) {
+	var result []VDSRecord
+	match := byVDSYears(wmi, code, years)
+	scan(ctx.VDS, func(obj husk.Dataer) {
+		if r := obj.(*VDSRecord); match(r) {
+			result = append(result, *r)
+		}
+	})
+
+	if len(result) == 0 {
+		return nil, ErrReferenceNotFound
+	}
+
+	return result, nil
+}
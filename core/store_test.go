@@ -0,0 +1,12 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/storetest"
+)
+
+func TestLocal_Conformance(t *testing.T) {
+	storetest.RunConformance(t, core.Local)
+}
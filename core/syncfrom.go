@@ -0,0 +1,298 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//ConflictPolicy decides which record is kept when both deployments changed it
+type ConflictPolicy int
+
+const (
+	//KeepLocal never overwrites a local record, differences are reported as conflicts
+	KeepLocal ConflictPolicy = iota
+	//KeepRemote overwrites local records which differ from the remote
+	KeepRemote
+	//KeepNewest overwrites local records when the remote has a higher Version
+	KeepNewest
+)
+
+var conflictPolicies = [...]string{
+	"local",
+	"remote",
+	"newest"}
+
+func (p ConflictPolicy) String() string {
+	return conflictPolicies[p]
+}
+
+//ParseConflictPolicy returns the ConflictPolicy for the name, an empty name keeps local records.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	if len(name) == 0 {
+		return KeepLocal, nil
+	}
+
+	for i, v := range conflictPolicies {
+		if strings.EqualFold(v, name) {
+			return ConflictPolicy(i), nil
+		}
+	}
+
+	return KeepLocal, fmt.Errorf("no conflict policy %s", name)
+}
+
+//SyncOptions control how records are pulled by SyncFrom
+type SyncOptions struct {
+	Policy ConflictPolicy
+	DryRun bool //Only report the changes, nothing is written
+}
+
+//SyncReport lists the changes made by SyncFrom for every kind of record
+type SyncReport struct {
+	VINs    ImportDiff
+	Regions ImportDiff
+	VDS     ImportDiff
+}
+
+//overwrite returns true when the remote record should replace the local one.
+func (o SyncOptions) overwrite(local, remote int) bool {
+	switch o.Policy {
+	case KeepRemote:
+		return true
+	case KeepNewest:
+		return remote > local
+	}
+
+	return false
+}
+
+//SyncFrom pulls the VINs created or changed since the given time, and every changed reference record from the remote store.
+//VINs are compared by their content, reference records by their Version. When they differ the policy decides which is kept.
+func SyncFrom(remote Store, since time.Time, opts SyncOptions) (SyncReport, error) {
+	result := SyncReport{}
+	vins, err := remote.VINsSince(since)
+
+	if err != nil {
+		return result, err
+	}
+
+	regions, err := remote.Regions()
+
+	if err != nil {
+		return result, err
+	}
+
+	records, err := remote.VDSRecords()
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Regions, err = syncRegions(regions, opts)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.VDS, err = syncVDS(records, opts)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.VINs, err = syncVINs(vins, opts)
+
+	return result, err
+}
+
+func syncVINs(vins []VIN, opts SyncOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.VIN.Save()
+
+	for _, v := range vins {
+		err = syncVIN(v, opts, &diff)
+
+		if err != nil {
+			return diff, err
+		}
+	}
+
+	return diff, nil
+}
+
+//syncVIN creates or updates the VIN under its lock, VINs with the same content as the local record are skipped.
+//The caller has to hold writing.
+func syncVIN(v VIN, opts SyncOptions, diff *ImportDiff) error {
+	unlock := vinLocks.lock(v.Full)
+	defer unlock()
+
+	//The remote's UpdatedAt is kept, so the VIN isn't seen as changed here
+	v.Checksum = v.checksum()
+	rec, err := findVIN(v.id())
+
+	if err != nil {
+		diff.Adds = append(diff.Adds, v.Full)
+
+		if opts.DryRun {
+			return nil
+		}
+
+		cset := ctx.VIN.Create(v)
+
+		if cset.Error != nil {
+			return cset.Error
+		}
+
+		stats.add(v)
+		indexVIN(v, cset.Record.GetKey())
+
+		return nil
+	}
+
+	prev := rec.Data()
+	local := *prev.(*VIN)
+
+	if local.checksum() == v.Checksum {
+		return nil
+	}
+
+	if !opts.overwrite(local.Version, v.Version) {
+		diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: local version %d, remote version %d", v.Full, local.Version, v.Version))
+		return nil
+	}
+
+	diff.Updates = append(diff.Updates, v.Full)
+
+	if opts.DryRun {
+		return nil
+	}
+
+	err = rec.Set(v)
+
+	if err != nil {
+		return err
+	}
+
+	err = ctx.VIN.Update(rec)
+
+	if err != nil {
+		rec.Set(prev)
+		return err
+	}
+
+	stats.replace(local, v)
+	index.remove(local)
+	indexVIN(v, rec.GetKey())
+
+	return nil
+}
+
+func syncRegions(regions []Region, opts SyncOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer referenceChanged()
+	defer ctx.Regions.Save()
+
+	for _, r := range regions {
+		rec, err := ctx.Regions.FindFirst(byRegionName(r.Name))
+
+		if err != nil {
+			diff.Adds = append(diff.Adds, r.Name)
+
+			if opts.DryRun {
+				continue
+			}
+
+			cset := ctx.Regions.Create(r)
+
+			if cset.Error != nil {
+				return diff, cset.Error
+			}
+
+			continue
+		}
+
+		err = syncRegion(rec, r, opts, &diff)
+
+		if err != nil {
+			return diff, err
+		}
+	}
+
+	return diff, nil
+}
+
+//syncRegion replaces the local region under its lock, when the policy keeps the remote.
+//The caller has to hold writing.
+func syncRegion(rec husk.Recorder, r Region, opts SyncOptions, diff *ImportDiff) error {
+	unlock := recordLocks.lock("regions/" + KeyOf(rec.GetKey()).String())
+	defer unlock()
+
+	local := rec.Data().(*Region)
+
+	if local.Version == r.Version {
+		return nil
+	}
+
+	if !opts.overwrite(local.Version, r.Version) {
+		diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: local version %d, remote version %d", r.Name, local.Version, r.Version))
+		return nil
+	}
+
+	diff.Updates = append(diff.Updates, r.Name)
+
+	if opts.DryRun {
+		return nil
+	}
+
+	return writeRegion(rec, r)
+}
+
+//syncVDS passes the records which should be written to BulkUpsertVDS, so they're validated like an import.
+func syncVDS(records []VDSRecord, opts SyncOptions) (ImportDiff, error) {
+	var items []VDSRecord
+	var conflicts []string
+
+	for _, r := range records {
+		rec, err := ctx.VDS.FindFirst(byVDSRecord(r))
+
+		if err == nil {
+			local := rec.Data().(*VDSRecord)
+
+			if local.Version == r.Version {
+				continue
+			}
+
+			if !opts.overwrite(local.Version, r.Version) {
+				conflicts = append(conflicts, fmt.Sprintf("%s%s %s %d-%d: local version %d, remote version %d",
+					r.WMICode, r.Code, r.Market, r.StartYear, r.EndYear, local.Version, r.Version))
+				continue
+			}
+		}
+
+		//Records without a Version overwrite the stored record
+		r.Version = 0
+		items = append(items, r)
+	}
+
+	diff, err := BulkUpsertVDS(items, ImportOptions{DryRun: opts.DryRun})
+	diff.Conflicts = append(diff.Conflicts, conflicts...)
+
+	return diff, err
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+//syncStore is a remote with only VINs
+type syncStore struct {
+	localStore
+	vins []VIN
+}
+
+func (s syncStore) VINsSince(since time.Time) ([]VIN, error) {
+	return s.vins, nil
+}
+
+func (syncStore) Regions() ([]Region, error) {
+	return nil, nil
+}
+
+func (syncStore) VDSRecords() ([]VDSRecord, error) {
+	return nil, nil
+}
+
+func TestLocal_VINsSince_Changed(t *testing.T) {
+	rec, err := VIN{Full: "JN1AZ4EH7DM430111", Unique: "JN1AZ4EH7DM", Serial: 430111}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	err = SetAttributes(KeyOf(rec.GetKey()), rec.Data().(*VIN).Version, map[string]string{"stock": "B2"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vins, err := Local().VINsSince(since)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range vins {
+		if v.Full == "JN1AZ4EH7DM430111" {
+			return
+		}
+	}
+
+	t.Error("expected the changed VIN to be pulled")
+}
+
+func TestSyncFrom_Add(t *testing.T) {
+	updated := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	remote := syncStore{vins: []VIN{{Full: "JN1AZ4EH7DM430112", Unique: "JN1AZ4EH7DM", Serial: 430112, CreatedAt: updated, UpdatedAt: updated}}}
+
+	report, err := SyncFrom(remote, time.Time{}, SyncOptions{})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.VINs.Adds) != 1 {
+		t.Fatalf("expected the VIN to be added, got %+v", report.VINs)
+	}
+
+	stored, err := Local().FindVIN("JN1AZ4EH7DM430112")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !stored.UpdatedAt.Equal(updated) {
+		t.Errorf("expected the remote's UpdatedAt %v, got %v", updated, stored.UpdatedAt)
+	}
+}
+
+func TestSyncFrom_Policies(t *testing.T) {
+	_, err := VIN{Full: "JN1AZ4EH7DM430113", Unique: "JN1AZ4EH7DM", Serial: 430113}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	older := VIN{Full: "JN1AZ4EH7DM430113", Unique: "JN1AZ4EH7DM", Serial: 430113, Version: -1, Attributes: map[string]string{"stock": "old"}}
+	newer := VIN{Full: "JN1AZ4EH7DM430113", Unique: "JN1AZ4EH7DM", Serial: 430113, Version: 3, Attributes: map[string]string{"stock": "new"}}
+
+	tests := []struct {
+		name    string
+		remote  VIN
+		opts    SyncOptions
+		updated bool
+	}{
+		{"local", newer, SyncOptions{Policy: KeepLocal}, false},
+		{"newest older", older, SyncOptions{Policy: KeepNewest}, false},
+		{"dry run", newer, SyncOptions{Policy: KeepRemote, DryRun: true}, false},
+		{"newest", newer, SyncOptions{Policy: KeepNewest}, true},
+	}
+
+	for _, tc := range tests {
+		report, err := SyncFrom(syncStore{vins: []VIN{tc.remote}}, time.Time{}, tc.opts)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stored, err := Local().FindVIN("JN1AZ4EH7DM430113")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if written := stored.Attributes["stock"] == "new"; written != tc.updated {
+			t.Errorf("%s: expected written to be %v, got %+v", tc.name, tc.updated, stored.Attributes)
+		}
+
+		if !tc.opts.DryRun && !tc.updated && len(report.VINs.Conflicts) != 1 {
+			t.Errorf("%s: expected a conflict, got %+v", tc.name, report.VINs)
+		}
+
+		if tc.opts.DryRun && len(report.VINs.Updates) != 1 {
+			t.Errorf("%s: expected the update to be reported, got %+v", tc.name, report.VINs)
+		}
+	}
+}
+
+func TestSyncFrom_Update(t *testing.T) {
+	_, err := VIN{Full: "JN1AZ4EH7DM430114", Unique: "JN1AZ4EH7DM", Serial: 430114, WMInfo: WMInfo{Manufacturer: "Sync Before"}}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := syncStore{vins: []VIN{{Full: "JN1AZ4EH7DM430114", Unique: "JN1AZ4EH7DM", Serial: 430114, Version: 5, WMInfo: WMInfo{Manufacturer: "Sync After"}}}}
+	report, err := SyncFrom(remote, time.Time{}, SyncOptions{Policy: KeepNewest})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.VINs.Updates) != 1 {
+		t.Fatalf("expected the VIN to be updated, got %+v", report.VINs)
+	}
+
+	counts := Stats().Manufacturers
+
+	if counts["Sync Before"] != 0 || counts["Sync After"] != 1 {
+		t.Errorf("expected the VIN to be counted for its new manufacturer, got %v", counts)
+	}
+
+	report, err = SyncFrom(remote, time.Time{}, SyncOptions{Policy: KeepLocal})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.VINs.Updates) != 0 || len(report.VINs.Conflicts) != 0 {
+		t.Errorf("expected the VIN with the same content to be skipped, got %+v", report.VINs)
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	p, err := ParseConflictPolicy("Newest")
+
+	if err != nil || p != KeepNewest {
+		t.Errorf("expected newest, got %v %v", p, err)
+	}
+
+	_, err = ParseConflictPolicy("oldest")
+
+	if err == nil {
+		t.Error("expected an unknown policy to be refused")
+	}
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+//Span is a timed operation of a decode, it's ended once with the error of the operation
+type Span interface {
+	SetAttribute(key, value string)
+	End(err error)
+}
+
+//Tracer starts a span as a child of the span in the context, and returns the context with the new span.
+//Start has the same shape as OpenTelemetry's trace.Tracer, so the tracer of a service which embeds core is adapted with a few lines.
+type Tracer interface {
+	Start(parent context.Context, name string) (context.Context, Span)
+}
+
+//Span names, steps of the decode are traced as SpanStep followed by the name of the step, ie. vin.step.wmi
+const (
+	SpanDecode = "vin.decode"
+	SpanParse  = "vin.parse"
+	SpanStep   = "vin.step."
+	SpanCreate = "vin.store.create"
+	SpanPlate  = "vin.plate"
+)
+
+var (
+	tracer     Tracer = noopTracer{}
+	tracerLock sync.RWMutex
+)
+
+//SetTracer traces decodes and stores with the tracer, nil turns tracing off.
+func SetTracer(t Tracer) {
+	if t == nil {
+		t = noopTracer{}
+	}
+
+	tracerLock.Lock()
+	defer tracerLock.Unlock()
+
+	tracer = t
+}
+
+//startSpan starts the span with the current tracer
+func startSpan(parent context.Context, name string) (context.Context, Span) {
+	tracerLock.RLock()
+	t := tracer
+	tracerLock.RUnlock()
+
+	return t.Start(parent, name)
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(parent context.Context, name string) (context.Context, Span) {
+	return parent, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key, value string) {}
+
+func (noopSpan) End(err error) {}
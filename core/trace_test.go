@@ -0,0 +1,63 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type recordedSpan struct {
+	name string
+	err  error
+}
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	ended []recordedSpan
+}
+
+func (r *recordingTracer) Start(parent context.Context, name string) (context.Context, Span) {
+	return parent, &recordingSpan{tracer: r, name: name}
+}
+
+type recordingSpan struct {
+	tracer *recordingTracer
+	name   string
+}
+
+func (s *recordingSpan) SetAttribute(key, value string) {}
+
+func (s *recordingSpan) End(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+
+	s.tracer.ended = append(s.tracer.ended, recordedSpan{name: s.name, err: err})
+}
+
+func TestRunSteps_Traced(t *testing.T) {
+	rec := &recordingTracer{}
+	SetTracer(rec)
+	defer SetTracer(nil)
+
+	failure := errors.New("lookup failed")
+	steps := []decodeStep{
+		{name: "wmi", run: func(m *VIN, warn func(Warning)) error { return nil }},
+		{name: "vds", after: []string{"wmi"}, run: func(m *VIN, warn func(Warning)) error { return failure }},
+		{name: "series", after: []string{"vds"}, run: func(m *VIN, warn func(Warning)) error { return nil }},
+	}
+
+	runSteps(context.Background(), &VIN{}, steps)
+
+	if len(rec.ended) != 2 {
+		t.Fatalf("expected the skipped step to have no span, got %v", rec.ended)
+	}
+
+	if rec.ended[0].name != SpanStep+"wmi" || rec.ended[0].err != nil {
+		t.Errorf("unexpected %v", rec.ended[0])
+	}
+
+	if rec.ended[1].name != SpanStep+"vds" || rec.ended[1].err != failure {
+		t.Errorf("unexpected %v", rec.ended[1])
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//approvalNumber matches EU whole vehicle type-approval numbers, ie. e1*2007/46*0333*07
+var approvalNumber = regexp.MustCompile(`^e\d{1,2}\*\d{2,4}/\d{2,4}(\*\d{2,4}/\d{2,4})?\*\d{4}\*\d{2}$`)
+
+//TypeApproval is an EU type-approval of a Series, referenced on the Certificate of Conformity (CoC).
+type TypeApproval struct {
+	WMICode    string `hsk:"size(3)"`
+	SeriesSpec string //Spec of the approved Series
+	VDSCode    string //Narrows the approval to a VDS, empty when it applies to the whole Series
+	Number     string //The type-approval number, ie. e1*2007/46*0333*07
+	Variant    string
+	Version    string
+	StartYear  int
+	EndYear    int
+}
+
+func (m TypeApproval) Valid() (bool, error) {
+	if !approvalNumber.MatchString(m.Number) {
+		return false, fmt.Errorf("%s is not a type-approval number", m.Number)
+	}
+
+	if m.EndYear > 0 && m.StartYear > m.EndYear {
+		return false, fmt.Errorf("approval starts in %d after it ends in %d", m.StartYear, m.EndYear)
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m TypeApproval) String() string {
+	return fmt.Sprintf("%s %s %s/%s", m.WMICode, m.Number, m.Variant, m.Version)
+}
+
+//covers returns true when the approval applies to the decoded VIN
+func (m TypeApproval) covers(v VIN) bool {
+	if len(v.Unique) < 3 || m.WMICode != v.Unique[:3] || m.SeriesSpec != v.Series.Spec {
+		return false
+	}
+
+	if len(m.VDSCode) > 0 && m.VDSCode != v.VDSInfo.Code {
+		return false
+	}
+
+	year := v.ModelYear()
+
+	return year >= m.StartYear && (m.EndYear == 0 || year <= m.EndYear)
+}
+
+//ImportTypeApprovals adds the type-approvals which aren't in the registry yet
+func ImportTypeApprovals(items []TypeApproval, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []TypeApproval
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+		item.VDSCode = strings.ToUpper(item.VDSCode)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.TypeApprovals.FindFirst(byTypeApproval(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.TypeApprovals.Save()
+
+	for _, item := range adds {
+		cset := ctx.TypeApprovals.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//FindTypeApprovals decodes the VIN and returns the type-approvals of its Series.
+func FindTypeApprovals(fullvin string) (*VIN, []TypeApproval, error) {
+	vin, err := GetByFullVIN(fullvin)
+
+	if err != nil {
+		vin, err = BuildInfo(fullvin)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(vin.Series.Spec) == 0 {
+		return vin, nil, errors.New("series is unknown, type-approvals can't be matched")
+	}
+
+	var result []TypeApproval
+	scan(ctx.TypeApprovals, func(obj husk.Dataer) {
+		a := obj.(*TypeApproval)
+
+		if a.covers(*vin) {
+			result = append(result, *a)
+		}
+	})
+
+	return vin, result, nil
+}
+
+type approvalFilter func(obj *TypeApproval) bool
+
+func (f approvalFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*TypeApproval))
+}
+
+func byTypeApproval(rec TypeApproval) approvalFilter {
+	return func(obj *TypeApproval) bool {
+		return obj.WMICode == rec.WMICode && obj.Number == rec.Number && obj.Variant == rec.Variant &&
+			obj.Version == rec.Version && obj.VDSCode == rec.VDSCode
+	}
+}
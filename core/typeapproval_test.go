@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestTypeApproval_Valid(t *testing.T) {
+	numbers := map[string]bool{
+		"e1*2007/46*0333*07":         true,
+		"e11*2001/116*0305*12":       true,
+		"e4*2007/46*2012/19*0002*03": true,
+		"E1*2007/46*0333*07":         false,
+		"e1-2007/46-0333-07":         false,
+		"":                           false,
+	}
+
+	for number, expect := range numbers {
+		if approvalNumber.MatchString(number) != expect {
+			t.Errorf("%q expected valid %v", number, expect)
+		}
+	}
+
+	_, err := TypeApproval{WMICode: "WBA", Number: "e1*2007/46*0333*07", StartYear: 2015, EndYear: 2012}.Valid()
+
+	if err == nil {
+		t.Error("expected an error when the approval ends before it starts")
+	}
+}
+
+func TestTypeApproval_Covers(t *testing.T) {
+	vin := VIN{
+		Unique:  "WBA8E9G5",
+		Years:   []int{2016},
+		VDSInfo: vds.VDSInfo{Code: "8E9G5"},
+		Series:  Series{Spec: "F30"},
+	}
+
+	approval := TypeApproval{WMICode: "WBA", SeriesSpec: "F30", Number: "e1*2001/116*0396*29", StartYear: 2012, EndYear: 2019}
+
+	if !approval.covers(vin) {
+		t.Error("expected the series approval to cover the VIN")
+	}
+
+	approval.VDSCode = "8E9G1"
+
+	if approval.covers(vin) {
+		t.Error("expected an approval of another VDS not to cover the VIN")
+	}
+
+	approval.VDSCode = ""
+	approval.EndYear = 2015
+
+	if approval.covers(vin) {
+		t.Error("expected an approval which ended to not cover the VIN")
+	}
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//upsertLock serializes upserts, so the same delivery can't create a VIN twice
+var upsertLock sync.Mutex
+
+//UpsertByExternalID stores the VIN under the partner's own identifier, repeated deliveries update the record instead of duplicating it.
+//When the identifier was delivered with another VIN before, it's moved to the new VIN. created is true when the VIN wasn't stored yet.
+func UpsertByExternalID(source, externalID, fullvin string) (rec husk.Recorder, created bool, err error) {
+	if len(source) == 0 || len(externalID) == 0 {
+		return nil, false, errors.New("upsert needs a source and external ID")
+	}
+
+	err = ValidateVIN(fullvin)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	vin, err := BuildInfo(fullvin)
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	upsertLock.Lock()
+	defer upsertLock.Unlock()
+
+	prev, err := ctx.VIN.FindFirst(byExternalID(source, externalID))
+
+	if err == nil && prev.Data().(*VIN).Full != vin.Full {
+		err = changeVIN(prev, func(obj *VIN) {
+			delete(obj.ExternalIDs, source)
+		})
+
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	rec, err = findVIN(vin.Full)
+
+	if err != nil {
+		vin.Source = source
+		vin.ExternalIDs = map[string]string{source: externalID}
+		rec, err = vin.Create()
+
+		return rec, err == nil, err
+	}
+
+	err = changeVIN(rec, func(obj *VIN) {
+		obj.refresh(*vin)
+		obj.ExternalIDs[source] = externalID
+	})
+
+	return rec, false, err
+}
+
+//refresh replaces the decoded fields with those of a newer decode, what was stored about the VIN is kept
+func (m *VIN) refresh(decoded VIN) {
+	m.Years = decoded.Years
+	m.WMInfo = decoded.WMInfo
+	m.VDSInfo = decoded.VDSInfo
+	m.Series = decoded.Series
+	m.VDSAlternatives = decoded.VDSAlternatives
+	m.PlantCountry = decoded.PlantCountry
+	m.Warnings = decoded.Warnings
+	m.Provenance = decoded.Provenance
+	m.LastDecodedAt = time.Now()
+}
+
+//changeVIN updates the stored VIN with the change, the change gets its own copy of the ExternalIDs
+func changeVIN(rec husk.Recorder, change func(obj *VIN)) error {
+	done, err := writing()
+
+	if err != nil {
+		return err
+	}
+
+	defer done()
+
+	unlock := vinLocks.lock(rec.Data().(*VIN).Full)
+	defer unlock()
+
+	prev := *rec.Data().(*VIN)
+	obj := prev
+	ids := make(map[string]string, len(obj.ExternalIDs))
+
+	for k, v := range obj.ExternalIDs {
+		ids[k] = v
+	}
+
+	obj.ExternalIDs = ids
+	change(&obj)
+	obj.Version++
+	obj.seal()
+
+	err = rec.Set(obj)
+
+	if err != nil {
+		return err
+	}
+
+	defer ctx.VIN.Save()
+	err = ctx.VIN.Update(rec)
+
+	if err != nil {
+		return err
+	}
+
+	//The refresh of a decode can move the VIN to another manufacturer, year or body type
+	stats.replace(prev, obj)
+
+	return nil
+}
+
+type externalIDFilter func(obj *VIN) bool
+
+func (f externalIDFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*VIN))
+}
+
+func byExternalID(source, externalID string) externalIDFilter {
+	return func(obj *VIN) bool {
+		return obj.ExternalIDs[source] == externalID
+	}
+}
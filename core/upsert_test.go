@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVIN_Refresh(t *testing.T) {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	stored := VIN{Full: "5NPEU46F77H259112", Source: "feed", Attributes: map[string]string{"stock": "A1"}, CreatedAt: created, Years: []int{1987}}
+	decoded := VIN{Full: "5NPEU46F77H259112", Years: []int{2007}, WMInfo: WMInfo{Manufacturer: "Hyundai"}}
+
+	stored.refresh(decoded)
+
+	if stored.ModelYear() != 2007 || stored.WMInfo.Manufacturer != "Hyundai" {
+		t.Errorf("expected the decoded fields to be replaced, got %+v", stored)
+	}
+
+	if stored.Source != "feed" || stored.Attributes["stock"] != "A1" || !stored.CreatedAt.Equal(created) {
+		t.Errorf("expected the stored fields to be kept, got %+v", stored)
+	}
+}
+
+func TestByExternalID(t *testing.T) {
+	v := &VIN{ExternalIDs: map[string]string{"dealer": "42"}}
+
+	if !byExternalID("dealer", "42")(v) {
+		t.Error("expected the dealer's ID to match")
+	}
+
+	if byExternalID("auction", "42")(v) || byExternalID("dealer", "43")(v) {
+		t.Error("expected IDs of other sources to not match")
+	}
+}
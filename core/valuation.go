@@ -0,0 +1,55 @@
+package core
+
+//ValueBand is the estimated value for a vehicle in a condition
+type ValueBand struct {
+	Condition string //ie. trade-in, private, retail
+	Low       int
+	High      int
+}
+
+//ValueEstimate is the result of a Valuation
+type ValueEstimate struct {
+	Provider string
+	Currency string
+	Bands    []ValueBand
+}
+
+//Valuation is implemented by pricing engines, they receive the decoded VIN so the make, model and year don't need to be derived again.
+type Valuation interface {
+	Estimate(v VIN) (ValueEstimate, error)
+}
+
+type noValuation struct{}
+
+//Estimate returns no bands
+func (noValuation) Estimate(v VIN) (ValueEstimate, error) {
+	return ValueEstimate{}, nil
+}
+
+var valuation Valuation = noValuation{}
+
+//SetValuation sets the pricing engine used by EstimateValue, nil restores the default which returns no bands.
+func SetValuation(v Valuation) {
+	if v == nil {
+		v = noValuation{}
+	}
+
+	valuation = v
+}
+
+//EstimateValue decodes the VIN and asks the pricing engine for its value
+func EstimateValue(fullvin string) (*VIN, ValueEstimate, error) {
+	vin, err := BuildInfo(fullvin)
+
+	if err != nil {
+		return nil, ValueEstimate{}, err
+	}
+
+	est, err := valuation.Estimate(*vin)
+
+	if err != nil {
+		return vin, ValueEstimate{}, err
+	}
+
+	return vin, est, nil
+}
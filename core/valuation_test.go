@@ -0,0 +1,46 @@
+package core
+
+import "testing"
+
+//fixedValuation values every VIN the same, and records the VIN it was asked about
+type fixedValuation struct {
+	asked *VIN
+}
+
+func (f fixedValuation) Estimate(v VIN) (ValueEstimate, error) {
+	*f.asked = v
+
+	return ValueEstimate{Provider: "fixed", Currency: "ZAR", Bands: []ValueBand{{Condition: "retail", Low: 100, High: 200}}}, nil
+}
+
+func TestEstimateValue_Default(t *testing.T) {
+	vin, est, err := EstimateValue(expectations.Full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if vin.Full != expectations.Full || len(est.Bands) != 0 {
+		t.Errorf("expected the decoded VIN without bands, got %s %+v", vin.Full, est)
+	}
+}
+
+func TestEstimateValue_Provider(t *testing.T) {
+	asked := &VIN{}
+	SetValuation(fixedValuation{asked: asked})
+	defer SetValuation(nil)
+
+	_, est, err := EstimateValue(expectations.Full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if est.Provider != "fixed" || len(est.Bands) != 1 {
+		t.Errorf("expected the provider's estimate, got %+v", est)
+	}
+
+	if asked.WMInfo.Manufacturer != expectations.WMInfo.Manufacturer {
+		t.Errorf("expected the provider to get the decoded VIN, got %+v", asked.WMInfo)
+	}
+}
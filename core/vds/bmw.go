@@ -1,11 +1,150 @@
 package vds
 
 type BMWVDS struct {
-	VDSInfo
+	Chassis string
 }
 
-func AnalyseBMW(vin string, obj *VDSInfo) (interface{}, error) {
-	return nil, nil
+func AnalyseBMW(vds string, obj *VDSInfo) (interface{}, error) {
+	tmp := new(BMWVDS)
+
+	//Older VINs use all 4 characters, from the table below
+	if chassis, ok := bmwModelCodes[vds[:4]]; ok {
+		tmp.Chassis = chassis
+	} else {
+		tmp.Chassis = bmwSeriesCodes[vds[:2]]
+	}
+
+	obj.Chassis = tmp.Chassis
+
+	return tmp, nil
+}
+
+//bmwSeriesCodes are the first two characters of the model code, used since the E90.
+var bmwSeriesCodes = map[string]string{
+	"PH": "E90",
+	"VA": "E90",
+	"VB": "E90",
+	"VS": "E90",
+	"WB": "E92",
+	"KG": "E93",
+	"3A": "F30",
+	"3B": "F30",
+	"3C": "F30",
+	"8A": "F30",
+	"8E": "F30",
+	"8B": "F31",
+	"3R": "F32",
+	"4P": "F33",
+	"5R": "G20",
+	"5U": "G20",
+	"5V": "G21",
+	"FR": "F10",
+	"FW": "F10",
+	"XG": "F10",
+	"JA": "G30",
+	"JE": "G30",
+	"JS": "G30",
+	"YA": "F01",
+	"YE": "F01",
+	"7E": "G11",
+	"7F": "G12",
+	"TX": "F25",
+	"TR": "G01",
+	"KR": "F15",
+	"CR": "G05",
+	"8M": "F80",
+	"3M": "F82",
+}
+
+//bmwModelCodes are the model codes of the VDS table below, and the chassis they belong to
+var bmwModelCodes = map[string]string{
+	"AA13": "E30",
+	"AA23": "E30",
+	"AB03": "E30",
+	"AB54": "E30",
+	"AB64": "E30",
+	"AB93": "E30",
+	"AC74": "E30",
+	"AC84": "E30",
+	"AD13": "E30",
+	"AD23": "E30",
+	"AE03": "E30",
+	"AE54": "E30",
+	"AE64": "E30",
+	"AE93": "E30",
+	"AF93": "E30",
+	"AG33": "E21",
+	"AG43": "E21",
+	"AH31": "E21",
+	"AH41": "E21",
+	"AJ93": "E30",
+	"AK03": "E30",
+	"AK74": "E30",
+	"AK84": "E30",
+	"BA73": "E30",
+	"BB13": "E30",
+	"BB23": "E30",
+	"BE53": "E36",
+	"BE63": "E36",
+	"BF33": "E36",
+	"BF43": "E36",
+	"BF93": "E36",
+	"BJ53": "E36",
+	"BJ63": "E36",
+	"CA53": "E36",
+	"CA63": "E36",
+	"CB33": "E36",
+	"CB43": "E36",
+	"CJ95": "E12",
+	"CJ97": "E12",
+	"DB24": "E28",
+	"DB74": "E28",
+	"DB84": "E28",
+	"DC71": "E28",
+	"DC74": "E28",
+	"DC81": "E28",
+	"DC84": "E28",
+	"DC93": "E28",
+	"DK73": "E28",
+	"DK83": "E28",
+	"EB35": "E24",
+	"EB36": "E24",
+	"EB45": "E24",
+	"EB46": "E24",
+	"EB74": "E24",
+	"EB84": "E24",
+	"EC74": "E24",
+	"EC84": "E24",
+	"EE14": "E24",
+	"EE81": "E24",
+	"EG13": "E31",
+	"EG23": "E31",
+	"FF33": "E23",
+	"FF34": "E23",
+	"FF43": "E23",
+	"FF44": "E23",
+	"FF74": "E23",
+	"FF84": "E23",
+	"FG24": "E23",
+	"FH74": "E23",
+	"FH84": "E23",
+	"GB33": "E32",
+	"GB43": "E32",
+	"GC43": "E32",
+	"GC83": "E32",
+	"GD43": "E32",
+	"GD83": "E32",
+	"HC13": "E34",
+	"HC23": "E34",
+	"HD13": "E34",
+	"HD23": "E34",
+	"HD53": "E34",
+	"HD63": "E34",
+	"HD93": "E34",
+	"HE13": "E34",
+	"HE23": "E34",
+	"HJ63": "E34",
+	"HK23": "E34",
 }
 
 //4-7 Model
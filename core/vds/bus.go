@@ -0,0 +1,33 @@
+package vds
+
+import "fmt"
+
+const (
+	SingleDeck = "Single"
+	DoubleDeck = "Double"
+)
+
+//BusInfo is the body configuration of buses and coaches, when the manufacturer encodes it
+type BusInfo struct {
+	Seating string `json:",omitempty"` //Seating capacity class, ie. 23-45 seats
+	Deck    string `json:",omitempty"` //Single or Double
+}
+
+//ParseBusInfo returns the BusInfo of the seating capacity class and deck, either may be empty.
+func ParseBusInfo(seating, deck string) (*BusInfo, error) {
+	switch deck {
+	case "", SingleDeck, DoubleDeck:
+		return &BusInfo{Seating: seating, Deck: deck}, nil
+	}
+
+	return nil, fmt.Errorf("deck %s is not %s or %s", deck, SingleDeck, DoubleDeck)
+}
+
+//BodyStyle is the body style of the bus, ie. Double Deck Bus
+func (b BusInfo) BodyStyle() string {
+	if len(b.Deck) == 0 {
+		return "Bus"
+	}
+
+	return b.Deck + " Deck Bus"
+}
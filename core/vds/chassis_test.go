@@ -0,0 +1,57 @@
+package vds
+
+import "testing"
+
+func TestAnalyseMercedes_EuropeanModelNumber(t *testing.T) {
+	obj := &VDSInfo{}
+	_, err := AnalyseMercedes("20504", obj)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Chassis != "W205" {
+		t.Errorf("expected W205, got %s", obj.Chassis)
+	}
+}
+
+func TestAnalyseMercedes_BodyDesignation(t *testing.T) {
+	for vds, expect := range map[string]string{"20534": "C205", "20524": "S205", "23844": "A238", "20494": "204", "25396": "253"} {
+		obj := &VDSInfo{}
+		_, err := AnalyseMercedes(vds, obj)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if obj.Chassis != expect {
+			t.Errorf("%s: expected %s, got %s", vds, expect, obj.Chassis)
+		}
+	}
+}
+
+func TestAnalyseBMW_G30(t *testing.T) {
+	obj := &VDSInfo{}
+	_, err := AnalyseBMW("JA5C5", obj)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Chassis != "G30" {
+		t.Errorf("expected G30, got %s", obj.Chassis)
+	}
+}
+
+func TestAnalyseBMW_E30Table(t *testing.T) {
+	obj := &VDSInfo{}
+	_, err := AnalyseBMW("AA133", obj)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Chassis != "E30" {
+		t.Errorf("expected E30, got %s", obj.Chassis)
+	}
+}
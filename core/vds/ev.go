@@ -0,0 +1,106 @@
+package vds
+
+const (
+	BEV  = "BEV"  //Battery electric vehicle
+	PHEV = "PHEV" //Plug-in hybrid electric vehicle
+)
+
+//EVInfo describes the battery and motors of electric vehicles, when the manufacturer encodes them in the VDS
+type EVInfo struct {
+	Powertrain string
+	Platform   string
+	Battery    string
+	Motors     string
+}
+
+//Tesla encodes the battery in position 7 and the drive units in position 8
+var teslaBattery = map[string]string{
+	"E": "Electric, NMC",
+	"F": "Electric, LFP",
+	"H": "High capacity",
+	"S": "Standard capacity",
+	"V": "Ultra high capacity",
+}
+
+var teslaMotors = map[string]string{
+	"1": "Single motor",
+	"2": "Dual motor",
+	"3": "Single motor, performance",
+	"4": "Dual motor, performance",
+	"5": "Dual motor, P2",
+	"6": "Tri motor, P2",
+	"A": "Single motor",
+	"B": "Dual motor",
+	"C": "Dual motor, performance",
+	"D": "Single motor, rear",
+	"E": "Dual motor, standard",
+	"F": "Dual motor, performance",
+	"J": "Single motor, rear",
+	"K": "Dual motor",
+	"L": "Dual motor, performance",
+}
+
+//Volkswagen MEB vehicles are identified by the model code in positions 7 and 8
+var mebModels = map[string]string{
+	"E1": "ID.3",
+	"E2": "ID.4, ID.5",
+	"EB": "ID. Buzz",
+}
+
+//Hyundai E-GMP (Ioniq 5) vehicles have 'K' in position 4,
+//position 5 is the battery and position 8 the motors.
+var egmpBattery = map[string]string{
+	"M": "Standard range",
+	"N": "Long range",
+	"R": "Long range",
+}
+
+var egmpMotors = map[string]string{
+	"E": "Single motor, rear",
+	"F": "Dual motor",
+}
+
+func teslaEV(vds string) *EVInfo {
+	battery, bok := teslaBattery[vds[3:4]]
+	motors, mok := teslaMotors[vds[4:5]]
+
+	if !bok && !mok {
+		return nil
+	}
+
+	return &EVInfo{
+		Powertrain: BEV,
+		Battery:    battery,
+		Motors:     motors,
+	}
+}
+
+func mebEV(vds string) *EVInfo {
+	if _, ok := mebModels[vds[3:5]]; !ok {
+		return nil
+	}
+
+	return &EVInfo{
+		Powertrain: BEV,
+		Platform:   "MEB",
+	}
+}
+
+func egmpEV(vds string) *EVInfo {
+	if vds[0:1] != "K" {
+		return nil
+	}
+
+	motors, ok := egmpMotors[vds[4:5]]
+
+	if !ok {
+		return nil
+	}
+
+	return &EVInfo{
+		Powertrain: BEV,
+		Platform:   "E-GMP",
+		Battery:    egmpBattery[vds[1:2]],
+		Motors:     motors,
+	}
+}
@@ -0,0 +1,37 @@
+package vds
+
+import "testing"
+
+func TestTeslaEV_DualMotor(t *testing.T) {
+	//5YJ3E1EB2JF...
+	ev := teslaEV("3E1EB")
+
+	if ev == nil {
+		t.Fatal("expected EVInfo")
+	}
+
+	if ev.Battery != "Electric, NMC" || ev.Motors != "Dual motor" {
+		t.Errorf("unexpected %+v", ev)
+	}
+}
+
+func TestMebEV_ID3(t *testing.T) {
+	//WVWZZZE1ZMP...
+	ev := mebEV("ZZZE1")
+
+	if ev == nil || ev.Platform != "MEB" {
+		t.Errorf("expected MEB, got %+v", ev)
+	}
+}
+
+func TestFindVDSInfo_BrandPrefix(t *testing.T) {
+	info, err := FindVDSInfo("Tesla, Inc.", "5YJ3E1EB2JF", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.EV == nil {
+		t.Error("expected EVInfo")
+	}
+}
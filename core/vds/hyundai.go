@@ -0,0 +1,13 @@
+package vds
+
+type HyundaiVDS struct {
+	EV *EVInfo
+}
+
+func AnalyseHyundai(vds string, obj *VDSInfo) (interface{}, error) {
+	tmp := new(HyundaiVDS)
+	tmp.EV = egmpEV(vds)
+	obj.EV = tmp.EV
+
+	return tmp, nil
+}
@@ -0,0 +1,81 @@
+package vds
+
+import "strconv"
+
+type MercedesVDS struct {
+	Chassis string
+}
+
+func AnalyseMercedes(vds string, obj *VDSInfo) (interface{}, error) {
+	tmp := new(MercedesVDS)
+
+	//VINs outside of North America start the VDS with the model number, eg. 205046 is a W205 C-Class.
+	if _, err := strconv.Atoi(vds[:3]); err == nil {
+		tmp.Chassis = mercedesModel(vds)
+	} else {
+		tmp.Chassis = mercedesChassis[vds[:2]]
+	}
+
+	obj.Chassis = tmp.Chassis
+
+	return tmp, nil
+}
+
+//mercedesModel returns the chassis of the model number with its body designation, ie. 205346 is a C205 coupé.
+//Only the number is returned when the designation can't be told from the VIN.
+func mercedesModel(vds string) string {
+	if !mercedesBodySeries[vds[:3]] || len(vds) < 4 {
+		return vds[:3]
+	}
+
+	body, ok := mercedesBodies[vds[3]]
+
+	if !ok {
+		return vds[:3]
+	}
+
+	return body + vds[:3]
+}
+
+//mercedesBodies are the body designations of the first digit of the model variant
+var mercedesBodies = map[byte]string{
+	'0': "W", //Saloon
+	'2': "S", //Estate
+	'3': "C", //Coupé
+	'4': "A", //Cabriolet
+}
+
+//mercedesBodySeries are the model numbers which designate the body with the first digit of the variant,
+//others, like the V177 A-Class saloon, don't follow it and only keep the number.
+var mercedesBodySeries = map[string]bool{
+	"204": true,
+	"205": true,
+	"206": true,
+	"207": true,
+	"212": true,
+	"213": true,
+	"238": true,
+}
+
+//mercedesChassis are the first two VDS characters of North American VINs
+var mercedesChassis = map[string]string{
+	"GF": "W204",
+	"GJ": "C204",
+	"WF": "W205",
+	"WJ": "C205",
+	"WK": "A205",
+	"HF": "W212",
+	"ZF": "W213",
+	"ZJ": "C238",
+	"NG": "W221",
+	"UG": "W222",
+	"UX": "C217",
+	"SJ": "C117",
+	"TG": "X156",
+	"0G": "X253",
+	"GG": "X204",
+	"DG": "W166",
+	"YJ": "W176",
+	"3G": "W177",
+	"PK": "C257",
+}
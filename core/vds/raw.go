@@ -0,0 +1,81 @@
+package vds
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//RawVDS is the characters of positions 4 to 9 as they are, so VINs of manufacturers without an analyzer can be mapped on exported data.
+//It's exported as the character of every position, ie. {"4":"K","5":"R","6":"0","7":"C","8":"5","9":"8"}
+type RawVDS [6]byte
+
+//NewRawVDS returns the RawVDS of the characters, ie. the positions 4 to 9 of a VIN
+func NewRawVDS(chars string) RawVDS {
+	var result RawVDS
+	copy(result[:], chars)
+
+	return result
+}
+
+//Position returns the character at the position of the VIN, positions outside 4 to 9 are empty
+func (r RawVDS) Position(pos int) string {
+	if pos < 4 || pos > 9 || r[pos-4] == 0 {
+		return ""
+	}
+
+	return string(r[pos-4])
+}
+
+//Positions returns the character of every position, by the position of the VIN
+func (r RawVDS) Positions() map[int]string {
+	result := make(map[int]string)
+
+	for pos := 4; pos <= 9; pos++ {
+		if c := r.Position(pos); len(c) > 0 {
+			result[pos] = c
+		}
+	}
+
+	return result
+}
+
+func (r RawVDS) String() string {
+	result := ""
+
+	for pos := 4; pos <= 9; pos++ {
+		result += r.Position(pos)
+	}
+
+	return result
+}
+
+func (r RawVDS) MarshalJSON() ([]byte, error) {
+	if r == (RawVDS{}) {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(r.Positions())
+}
+
+func (r *RawVDS) UnmarshalJSON(data []byte) error {
+	var positions map[int]string
+	err := json.Unmarshal(data, &positions)
+
+	if err != nil {
+		return err
+	}
+
+	var result RawVDS
+
+	for pos, c := range positions {
+		if pos < 4 || pos > 9 || len(c) != 1 {
+			return fmt.Errorf("%d: %q is not a character of the VDS", pos, c)
+		}
+
+		result[pos-4] = c[0]
+	}
+
+	*r = result
+
+	return nil
+}
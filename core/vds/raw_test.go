@@ -0,0 +1,52 @@
+package vds
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFindVDSInfo_NoAnalyzer(t *testing.T) {
+	info, err := FindVDSInfo("Unknown Motors", "1ZZKR0C58F0", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Raw.String() != "KR0C58" || info.Raw.Position(4) != "K" || info.Raw.Position(9) != "8" {
+		t.Errorf("unexpected raw VDS %s", info.Raw)
+	}
+
+	if info.Raw.Position(3) != "" || info.Raw.Position(10) != "" {
+		t.Error("expected positions outside the VDS to be empty")
+	}
+}
+
+func TestRawVDS_JSON(t *testing.T) {
+	raw := NewRawVDS("KR0C58")
+	data, err := json.Marshal(raw)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `{"4":"K","5":"R","6":"0","7":"C","8":"5","9":"8"}`
+
+	if string(data) != expect {
+		t.Errorf("expected %s, got %s", expect, data)
+	}
+
+	var result RawVDS
+	err = json.Unmarshal(data, &result)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != raw {
+		t.Errorf("expected %s, got %s", raw, result)
+	}
+
+	if err := json.Unmarshal([]byte(`{"10":"A"}`), &result); err == nil {
+		t.Error("expected position 10 to fail")
+	}
+}
@@ -0,0 +1,171 @@
+package vds
+
+//TeslaVDS is decoded from the published Tesla VIN breakdowns
+type TeslaVDS struct {
+	Model     string
+	BodyStyle string
+	Steering  string
+	Restraint string
+	Plant     string
+	EV        *EVInfo
+}
+
+func AnalyseTesla(vds string, obj *VDSInfo) (interface{}, error) {
+	tmp := new(TeslaVDS)
+
+	macros := make(map[int]func(char string))
+	macros[4] = tmp.Position4
+	macros[5] = tmp.Position5
+	macros[6] = tmp.Position6
+
+	for k, v := range vds {
+		macro, ok := macros[k+4]
+
+		if ok {
+			macro(string(v))
+		}
+	}
+
+	//7 - Battery & 8 - Motor/Drive unit
+	tmp.EV = teslaEV(vds)
+	tmp.Position11(obj.PlantCode)
+
+	obj.Model = tmp.Model
+	obj.BodyStyle = tmp.BodyStyle
+	obj.Restraint = tmp.Restraint
+	obj.Plant = tmp.Plant
+	obj.PlantCountry = teslaPlantCountries[obj.PlantCode]
+	obj.SteeringPosition = tmp.Steering
+	obj.EV = tmp.EV
+
+	return tmp, nil
+}
+
+//Model
+func (v *TeslaVDS) Position4(char string) {
+	switch char {
+	case "S":
+		v.Model = "Model S"
+		break
+	case "3":
+		v.Model = "Model 3"
+		break
+	case "X":
+		v.Model = "Model X"
+		break
+	case "Y":
+		v.Model = "Model Y"
+		break
+	case "R":
+		v.Model = "Roadster"
+		break
+	case "C":
+		v.Model = "Cybertruck"
+		break
+	}
+}
+
+//Body Type & Steering
+func (v *TeslaVDS) Position5(char string) {
+	switch char {
+	case "A":
+		v.BodyStyle = "Hatchback 5 Door"
+		v.Steering = LHD
+		break
+	case "B":
+		v.BodyStyle = "Hatchback 5 Door"
+		v.Steering = RHD
+		break
+	case "C":
+		v.BodyStyle = "MPV 5 Door"
+		v.Steering = LHD
+		break
+	case "D":
+		v.BodyStyle = "MPV 5 Door"
+		v.Steering = RHD
+		break
+	case "E":
+		v.BodyStyle = "Sedan 4 Door"
+		v.Steering = LHD
+		break
+	case "F":
+		v.BodyStyle = "Sedan 4 Door"
+		v.Steering = RHD
+		break
+	case "G":
+		v.BodyStyle = "MPV 5 Door"
+		v.Steering = LHD
+		break
+	case "H":
+		v.BodyStyle = "MPV 5 Door"
+		v.Steering = RHD
+		break
+	}
+}
+
+//Restraint System
+func (v *TeslaVDS) Position6(char string) {
+	switch char {
+	case "1":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Knee Airbags"
+		break
+	case "2":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags"
+		break
+	case "3":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Knee Airbags, 3rd Row"
+		break
+	case "4":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, 3rd Row"
+		break
+	case "5":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Knee Airbags, 2nd Row Curtain"
+		break
+	case "A":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Knee Airbags, PODS"
+		break
+	case "B":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, PODS"
+		break
+	case "C":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Knee Airbags, Curtain Airbags"
+		break
+	case "D":
+		v.Restraint = "Manual Belts, Front Airbags, Side Airbags, Curtain Airbags"
+		break
+	}
+}
+
+//teslaPlantCountries are the countries of the plants in position 11
+var teslaPlantCountries = map[string]string{
+	"F": "United States",
+	"A": "United States",
+	"B": "Germany",
+	"C": "China",
+	"N": "United States",
+	"P": "United States",
+}
+
+//Manufacturing Plant
+func (v *TeslaVDS) Position11(char string) {
+	switch char {
+	case "F":
+		v.Plant = "Fremont, California"
+		break
+	case "A":
+		v.Plant = "Austin, Texas"
+		break
+	case "B":
+		v.Plant = "Berlin, Germany"
+		break
+	case "C":
+		v.Plant = "Shanghai, China"
+		break
+	case "N":
+		v.Plant = "Reno, Nevada"
+		break
+	case "P":
+		v.Plant = "Palo Alto, California"
+		break
+	}
+}
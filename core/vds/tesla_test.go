@@ -0,0 +1,62 @@
+package vds
+
+import "testing"
+
+func TestAnalyseTesla_Model3Fremont(t *testing.T) {
+	info, err := FindVDSInfo("Tesla, Inc.", "5YJ3E1EA7KF", nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Plant != "Fremont, California" {
+		t.Errorf("expected Fremont, got %s", info.Plant)
+	}
+
+	if info.BodyStyle != "Sedan 4 Door" {
+		t.Errorf("expected Sedan 4 Door, got %s", info.BodyStyle)
+	}
+
+	if info.Model != "Model 3" {
+		t.Errorf("expected Model 3, got %s", info.Model)
+	}
+
+	if info.Restraint != "Manual Belts, Front Airbags, Side Airbags, Knee Airbags" {
+		t.Errorf("expected knee airbags, got %s", info.Restraint)
+	}
+
+	if info.EV == nil || info.EV.Motors != "Single motor" {
+		t.Errorf("expected single motor, got %+v", info.EV)
+	}
+}
+
+func TestAnalyseTesla_ModelYShanghai(t *testing.T) {
+	detail, err := AnalyseTesla("YGDEE", &VDSInfo{PlantCode: "C"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tsla := detail.(*TeslaVDS)
+
+	if tsla.Model != "Model Y" || tsla.Plant != "Shanghai, China" || tsla.Steering != "LHD" {
+		t.Errorf("unexpected %+v", tsla)
+	}
+}
+
+func TestAnalyseTesla_PlantCountryAndSteering(t *testing.T) {
+	info := &VDSInfo{PlantCode: "B"}
+	_, err := AnalyseTesla("YHDEE", info)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.PlantCountry != "Germany" {
+		t.Errorf("expected Germany, got %s", info.PlantCountry)
+	}
+
+	if info.SteeringPosition != RHD {
+		t.Errorf("expected RHD, got %s", info.SteeringPosition)
+	}
+}
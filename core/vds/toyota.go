@@ -28,6 +28,8 @@ func AnalyseToyota(vds string, obj *VDSInfo) (interface{}, error) {
 		}
 	}
 
+	obj.BodyStyle = tmp.BodyStyle
+
 	return tmp, nil
 }
 
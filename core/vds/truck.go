@@ -0,0 +1,95 @@
+package vds
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	HydraulicBrakes = "Hydraulic"
+	AirBrakes       = "Air"
+	ElectricBrakes  = "Electric"
+)
+
+//TruckInfo is the gross vehicle weight rating and brake system, which FMVSS 565 requires in the VDS of trucks, MPVs and buses
+type TruckInfo struct {
+	GVWRClass string //ie. 2G or 8
+	GVWR      string //Range of the class in pounds, ie. 8,001 - 9,000 lb
+	Brakes    string `json:",omitempty"`
+}
+
+//gvwrClass is a row of 49 CFR 565.15 Table II, Max is zero for the open ended class
+type gvwrClass struct {
+	Class string
+	Min   int
+	Max   int
+}
+
+var gvwrClasses = []gvwrClass{
+	{"1A", 0, 3000},
+	{"1B", 3001, 4000},
+	{"1C", 4001, 5000},
+	{"1D", 5001, 6000},
+	{"2E", 6001, 7000},
+	{"2F", 7001, 8000},
+	{"2G", 8001, 9000},
+	{"2H", 9001, 10000},
+	{"3", 10001, 14000},
+	{"4", 14001, 16000},
+	{"5", 16001, 19500},
+	{"6", 19501, 26000},
+	{"7", 26001, 33000},
+	{"8", 33001, 0},
+}
+
+//GVWRClassOf returns the class of the gross vehicle weight rating, in pounds
+func GVWRClassOf(pounds int) string {
+	for _, c := range gvwrClasses {
+		if pounds >= c.Min && (c.Max == 0 || pounds <= c.Max) {
+			return c.Class
+		}
+	}
+
+	return ""
+}
+
+//ParseTruckInfo returns the TruckInfo of the class and brake system, the brakes may be empty.
+func ParseTruckInfo(class, brakes string) (*TruckInfo, error) {
+	class = strings.ToUpper(strings.TrimSpace(class))
+
+	for _, c := range gvwrClasses {
+		if c.Class != class {
+			continue
+		}
+
+		result := &TruckInfo{GVWRClass: c.Class, GVWR: c.String()}
+
+		switch brakes {
+		case "", HydraulicBrakes, AirBrakes, ElectricBrakes:
+			result.Brakes = brakes
+		default:
+			return nil, fmt.Errorf("brakes %s are not %s, %s or %s", brakes, HydraulicBrakes, AirBrakes, ElectricBrakes)
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("%s is not a GVWR class", class)
+}
+
+func (c gvwrClass) String() string {
+	if c.Max == 0 {
+		return fmt.Sprintf("%s lb and over", pounds(c.Min))
+	}
+
+	return fmt.Sprintf("%s - %s lb", pounds(c.Min), pounds(c.Max))
+}
+
+//pounds formats the weight with thousands separators
+func pounds(n int) string {
+	if n < 1000 {
+		return fmt.Sprint(n)
+	}
+
+	return fmt.Sprintf("%s,%03d", pounds(n/1000), n%1000)
+}
@@ -0,0 +1,45 @@
+package vds
+
+import "testing"
+
+func TestGVWRClassOf(t *testing.T) {
+	cases := map[int]string{
+		2500:  "1A",
+		6000:  "1D",
+		8500:  "2G",
+		26000: "6",
+		80000: "8",
+	}
+
+	for pounds, expect := range cases {
+		if got := GVWRClassOf(pounds); got != expect {
+			t.Errorf("%d: expected %s, got %s", pounds, expect, got)
+		}
+	}
+}
+
+func TestParseTruckInfo(t *testing.T) {
+	info, err := ParseTruckInfo("2g", HydraulicBrakes)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.GVWRClass != "2G" || info.GVWR != "8,001 - 9,000 lb" || info.Brakes != HydraulicBrakes {
+		t.Errorf("unexpected %+v", info)
+	}
+
+	info, _ = ParseTruckInfo("8", AirBrakes)
+
+	if info.GVWR != "33,001 lb and over" {
+		t.Errorf("expected class 8 to be open ended, got %s", info.GVWR)
+	}
+
+	if _, err := ParseTruckInfo("9", ""); err == nil {
+		t.Error("expected 9 to be an unknown class")
+	}
+
+	if _, err := ParseTruckInfo("3", "Drum"); err == nil {
+		t.Error("expected Drum to be an unknown brake system")
+	}
+}
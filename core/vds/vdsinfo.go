@@ -1,40 +1,135 @@
 package vds
 
 import (
-	"fmt"
-	"log"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	LHD = "LHD" //Left-hand drive
+	RHD = "RHD" //Right-hand drive
 )
 
 type VDSAnalyzer func(vds string, obj *VDSInfo) (interface{}, error)
 
 type VDSInfo struct {
-	Code string //6 Characters of the VDS
+	Code             string //6 Characters of the VDS
+	Raw              RawVDS //Characters of positions 4 to 9, set even when the manufacturer has no analyzer
+	PlantCode        string //Position 11, some manufacturers decode it with the VDS
+	BodyStyle        string
+	Chassis          string //Internal platform code, eg. W205 or G30
+	Plant            string
+	PlantCountry     string     `json:",omitempty"` //Country of the Plant, when the analyzer knows it
+	SteeringPosition string     `json:",omitempty"` //LHD or RHD, when the manufacturer encodes it
+	Model            string     `json:",omitempty"` //Model line, when the manufacturer encodes it
+	Restraint        string     `json:",omitempty"` //Belts and airbags, when the manufacturer encodes them
+	EV               *EVInfo    `json:",omitempty"`
+	Truck            *TruckInfo `json:",omitempty"` //Weight class and brakes, only for vehicle types FMVSS 565 requires them of
+	Bus              *BusInfo   `json:",omitempty"` //Seating and deck, only for buses
 }
 
-var analyzers map[string]VDSAnalyzer
+var (
+	analyzers map[string]VDSAnalyzer
+	//names are the registered manufacturers, the longest first so 'Toyota Canada' is matched before 'Toyota'
+	names        []string
+	disabled     map[string]bool
+	analyzerLock sync.RWMutex
+)
 
 func init() {
 	analyzers = make(map[string]VDSAnalyzer)
-	analyzers["BMW"] = AnalyseBMW
-	analyzers["Toyota"] = AnalyseToyota
+	register("BMW", AnalyseBMW)
+	register("Toyota", AnalyseToyota)
+	register("Tesla", AnalyseTesla)
+	register("Volkswagen", AnalyseVolkswagen)
+	register("Hyundai", AnalyseHyundai)
+	register("Mercedes", AnalyseMercedes)
+	register("Daimler", AnalyseMercedes)
+}
+
+//Register adds the analyzer for the manufacturer, it replaces the analyzer already registered for the name.
+func Register(make string, analyzer VDSAnalyzer) {
+	analyzerLock.Lock()
+	defer analyzerLock.Unlock()
+
+	register(make, analyzer)
+}
+
+//register adds the analyzer, the caller holds the analyzerLock
+func register(make string, analyzer VDSAnalyzer) {
+	if _, ok := analyzers[make]; !ok {
+		names = append(names, make)
+		sort.Slice(names, func(i, j int) bool {
+			if len(names[i]) != len(names[j]) {
+				return len(names[i]) > len(names[j])
+			}
+
+			return names[i] < names[j]
+		})
+	}
+
+	analyzers[make] = analyzer
+}
+
+//Disable turns the analyzers of the manufacturers off, their VDS isn't decoded. It replaces the makes already disabled.
+func Disable(makes ...string) {
+	result := make(map[string]bool)
+
+	for _, make := range makes {
+		result[strings.TrimSpace(make)] = true
+	}
+
+	analyzerLock.Lock()
+	defer analyzerLock.Unlock()
+
+	disabled = result
+}
+
+//analyzerFor returns the analyzer registered for the manufacturer,
+//names like 'Toyota Canada' and 'Tesla, Inc.' use the analyzer of the longest registered name they start with.
+//Disabled brands have no analyzer.
+func analyzerFor(make string) (VDSAnalyzer, bool) {
+	analyzerLock.RLock()
+	defer analyzerLock.RUnlock()
+
+	if analyzer, ok := analyzers[make]; ok {
+		return analyzer, !disabled[make]
+	}
+
+	for _, name := range names {
+		if strings.HasPrefix(make, name) {
+			return analyzers[name], !disabled[name] && !disabled[make]
+		}
+	}
+
+	return nil, false
+}
+
+//HasAnalyzer returns true when the VDS of the manufacturer can be decoded
+func HasAnalyzer(make string) bool {
+	_, ok := analyzerFor(make)
+
+	return ok
 }
 
-func FindVDSInfo(make string, unique string, years []int) (interface{}, error) {
+//FindVDSInfo decodes the VDS with the analyzer of the manufacturer.
+//Manufacturers without an analyzer only have their Code, PlantCode and Raw characters, see HasAnalyzer.
+func FindVDSInfo(make string, unique string, years []int) (*VDSInfo, error) {
 	vdsStr := unique[3:8]
 
-	result := &VDSInfo{Code: vdsStr}
-	analyzer, ok := analyzers[make]
+	result := &VDSInfo{Code: vdsStr, Raw: NewRawVDS(unique[3:9]), PlantCode: unique[10:11]}
+	analyzer, ok := analyzerFor(make)
 
 	if !ok {
-		return nil, fmt.Errorf("no analyzer found for %s", make)
+		return result, nil
 	}
 
-	tmp, err := analyzer(vdsStr, result)
+	_, err := analyzer(vdsStr, result)
 
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("%v\n", tmp)
-	return nil, nil
+	return result, nil
 }
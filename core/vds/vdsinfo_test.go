@@ -0,0 +1,45 @@
+package vds
+
+import "testing"
+
+func TestDisable(t *testing.T) {
+	defer Disable()
+
+	Disable("Toyota")
+
+	if HasAnalyzer("Toyota") || HasAnalyzer("Toyota Canada") {
+		t.Error("expected Toyota to be disabled")
+	}
+
+	if !HasAnalyzer("BMW") {
+		t.Error("expected BMW to be enabled")
+	}
+
+	Disable()
+
+	if !HasAnalyzer("Toyota Canada") {
+		t.Error("expected Toyota to be enabled again")
+	}
+}
+
+func TestAnalyzerFor_LongestPrefix(t *testing.T) {
+	Register("Tesla Semi", func(vds string, obj *VDSInfo) (interface{}, error) {
+		obj.Chassis = "Semi"
+		return nil, nil
+	})
+
+	for make, semi := range map[string]bool{"Tesla Semi Division": true, "Tesla, Inc.": false} {
+		analyzer, ok := analyzerFor(make)
+
+		if !ok {
+			t.Fatalf("expected an analyzer for %s", make)
+		}
+
+		obj := &VDSInfo{}
+		analyzer("S3E1E", obj)
+
+		if (obj.Chassis == "Semi") != semi {
+			t.Errorf("%s: expected the longest registered name to match, got chassis %q", make, obj.Chassis)
+		}
+	}
+}
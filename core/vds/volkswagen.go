@@ -0,0 +1,15 @@
+package vds
+
+type VolkswagenVDS struct {
+	Model string
+	EV    *EVInfo
+}
+
+func AnalyseVolkswagen(vds string, obj *VDSInfo) (interface{}, error) {
+	tmp := new(VolkswagenVDS)
+	tmp.Model = mebModels[vds[3:5]]
+	tmp.EV = mebEV(vds)
+	obj.EV = tmp.EV
+
+	return tmp, nil
+}
@@ -0,0 +1,87 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+//VDSCandidate is a VDS record which matches the VIN, ranked by how well its years and market fit
+type VDSCandidate struct {
+	Record VDSRecord
+	Score  int
+}
+
+const (
+	scoreVDSModelYear = 4 //The record is active in the most recent year the VIN could be from
+	scoreVDSMarket    = 2 //The record is for the country or region of the VIN
+	scoreVDSAnyMarket = 1 //The record has no market, it applies to every market
+)
+
+//RankVDS ranks the records which matched the VDS code, the best candidate is first.
+//Records active in the model year and of the VIN's market rank higher, ties go to the narrowest year range.
+//Markets are the country and region names the VIN is sold in, ie. its PlantCountry and WMI country.
+func RankVDS(records []VDSRecord, years []int, markets ...string) []VDSCandidate {
+	modelYear := 0
+
+	if len(years) > 0 {
+		modelYear = years[len(years)-1]
+	}
+
+	result := make([]VDSCandidate, len(records))
+
+	for i, r := range records {
+		c := VDSCandidate{Record: r}
+
+		if r.StartYear <= modelYear && modelYear <= r.EndYear {
+			c.Score += scoreVDSModelYear
+		}
+
+		if len(r.Market) == 0 {
+			c.Score += scoreVDSAnyMarket
+		} else if inMarket(r.Market, markets) {
+			c.Score += scoreVDSMarket
+		}
+
+		result[i] = c
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+
+		return a.Record.EndYear-a.Record.StartYear < b.Record.EndYear-b.Record.StartYear
+	})
+
+	return result
+}
+
+//findVDSCandidates returns the ranked records of the VDS code, from memory or the store when the reference data isn't loaded
+func findVDSCandidates(wmi, code string, years []int, markets ...string) []VDSCandidate {
+	var records []VDSRecord
+
+	if set := currentReference(); set != nil {
+		records = set.findVDSRecords(wmi, code, years)
+	} else {
+		records, _ = currentStore().FindVDSRecords(wmi, code, years)
+	}
+
+	return RankVDS(records, years, markets...)
+}
+
+//markets returns the names the market of the VIN can be recorded as
+func (m VIN) markets() []string {
+	return []string{m.PlantCountry, m.WMInfo.Country, m.WMInfo.Region}
+}
+
+func inMarket(market string, markets []string) bool {
+	for _, v := range markets {
+		if len(v) > 0 && strings.EqualFold(v, market) {
+			return true
+		}
+	}
+
+	return false
+}
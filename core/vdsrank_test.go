@@ -0,0 +1,27 @@
+package core
+
+import "testing"
+
+func TestRankVDS(t *testing.T) {
+	records := []VDSRecord{
+		{WMICode: "WVW", Code: "ZZZ1K", SeriesSpec: "Mk5", StartYear: 2003, EndYear: 2009},
+		{WMICode: "WVW", Code: "ZZZ1K", SeriesSpec: "Mk5 ZA", Market: "South Africa", StartYear: 2004, EndYear: 2010},
+		{WMICode: "WVW", Code: "ZZZ1K", SeriesSpec: "Mk5 US", Market: "United States", StartYear: 2005, EndYear: 2009},
+		{WMICode: "WVW", Code: "ZZZ1K", SeriesSpec: "Mk4", StartYear: 1997, EndYear: 2006},
+	}
+
+	ranked := RankVDS(records, []int{2006}, "South Africa", "Germany", "Europe")
+	expect := []string{"Mk5 ZA", "Mk5", "Mk4", "Mk5 US"}
+
+	for i, c := range ranked {
+		if c.Record.SeriesSpec != expect[i] {
+			t.Errorf("%d: expected %s, got %s with %d", i, expect[i], c.Record.SeriesSpec, c.Score)
+		}
+	}
+
+	ranked = RankVDS(records[:2], []int{1980, 2010})
+
+	if ranked[0].Record.SeriesSpec != "Mk5 ZA" {
+		t.Errorf("expected the record of the model year first, got %s", ranked[0].Record.SeriesSpec)
+	}
+}
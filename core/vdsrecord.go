@@ -0,0 +1,62 @@
+package core
+
+import "github.com/louisevanderlith/husk"
+
+//VDSRecord links the Vehicle Descriptor Section of a manufacturer to the Series it identifies.
+type VDSRecord struct {
+	WMICode    string `hsk:"size(3)"`
+	Code       string `hsk:"size(5)"`
+	SeriesSpec string
+	Market     string
+	StartYear  int
+	EndYear    int
+	Version    int
+}
+
+func (m VDSRecord) Valid() (bool, error) {
+	return husk.ValidateStruct(&m)
+}
+
+//overlaps returns true when both records are active in at least one common year
+func (m VDSRecord) overlaps(o VDSRecord) bool {
+	return m.StartYear <= o.EndYear && o.StartYear <= m.EndYear
+}
+
+func GetAllVDSRecords(page, size int) husk.Collection {
+	return ctx.VDS.Find(page, size, husk.Everything())
+}
+
+type vdsFilter func(obj *VDSRecord) bool
+
+func (f vdsFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*VDSRecord))
+}
+
+func byVDSCode(wmi, code string) vdsFilter {
+	return func(obj *VDSRecord) bool {
+		return obj.WMICode == wmi && obj.Code == code
+	}
+}
+
+func byVDSRecord(rec VDSRecord) vdsFilter {
+	return func(obj *VDSRecord) bool {
+		return obj.WMICode == rec.WMICode && obj.Code == rec.Code && obj.Market == rec.Market && obj.StartYear == rec.StartYear
+	}
+}
+
+//byVDSYears matches records for the code, which were active in one of the years
+func byVDSYears(wmi, code string, years []int) vdsFilter {
+	return func(obj *VDSRecord) bool {
+		if obj.WMICode != wmi || obj.Code != code {
+			return false
+		}
+
+		for _, y := range years {
+			if obj.StartYear <= y && y <= obj.EndYear {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,16 @@
+package core
+
+import "errors"
+
+//ErrVersionConflict is returned when a record is written with a Version other than the stored Version,
+//which means it was changed since it was read.
+var ErrVersionConflict = errors.New("record has been changed since it was read")
+
+//checkVersion returns ErrVersionConflict when the versions differ
+func checkVersion(stored, written int) error {
+	if stored != written {
+		return ErrVersionConflict
+	}
+
+	return nil
+}
@@ -1,31 +1,53 @@
 package core
 
 import (
-	"errors"
-	"fmt"
-	"strconv"
-	"strings"
+	"context"
+	"log"
 	"time"
 
 	"github.com/louisevanderlith/husk"
 	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vinmath"
 )
 
 //VIN is the key to the entire vehicle database.
 type VIN struct {
-	Full    string `hsk:"size(17)"`
-	Unique  string `hsk:"min(2)"`
-	Serial  int
-	WMInfo  WMInfo
-	VDSInfo vds.VDSInfo
+	Full            string `hsk:"size(17)"`
+	Class           string `json:",omitempty"` //ClassPIN for PINs, empty for VINs
+	Unique          string `hsk:"min(2)"`
+	Serial          int
+	Years           []int
+	WMInfo          WMInfo
+	VDSInfo         vds.VDSInfo
+	Series          Series
+	VDSAlternatives []VDSCandidate    `json:",omitempty"` //Other VDS records which matched, ranked below the one the Series was decoded from. See RankVDS
+	PlantCountry    string            `json:",omitempty"` //Where the vehicle was assembled, WMInfo.Country is where the WMI was assigned
+	Source          string            //Where the VIN was ingested from
+	ExternalIDs     map[string]string `json:",omitempty"` //Identifiers of the partners which delivered the VIN, by source. See UpsertByExternalID
+	Attributes      map[string]string
+	Flags           []string          `json:",omitempty"` //Signals that the VIN might not be genuine
+	Warnings        []Warning         `json:",omitempty"` //Caveats of the decode, see Warning
+	HashedVIN       string            `json:",omitempty"` //Set when the serial was purged by the retention policy
+	Checksum        string            //Hash of the content, see VerifyIntegrity
+	Provenance      map[string]string `json:",omitempty"` //Fields which weren't decoded from the reference data, and where they came from
+	CreatedAt       time.Time         //When the VIN first entered the dataset
+	UpdatedAt       time.Time         //When the record last changed
+	LastDecodedAt   time.Time         //When the VIN was last decoded, lookups of a stored VIN update it
+	Version         int
 }
 
+//newVIN decodes the VIN, it's the only place a VIN is deconstructed.
 func newVIN(fullvin string) (*VIN, error) {
+	return newTracedVIN(context.Background(), fullvin)
+}
+
+//newTracedVIN is newVIN, the decode is traced as children of the span in the context
+func newTracedVIN(parent context.Context, fullvin string) (*VIN, error) {
 	vin := &VIN{
-		Full: fullvin,
+		Full: vinmath.Canonical(fullvin),
 	}
 
-	err := vin.deconstruct()
+	err := vin.deconstructTraced(parent)
 
 	if err != nil {
 		return nil, err
@@ -34,8 +56,19 @@ func newVIN(fullvin string) (*VIN, error) {
 	return vin, nil
 }
 
-func GetVIN(key husk.Key) (*VIN, error) {
-	rec, err := ctx.VIN.FindByKey(key)
+func GetVIN(key Key) (*VIN, error) {
+	rec, err := ctx.VIN.FindByKey(key.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.Data().(*VIN), nil
+}
+
+//GetByFullVIN returns the stored VIN, it isn't decoded when it's not stored.
+func GetByFullVIN(fullvin string) (*VIN, error) {
+	rec, err := findVIN(vinmath.Canonical(fullvin))
 
 	if err != nil {
 		return nil, err
@@ -48,179 +81,256 @@ func GetAllVINS(page, size int) husk.Collection {
 	return ctx.VIN.Find(page, size, husk.Everything())
 }
 
-//Valid checks if the object's values meets the data requirements
-func (m VIN) Valid() (bool, error) {
-	return husk.ValidateStruct(&m)
-}
+//SortedVINS returns a page of VINs, in the given order.
+func SortedVINS(page, size int, sortBy SortBy, descending bool) []husk.Recorder {
+	var all []storedVIN
+	scan(ctx.VIN, func(obj husk.Dataer) {
+		v := obj.(*VIN)
+		rec, err := findVIN(v.id())
 
-func (m VIN) Create() (husk.Recorder, error) {
-	item, err := ctx.VIN.FindFirst(byFullVIN(m.Full))
+		if err == nil {
+			all = append(all, storedVIN{VIN: *v, rec: rec})
+		}
+	})
 
-	//If Found, just return the record
-	if err == nil {
-		return item, nil
+	sortStored(all, sortBy, descending)
+
+	var result []husk.Recorder
+	start, end := pageBounds(len(all), page, size)
+
+	for _, v := range all[start:end] {
+		result = append(result, v.rec)
 	}
 
-	cset := ctx.VIN.Create(m)
+	return result
+}
 
-	if cset.Error != nil {
-		return nil, cset.Error
+//ModelYear returns the most recent year the VIN could have been manufactured in
+func (m VIN) ModelYear() int {
+	if len(m.Years) == 0 {
+		return 0
 	}
 
-	return cset.Record, nil
+	return m.Years[len(m.Years)-1]
 }
 
-//ValidateVIN does exactly what it says. This is the first step in creating a VIN DB Entry.
-func ValidateVIN(fullvin string) error {
-	if len(fullvin) != 17 {
-		return errors.New("not correct length")
+//id identifies the record, records which had their serial purged are only unique by their HashedVIN
+func (m VIN) id() string {
+	if len(m.HashedVIN) > 0 {
+		return m.HashedVIN
 	}
 
-	if strings.ContainsAny(fullvin, "IOQ") {
-		return errors.New("found illegal characters")
+	return m.Full
+}
+
+//flag adds the flag once
+func (m *VIN) flag(name string) {
+	if m.flagged(name) {
+		return
 	}
 
-	checkDigit := fullvin[8:9]
-	score := calculateScore(fullvin)
+	m.Flags = append(m.Flags, name)
+}
 
-	if checkDigit != score {
-		return fmt.Errorf("check digit %s is invalid for %s", checkDigit, score)
+//flagged returns true when the VIN has the flag
+func (m VIN) flagged(name string) bool {
+	for _, f := range m.Flags {
+		if f == name {
+			return true
+		}
 	}
 
-	return nil
+	return false
 }
 
-//BuildInfo tries to extract information from VIN number
-func BuildInfo(fullvin string) (*VIN, error) {
-	vin, err := newVIN(fullvin)
+//Valid checks if the object's values meets the data requirements
+func (m VIN) Valid() (bool, error) {
+	err := validateAttributes(m.Attributes)
 
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	err = vin.deconstruct()
+	return husk.ValidateStruct(&m)
+}
 
-	if err != nil {
-		return nil, err
-	}
+func (m VIN) Create() (husk.Recorder, error) {
+	return m.CreateWithContext(context.Background())
+}
 
-	return vin, nil
+//CreateWithContext is Create, traced as a child of the span in the context.
+func (m VIN) CreateWithContext(parent context.Context) (husk.Recorder, error) {
+	rec, _, err := m.create(parent)
+
+	return rec, err
 }
 
-//deconstruct will attempt to populat as much detail as possible for the given VIN
-func (m *VIN) deconstruct() error {
-	m.Unique, m.Serial = getUniqueSerial(m.Full)
-	wmiInfo, err := FindWMInfo(m.Unique)
+//create is CreateWithContext, it also returns a copy of the stored VIN which was taken under the lock of the VIN.
+//The record is shared with every reader, callers which don't need it should use the copy.
+func (m VIN) create(parent context.Context) (rec husk.Recorder, stored VIN, err error) {
+	//Lowercase or padded VINs would be stored, and locked, apart from the VIN
+	m.Full = vinmath.Canonical(m.Full)
+	_, span := startSpan(parent, SpanCreate)
+	span.SetAttribute("vin", m.Full)
+	defer func() { span.End(err) }()
+
+	done, err := writing()
 
 	if err != nil {
-		return err
+		return nil, VIN{}, err
+	}
+
+	defer done()
+
+	//The same VIN can be ingested by two workers, the one which gets the lock creates it and the other finds it
+	unlock := vinLocks.lock(m.Full)
+	defer unlock()
+
+	item, err := findVIN(m.Full)
+
+	//If Found, just return the record
+	if err == nil {
+		return item, decodedAgain(item, m.Source), nil
 	}
 
-	m.WMInfo = wmiInfo
+	m.CreatedAt = time.Now()
+	m.LastDecodedAt = m.CreatedAt
+	m.seal()
+	cset := ctx.VIN.Create(m)
 
-	//Get Year
-	years, err := manufactureYear(m.Full[9:10])
+	if cset.Error != nil {
+		return nil, VIN{}, cset.Error
+	}
+
+	stats.add(m)
+	indexVIN(m, cset.Record.GetKey())
+	raise(EventCreated, m)
+
+	return cset.Record, m, nil
+}
+
+//decodedAgain writes a copy of the stored VIN with its LastDecodedAt, and returns it. The stored VIN is never changed in place,
+//other callers may be reading it. Only the clone flag changes the record, otherwise UpdatedAt is kept. See checkClone
+//The caller has to hold writing and the lock of the VIN.
+func decodedAgain(rec husk.Recorder, source string) VIN {
+	stored := *rec.Data().(*VIN)
+	alert := checkClone(&stored, source)
+	stored.LastDecodedAt = time.Now()
+	stored.Checksum = stored.checksum()
+	err := rec.Set(stored)
+
+	if err == nil {
+		err = ctx.VIN.Update(rec)
+	}
 
 	if err != nil {
-		return err
+		log.Println("last decoded", err)
 	}
 
-	//Get VDS
-	_, err = vds.FindVDSInfo(wmiInfo.Manufacturer, m.Unique, years)
+	if alert != nil {
+		raiseEvent(Event{Type: EventPossibleClone, Time: alert.Seen, VIN: stored, Clone: alert})
+	}
+
+	return stored
+}
+
+//ValidateVIN does exactly what it says. This is the first step in creating a VIN DB Entry.
+//WMIs which are exempt from the check digit only fail on the length and characters, ValidateVINWarnings returns the warning of the tolerated check digit.
+func ValidateVIN(fullvin string) error {
+	_, err := ValidateVINWarnings(fullvin)
 
 	return err
 }
 
-func getUniqueSerial(fullvin string) (string, int) {
-	serial, _ := strconv.Atoi(fullvin[11:])
-	return fullvin[:11], serial
+//ValidateVINWarnings is ValidateVIN, with a warning when a wrong check digit was tolerated.
+func ValidateVINWarnings(fullvin string) ([]Warning, error) {
+	fullvin = vinmath.Canonical(fullvin)
+
+	return tolerateCheckDigit(fullvin, vinmath.Validate(fullvin))
 }
 
-func calculateScore(fullvin string) string {
-	result := 0
+//ValidateVINMode validates the VIN with the rules of a regional profile, with a warning when a wrong check digit was tolerated.
+func ValidateVINMode(fullvin string, mode vinmath.Mode) ([]Warning, error) {
+	fullvin = vinmath.Canonical(fullvin)
 
-	digitMap := getCharacterMap()
-	weights := []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+	return tolerateCheckDigit(fullvin, vinmath.ValidateMode(fullvin, mode))
+}
 
-	for k, v := range fullvin {
-		value := 0
-		strVal := string(v)
-		value, ok := digitMap[strVal]
+//ValidateBatch diagnoses every VIN, reporting the rule and position that failed.
+func ValidateBatch(vins []string) []vinmath.ValidationResult {
+	return vinmath.ValidateBatch(vins)
+}
 
-		//If the character is not found, it's a digit.
-		if !ok {
-			val, err := strconv.Atoi(strVal)
+//Spec returns the positions, weights and legal characters of a VIN, to generate validators elsewhere.
+func Spec() vinmath.Specification {
+	return vinmath.Spec()
+}
 
-			if err != nil {
-				panic(err)
-			}
+//BuildInfo tries to extract information from VIN number
+//VINs which can't be decoded, or are decoded without a manufacturer or VDS analyzer, are quarantined when QuarantineEnabled is set.
+//Errors of the store or the context are temporary, those VINs and partial decodes aren't quarantined.
+func BuildInfo(fullvin string) (*VIN, error) {
+	return BuildInfoWithContext(context.Background(), fullvin)
+}
+
+//BuildInfoWithContext is BuildInfo, traced as a child of the span in the context.
+//The parse and every step of the decode are spans of their own, see SetTracer.
+func BuildInfoWithContext(parent context.Context, fullvin string) (result *VIN, err error) {
+	fullvin = vinmath.Canonical(fullvin)
+	traced, span := startSpan(parent, SpanDecode)
+	span.SetAttribute("vin", fullvin)
+	defer func() { span.End(err) }()
 
-			value = val
+	vin, err := newTracedVIN(traced, fullvin)
+
+	if err != nil {
+		if QuarantineEnabled && undecodable(err) {
+			_, qErr := quarantine(fullvin, err)
+
+			if qErr != nil {
+				log.Println("quarantine", qErr)
+			}
 		}
 
-		result += value * weights[k]
+		return nil, err
 	}
 
-	mod := result % 11
+	//A partial decode ran out of its budget, the next lookup may complete it
+	if reason := undecoded(*vin); reason != nil && QuarantineEnabled && !vin.Partial() {
+		_, qErr := quarantine(fullvin, reason)
 
-	if mod == 10 {
-		return "X"
+		if qErr != nil {
+			log.Println("quarantine", qErr)
+		}
 	}
 
-	return strconv.Itoa(mod)
-}
-
-func getCharacterMap() map[string]int {
-	digitMap := make(map[string]int)
-	digitMap["A"] = 1
-	digitMap["B"] = 2
-	digitMap["C"] = 3
-	digitMap["D"] = 4
-	digitMap["E"] = 5
-	digitMap["F"] = 6
-	digitMap["G"] = 7
-	digitMap["H"] = 8
-	digitMap["J"] = 1
-	digitMap["K"] = 2
-	digitMap["L"] = 3
-	digitMap["M"] = 4
-	digitMap["N"] = 5
-	digitMap["P"] = 7
-	digitMap["R"] = 9
-	digitMap["S"] = 2
-	digitMap["T"] = 3
-	digitMap["U"] = 4
-	digitMap["V"] = 5
-	digitMap["W"] = 6
-	digitMap["X"] = 7
-	digitMap["Y"] = 8
-	digitMap["Z"] = 9
+	raise(EventDecoded, *vin)
 
-	return digitMap
+	return vin, nil
 }
 
-func manufactureYear(digit string) ([]int, error) {
-	charWeight := getCharWeight(digit)
-
-	if charWeight == 0 {
-		return nil, errors.New("unable to get a weight")
-	}
-
-	var result []int
+//deconstruct will attempt to populat as much detail as possible for the given VIN
+//The lookups run concurrently, see decodeSteps. Overrides are applied last.
+func (m *VIN) deconstruct() error {
+	return m.deconstructTraced(context.Background())
+}
 
-	//VIN Characters are reset every 30years
-	years := []int{1980, 2010}
-	maxYear := time.Now().Year()
+//deconstructTraced is deconstruct, the parse and steps are traced as children of the span in the context
+func (m *VIN) deconstructTraced(parent context.Context) error {
+	_, span := startSpan(parent, SpanParse)
+	m.Unique, m.Serial = vinmath.UniqueSerial(m.Full)
+	m.VDSInfo = vds.VDSInfo{Code: vinmath.Segment(m.Full).VDS, Raw: vds.NewRawVDS(m.Full[3:9])}
+	m.Warnings = nil
+	m.Provenance = nil
+	span.End(nil)
 
-	for _, v := range years {
-		year := v + charWeight
+	err := runSteps(parent, m, decodeSteps)
 
-		if year < maxYear {
-			result = append(result, year)
-		}
+	if err != nil {
+		return err
 	}
 
-	return result, nil
+	return applyOverride(m)
 }
 
 /*
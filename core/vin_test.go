@@ -195,3 +195,119 @@ func TestDeconstruct_VDS_Toyota(t *testing.T) {
 	t.Fail()
 	//FINISH
 }
+
+func TestParseOffline_RegionCorrect(t *testing.T) {
+	obj, err := ParseOffline(expectations.Full)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.WMInfo.Region != expectations.WMInfo.Region {
+		t.Errorf("expected %v, got %v", expectations.WMInfo.Region, obj.WMInfo.Region)
+	}
+
+	if obj.Serial != expectations.Serial {
+		t.Errorf("expected %v, got %v", expectations.Serial, obj.Serial)
+	}
+}
+
+func TestBuildInfo_MixedCase(t *testing.T) {
+	obj, err := BuildInfo(" 5npEU46F77h259112 ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Full != expectations.Full || obj.Unique != expectations.Unique {
+		t.Errorf("expected %s, got %s %s", expectations.Full, obj.Full, obj.Unique)
+	}
+}
+
+func TestCreate_MixedCase(t *testing.T) {
+	created, err := VIN{Full: " 3vwfe21c04m000001 ", Unique: "3VWFE21C04M", Serial: 1}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if full := created.Data().(*VIN).Full; full != "3VWFE21C04M000001" {
+		t.Errorf("expected the canonical VIN to be stored, got %q", full)
+	}
+
+	again, err := VIN{Full: "3VWFE21C04M000001", Unique: "3VWFE21C04M", Serial: 1}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if again.GetKey() != created.GetKey() {
+		t.Error("expected the VIN to be stored once")
+	}
+
+	found, err := GetByFullVIN("3vwFE21C04M000001 ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Full != "3VWFE21C04M000001" {
+		t.Errorf("expected the stored VIN, got %q", found.Full)
+	}
+}
+
+func TestCreateBatch_MixedCase(t *testing.T) {
+	recs, err := CreateBatch([]VIN{
+		{Full: "3vwfe21c04m000002", Unique: "3VWFE21C04M", Serial: 2},
+		{Full: " 3VWFE21C04M000002", Unique: "3VWFE21C04M", Serial: 2},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if recs[0].GetKey() != recs[1].GetKey() {
+		t.Error("expected the VIN to be stored once")
+	}
+
+	if full := recs[0].Data().(*VIN).Full; full != "3VWFE21C04M000002" {
+		t.Errorf("expected the canonical VIN to be stored, got %q", full)
+	}
+}
+
+func TestGetByFullVIN(t *testing.T) {
+	_, err := VIN{Full: "YV1MS382972000002", Unique: "YV1MS382972", Serial: 2}.Create()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := GetByFullVIN(" yv1ms382972000002")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Serial != 2 {
+		t.Errorf("expected the stored VIN, got %+v", found)
+	}
+
+	_, err = GetByFullVIN("YV1MS382972000003")
+
+	if err != ErrVINNotFound {
+		t.Errorf("expected a VIN which isn't stored not to be found, got %v", err)
+	}
+}
+
+//BenchmarkBuildInfo measures the latency of a single decode, every lookup should happen once.
+func BenchmarkBuildInfo(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, err := BuildInfo(expectations.Full)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
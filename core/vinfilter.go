@@ -10,8 +10,16 @@ func (f vinFilter) Filter(obj husk.Dataer) bool {
 	return f(obj.(*VIN))
 }
 
-func byFullVIN(full string) vinFilter {
+//byID matches the full VIN, or the hashed VIN of records which had their serial purged
+func byID(id string) vinFilter {
 	return func(obj *VIN) bool {
-		return obj.Full == full
+		return obj.id() == id
+	}
+}
+
+func byAttribute(name, value string) vinFilter {
+	return func(obj *VIN) bool {
+		v, ok := obj.Attributes[name]
+		return ok && v == value
 	}
 }
@@ -0,0 +1,51 @@
+package core
+
+import "sync"
+
+//vinLocks serializes the creates of the same VIN, so two workers which ingest it concurrently get the same record.
+//Creates of different VINs don't wait on each other.
+var vinLocks = keyedLock{locks: make(map[string]*refLock)}
+
+//recordLocks serializes the writes of the same reference record, so a version is checked and written by one writer at a time.
+//VIN records are locked on vinLocks, by their Full VIN.
+var recordLocks = keyedLock{locks: make(map[string]*refLock)}
+
+type keyedLock struct {
+	mu    sync.Mutex
+	locks map[string]*refLock
+}
+
+//refLock is the lock of a key, it's removed when no caller holds or waits on it
+type refLock struct {
+	sync.Mutex
+	refs int
+}
+
+//lock blocks until the key is free, the returned func releases it
+func (k *keyedLock) lock(key string) func() {
+	k.mu.Lock()
+	l, ok := k.locks[key]
+
+	if !ok {
+		l = &refLock{}
+		k.locks[key] = l
+	}
+
+	l.refs++
+	k.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		k.mu.Lock()
+		l.refs--
+
+		if l.refs == 0 {
+			delete(k.locks, key)
+		}
+
+		k.mu.Unlock()
+	}
+}
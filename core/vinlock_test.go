@@ -0,0 +1,61 @@
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKeyedLock_Serializes(t *testing.T) {
+	locks := keyedLock{locks: make(map[string]*refLock)}
+	var active, overlaps int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock := locks.lock("5NPEU46F77H259112")
+			defer unlock()
+
+			if atomic.AddInt32(&active, 1) > 1 {
+				atomic.AddInt32(&overlaps, 1)
+			}
+
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+
+	wg.Wait()
+
+	if overlaps != 0 {
+		t.Errorf("expected the lock to be held by one goroutine at a time, overlapped %d times", overlaps)
+	}
+
+	if len(locks.locks) != 0 {
+		t.Errorf("expected the released locks to be removed, got %d", len(locks.locks))
+	}
+}
+
+func TestKeyedLock_OtherKeys(t *testing.T) {
+	locks := keyedLock{locks: make(map[string]*refLock)}
+	unlock := locks.lock("5NPEU46F77H259112")
+	defer unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		locks.lock("JHMCM56557C404453")()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected another VIN not to wait for the lock")
+	}
+}
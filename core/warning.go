@@ -0,0 +1,58 @@
+package core
+
+import "fmt"
+
+//Warning codes, a decode with warnings is still accepted
+const (
+	//WarnCheckDigit is raised when the check digit is wrong, the VIN may have been misread or the manufacturer doesn't use one
+	WarnCheckDigit = "check-digit"
+	//WarnAmbiguousYear is raised when the year code matches more than one model year
+	WarnAmbiguousYear = "ambiguous-year"
+	//WarnFuzzyWMI is raised when the manufacturer is unknown, but similar WMIs were found
+	WarnFuzzyWMI = "fuzzy-wmi"
+	//WarnPartial is raised when a step exceeded the DecodeBudget, the fields it sets are empty
+	WarnPartial = "partial"
+	//WarnSubstituted is raised when I, O or Q were replaced by 1 or 0, see vinmath.SubstituteIllegal
+	WarnSubstituted = "substituted"
+	//WarnLegacyChars is raised when a legacy identifier with I, O or Q was accepted, see vinmath.AcceptIllegal
+	WarnLegacyChars = "legacy-chars"
+	//WarnRawVDS is raised when the manufacturer has no VDS analyzer, only the raw characters of the VDS are known
+	WarnRawVDS = "raw-vds"
+)
+
+//Warning is a caveat on a decode, callers can accept the result but should surface it
+type Warning struct {
+	Code    string
+	Message string
+}
+
+//MergeWarnings adds the warnings of a decode to the warnings of its validation.
+//A decode warning is left out when the validation already raised its code, ie. a tolerated check digit.
+func MergeWarnings(validation, decode []Warning) []Warning {
+	result := append([]Warning(nil), validation...)
+
+	for _, w := range decode {
+		if !hasWarning(validation, w.Code) {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+func hasWarning(warnings []Warning, code string) bool {
+	for _, w := range warnings {
+		if w.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newWarning(code, msg string, args ...interface{}) Warning {
+	return Warning{
+		Code:    code,
+		Message: fmt.Sprintf(msg, args...),
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+//WarrantyPolicy is the factory warranty a manufacturer gives on the vehicles of a model year range.
+type WarrantyPolicy struct {
+	Manufacturer string `hsk:"min(2)"` //Name of the manufacturer, as decoded in WMInfo
+	Coverage     string `hsk:"min(2)"` //ie. basic, powertrain, corrosion, battery
+	Months       int
+	Distance     int //Kilometres, 0 when the distance is unlimited
+	StartYear    int
+	EndYear      int //0 when the policy still applies
+}
+
+func (m WarrantyPolicy) Valid() (bool, error) {
+	if m.Months <= 0 {
+		return false, fmt.Errorf("%s warranty needs a term in months", m.Coverage)
+	}
+
+	if m.EndYear > 0 && m.StartYear > m.EndYear {
+		return false, fmt.Errorf("policy starts in %d after it ends in %d", m.StartYear, m.EndYear)
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m WarrantyPolicy) String() string {
+	return fmt.Sprintf("%s %s %d", m.Manufacturer, m.Coverage, m.StartYear)
+}
+
+//covers returns true when the policy applies to the manufacturer and model year
+func (m WarrantyPolicy) covers(manufacturer string, year int) bool {
+	return strings.EqualFold(m.Manufacturer, manufacturer) && year >= m.StartYear && (m.EndYear == 0 || year <= m.EndYear)
+}
+
+//ImportWarrantyPolicies adds the policies which aren't in the registry yet
+func ImportWarrantyPolicies(items []WarrantyPolicy, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []WarrantyPolicy
+
+	for _, item := range items {
+		item.Coverage = strings.ToLower(item.Coverage)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.Warranties.FindFirst(byWarrantyPolicy(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.Warranties.Save()
+
+	for _, item := range adds {
+		cset := ctx.Warranties.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//WarrantyPolicies returns the policies of the VIN's manufacturer for its model year
+func WarrantyPolicies(v VIN) []WarrantyPolicy {
+	var result []WarrantyPolicy
+	scan(ctx.Warranties, func(obj husk.Dataer) {
+		p := obj.(*WarrantyPolicy)
+
+		if p.covers(v.WMInfo.Manufacturer, v.ModelYear()) {
+			result = append(result, *p)
+		}
+	})
+
+	return result
+}
+
+type warrantyFilter func(obj *WarrantyPolicy) bool
+
+func (f warrantyFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*WarrantyPolicy))
+}
+
+func byWarrantyPolicy(rec WarrantyPolicy) warrantyFilter {
+	return func(obj *WarrantyPolicy) bool {
+		return strings.EqualFold(obj.Manufacturer, rec.Manufacturer) && obj.Coverage == rec.Coverage && obj.StartYear == rec.StartYear
+	}
+}
@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/louisevanderlith/husk"
+)
+
+var (
+	//tireSize matches metric tire sizes, ie. 225/45R17 or 225/45 ZR17 94W
+	tireSize = regexp.MustCompile(`^\d{3}/\d{2} ?Z?R\d{2}( \d{2,3}[A-Z])?$`)
+	//boltPattern matches the number of studs and pitch circle diameter, ie. 5x120
+	boltPattern = regexp.MustCompile(`^\d{1,2}x\d{2,3}(\.\d)?$`)
+)
+
+//WheelSpec is the original equipment tire size and wheel fitment of a Series, or of a VDS (trim) in the Series.
+type WheelSpec struct {
+	WMICode     string `hsk:"size(3)"`
+	SeriesSpec  string
+	VDSCode     string //Narrows the spec to a trim, empty when it applies to the whole Series
+	FrontTire   string //ie. 225/45R17
+	RearTire    string //Empty when it's the same as the front
+	Wheel       string //ie. 7.5Jx17 ET37
+	BoltPattern string //ie. 5x120
+	CenterBore  float64
+	StartYear   int
+	EndYear     int //0 when the spec still applies
+}
+
+func (m WheelSpec) Valid() (bool, error) {
+	if !tireSize.MatchString(m.FrontTire) {
+		return false, fmt.Errorf("%s is not a tire size", m.FrontTire)
+	}
+
+	if len(m.RearTire) > 0 && !tireSize.MatchString(m.RearTire) {
+		return false, fmt.Errorf("%s is not a tire size", m.RearTire)
+	}
+
+	if !boltPattern.MatchString(m.BoltPattern) {
+		return false, fmt.Errorf("%s is not a bolt pattern", m.BoltPattern)
+	}
+
+	return husk.ValidateStruct(&m)
+}
+
+func (m WheelSpec) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s %s %s %s %d", m.WMICode, m.SeriesSpec, m.VDSCode, m.FrontTire, m.StartYear))
+}
+
+//covers returns true when the spec applies to the decoded VIN
+func (m WheelSpec) covers(v VIN) bool {
+	if len(v.Unique) < 3 || m.WMICode != v.Unique[:3] || m.SeriesSpec != v.Series.Spec {
+		return false
+	}
+
+	if len(m.VDSCode) > 0 && m.VDSCode != v.VDSInfo.Code {
+		return false
+	}
+
+	year := v.ModelYear()
+
+	return year >= m.StartYear && (m.EndYear == 0 || year <= m.EndYear)
+}
+
+//ImportWheelSpecs adds the specs which aren't in the registry yet
+func ImportWheelSpecs(items []WheelSpec, opts ImportOptions) (ImportDiff, error) {
+	diff := ImportDiff{}
+	var adds []WheelSpec
+
+	for _, item := range items {
+		item.WMICode = strings.ToUpper(item.WMICode)
+		item.VDSCode = strings.ToUpper(item.VDSCode)
+		item.FrontTire = strings.ToUpper(item.FrontTire)
+		item.RearTire = strings.ToUpper(item.RearTire)
+
+		if ok, err := item.Valid(); !ok {
+			diff.Conflicts = append(diff.Conflicts, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		_, err := ctx.Wheels.FindFirst(byWheelSpec(item))
+
+		if err == nil {
+			continue
+		}
+
+		diff.Adds = append(diff.Adds, item.String())
+		adds = append(adds, item)
+	}
+
+	if opts.DryRun || len(adds) == 0 {
+		return diff, nil
+	}
+
+	done, err := writing()
+
+	if err != nil {
+		return diff, err
+	}
+
+	defer done()
+	defer ctx.Wheels.Save()
+
+	for _, item := range adds {
+		cset := ctx.Wheels.Create(item)
+
+		if cset.Error != nil {
+			return diff, cset.Error
+		}
+	}
+
+	return diff, nil
+}
+
+//WheelSpecs returns the OE tire and wheel specs of the decoded VIN.
+//Specs of its trim are returned when there are any, otherwise those of the Series.
+func WheelSpecs(v VIN) []WheelSpec {
+	var specs []WheelSpec
+	scan(ctx.Wheels, func(obj husk.Dataer) {
+		specs = append(specs, *obj.(*WheelSpec))
+	})
+
+	return wheelsOf(v, specs)
+}
+
+func wheelsOf(v VIN, specs []WheelSpec) []WheelSpec {
+	var series, trim []WheelSpec
+
+	for _, s := range specs {
+		if !s.covers(v) {
+			continue
+		}
+
+		if len(s.VDSCode) > 0 {
+			trim = append(trim, s)
+		} else {
+			series = append(series, s)
+		}
+	}
+
+	if len(trim) > 0 {
+		return trim
+	}
+
+	return series
+}
+
+type wheelFilter func(obj *WheelSpec) bool
+
+func (f wheelFilter) Filter(obj husk.Dataer) bool {
+	return f(obj.(*WheelSpec))
+}
+
+func byWheelSpec(rec WheelSpec) wheelFilter {
+	return func(obj *WheelSpec) bool {
+		return obj.WMICode == rec.WMICode && obj.SeriesSpec == rec.SeriesSpec && obj.VDSCode == rec.VDSCode &&
+			obj.FrontTire == rec.FrontTire && obj.RearTire == rec.RearTire && obj.StartYear == rec.StartYear
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestWheelSpec_Sizes(t *testing.T) {
+	sizes := map[string]bool{
+		"225/45R17":       true,
+		"225/45 ZR17 94W": true,
+		"255/40R18":       true,
+		"P225/45R17":      false,
+		"17 inch":         false,
+	}
+
+	for size, expect := range sizes {
+		if tireSize.MatchString(size) != expect {
+			t.Errorf("%q expected valid %v", size, expect)
+		}
+	}
+
+	if !boltPattern.MatchString("5x112") || !boltPattern.MatchString("6x139.7") || boltPattern.MatchString("5-112") {
+		t.Error("unexpected bolt pattern match")
+	}
+}
+
+func TestWheelsOf(t *testing.T) {
+	v := VIN{Unique: "WBA8E9G5", Years: []int{2016}, VDSInfo: vds.VDSInfo{Code: "8E9G5"}}
+	v.Series.Spec = "F30"
+
+	specs := []WheelSpec{
+		{WMICode: "WBA", SeriesSpec: "F30", FrontTire: "205/60R16", BoltPattern: "5x120", StartYear: 2012},
+		{WMICode: "WBA", SeriesSpec: "F30", VDSCode: "8E9G5", FrontTire: "225/45R18", RearTire: "255/40R18", BoltPattern: "5x120", StartYear: 2012},
+		{WMICode: "WBA", SeriesSpec: "F30", VDSCode: "8E9G1", FrontTire: "225/50R17", BoltPattern: "5x120", StartYear: 2012},
+	}
+
+	result := wheelsOf(v, specs)
+
+	if len(result) != 1 || result[0].RearTire != "255/40R18" {
+		t.Errorf("expected the spec of the trim, got %+v", result)
+	}
+
+	v.VDSInfo.Code = "8E9G9"
+	result = wheelsOf(v, specs)
+
+	if len(result) != 1 || result[0].FrontTire != "205/60R16" {
+		t.Errorf("expected the spec of the series, got %+v", result)
+	}
+}
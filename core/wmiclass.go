@@ -0,0 +1,116 @@
+package core
+
+import (
+	"strings"
+	"sync"
+)
+
+//wmiClasses are the vehicle types the WMIs were assigned for. Manufacturers get a WMI per type of vehicle they build,
+//ie. Ford builds cars as 1FA, MPVs as 1FM and trucks as 1FT.
+var wmiClasses = map[string]VehicleType{
+	"1FA": PassengerCar,
+	"1FB": Bus,
+	"1FC": Incomplete,
+	"1FD": Incomplete,
+	"1FM": MPV,
+	"1FT": Truck,
+	"1FU": Truck,
+	"1FV": Truck,
+	"1G1": PassengerCar,
+	"1GC": Truck,
+	"1GN": MPV,
+	"1GT": Truck,
+	"1GR": Trailer,
+	"1HD": Motorcycle,
+	"1HG": PassengerCar,
+	"1JJ": Trailer,
+	"1M8": Bus,
+	"1N4": PassengerCar,
+	"1N6": Truck,
+	"1UY": Trailer,
+	"2HG": PassengerCar,
+	"2T1": PassengerCar,
+	"3AK": Truck,
+	"3FA": PassengerCar,
+	"3VW": PassengerCar,
+	"4S3": PassengerCar,
+	"4S4": MPV,
+	"4T1": PassengerCar,
+	"4T3": MPV,
+	"4UZ": Incomplete,
+	"4V4": Truck,
+	"5FN": MPV,
+	"5J6": MPV,
+	"5N1": MPV,
+	"5NP": PassengerCar,
+	"5TD": MPV,
+	"5TF": Truck,
+	"5UX": MPV,
+	"5YJ": PassengerCar,
+	"JH2": Motorcycle,
+	"JHM": PassengerCar,
+	"JKA": Motorcycle,
+	"JM1": PassengerCar,
+	"JN1": PassengerCar,
+	"JS1": Motorcycle,
+	"JT2": PassengerCar,
+	"JTD": PassengerCar,
+	"JTE": MPV,
+	"JTH": PassengerCar,
+	"JYA": Motorcycle,
+	"KL1": PassengerCar,
+	"KMH": PassengerCar,
+	"KNA": PassengerCar,
+	"SAJ": PassengerCar,
+	"SAL": MPV,
+	"SFD": Bus,
+	"VF1": PassengerCar,
+	"VF3": PassengerCar,
+	"VF7": PassengerCar,
+	"WAU": PassengerCar,
+	"WB1": Motorcycle,
+	"WBA": PassengerCar,
+	"WBS": PassengerCar,
+	"WBY": PassengerCar,
+	"WDB": PassengerCar,
+	"WDC": MPV,
+	"WDD": PassengerCar,
+	"WMA": Truck,
+	"WP0": PassengerCar,
+	"WP1": MPV,
+	"WV1": Truck,
+	"WV2": MPV,
+	"WVG": MPV,
+	"WVW": PassengerCar,
+	"YV1": PassengerCar,
+	"YV2": Truck,
+	"YV3": Bus,
+	"YV4": MPV,
+	"ZAR": PassengerCar,
+	"ZDM": Motorcycle,
+	"ZFF": PassengerCar,
+}
+
+var classMu sync.RWMutex
+
+//SetVehicleClass adds or replaces the vehicle type the WMI was assigned for
+func SetVehicleClass(wmi string, vehicleType VehicleType) {
+	classMu.Lock()
+	defer classMu.Unlock()
+
+	wmiClasses[strings.ToUpper(wmi)] = vehicleType
+}
+
+//VehicleClassOf returns the vehicle type the WMI of the VIN was assigned for, false when the WMI isn't in the table
+func VehicleClassOf(uniquevin string) (VehicleType, bool) {
+	if len(uniquevin) < 3 {
+		return PassengerCar, false
+	}
+
+	classMu.RLock()
+	defer classMu.RUnlock()
+
+	result, ok := wmiClasses[strings.ToUpper(uniquevin[:3])]
+
+	return result, ok
+}
@@ -0,0 +1,37 @@
+package core
+
+import "testing"
+
+func TestVehicleClassOf(t *testing.T) {
+	cases := map[string]VehicleType{
+		"1FTFW1ET5DF000001": Truck,
+		"1FMCU0GX5DU000001": MPV,
+		"1fa6p8th5f5000001": PassengerCar,
+		"JH2RC5006RM000001": Motorcycle,
+		"SFD5DBRR5KGX00001": Bus,
+	}
+
+	for vin, expect := range cases {
+		if class, ok := VehicleClassOf(vin); !ok || class != expect {
+			t.Errorf("%s: expected %s, got %s", vin, expect, class)
+		}
+	}
+
+	if _, ok := VehicleClassOf("9BWZZZ377VT000001"); ok {
+		t.Error("expected 9BW not to be in the table")
+	}
+}
+
+func TestWMInfo_Class(t *testing.T) {
+	stored := WMInfo{VehicleType: PassengerCar.String()}
+
+	if stored.Class() != PassengerCar.String() {
+		t.Errorf("expected the VehicleType, got %s", stored.Class())
+	}
+
+	decoded := WMInfo{VehicleType: PassengerCar.String(), VehicleClass: MPV.String()}
+
+	if decoded.Class() != MPV.String() {
+		t.Errorf("expected the VehicleClass, got %s", decoded.Class())
+	}
+}
@@ -1,17 +1,57 @@
 package core
 
 import (
+	"fmt"
 	"strings"
 )
 
 type WMInfo struct {
-	Region       string
-	Country      string
-	Manufacturer string
-	VehicleType  string // VehicleType
+	Region        string
+	Country       string
+	CountryAlpha2 string `json:",omitempty"` //ISO 3166-1 alpha-2 code of the Country, ie. DE
+	CountryAlpha3 string `json:",omitempty"` //ISO 3166-1 alpha-3 code of the Country, ie. DEU
+	Manufacturer  string
+	VehicleType   string   // VehicleType
+	VehicleClass  string   //The VehicleType the WMI was assigned for, the manufacturer's VehicleType when the WMI isn't in the table. See VehicleClassOf
+	Suggestions   []string `json:",omitempty"` //Similar WMIs, when the manufacturer wasn't found
 }
 
+//wmiMiss is the result of a WMI without a manufacturer, kept in the negative cache
+type wmiMiss struct {
+	info WMInfo
+	err  error
+}
+
+//FindWMInfo returns the region, country and manufacturer of the WMI.
+//When FuzzyWMI is enabled and the manufacturer is unknown, similar WMIs are suggested.
+//WMIs without a manufacturer are remembered for the NegativeTTL.
 func FindWMInfo(uniquevin string) (WMInfo, error) {
+	key := fmt.Sprintf("wmi:%s:%v", uniquevin[:3], FuzzyWMI)
+
+	if miss, ok := notFound.get(key); ok {
+		return miss.(wmiMiss).info, miss.(wmiMiss).err
+	}
+
+	result, err := findWMInfo(uniquevin)
+
+	//Errors of the store are temporary, they aren't remembered as misses
+	if (err != nil && undecodable(err)) || (err == nil && len(result.Manufacturer) == 0) {
+		notFound.put(key, wmiMiss{info: result, err: err})
+	}
+
+	return result, err
+}
+
+//Class returns the VehicleClass, VINs which were stored before it was decoded fall back to the VehicleType
+func (w WMInfo) Class() string {
+	if len(w.VehicleClass) == 0 {
+		return w.VehicleType
+	}
+
+	return w.VehicleClass
+}
+
+func findWMInfo(uniquevin string) (WMInfo, error) {
 	result := WMInfo{}
 
 	region, err := GetRegionByCode(uniquevin)
@@ -30,12 +70,13 @@ func FindWMInfo(uniquevin string) (WMInfo, error) {
 
 		if country.RegionCode == regionCode && country.HasCode(countryCode) {
 			result.Country = country.Name
+			result.CountryAlpha2, result.CountryAlpha3, _ = CountryCodes(country.Name)
 			wmi := uniquevin[:3]
 
 			for j := 0; j < len(country.Manufacturers); j++ {
 				manufacturer := country.Manufacturers[j]
 
-				if strings.HasPrefix(wmi, manufacturer.WMICode) {
+				if len(manufacturer.WMICode) > 0 && strings.HasPrefix(wmi, manufacturer.WMICode) {
 					result.Manufacturer = manufacturer.Name
 					result.VehicleType = manufacturer.VehicleType.String()
 
@@ -47,5 +88,15 @@ func FindWMInfo(uniquevin string) (WMInfo, error) {
 		}
 	}
 
+	if class, ok := VehicleClassOf(uniquevin); ok {
+		result.VehicleClass = class.String()
+	} else {
+		result.VehicleClass = result.VehicleType
+	}
+
+	if FuzzyWMI && len(result.Manufacturer) == 0 {
+		result.Suggestions = SuggestWMI(uniquevin)
+	}
+
 	return result, nil
 }
@@ -0,0 +1,103 @@
+//Package framecode handles the frame numbers used by Japanese domestic market vehicles instead of a 17 character VIN.
+//A frame number is the model code and serial, separated by a hyphen. ie. JZA80-0012345
+package framecode
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//FrameNumber is the parsed detail of a JDM frame number
+type FrameNumber struct {
+	Full         string
+	ModelCode    string
+	Serial       int
+	Manufacturer string
+	Model        string
+}
+
+//Validate checks the format of the frame number
+func Validate(frame string) error {
+	_, _, err := split(frame)
+
+	return err
+}
+
+//Parse validates the frame number and looks up the manufacturer and model of known model codes.
+//Unknown model codes are not an error, the Manufacturer and Model will be empty.
+func Parse(frame string) (FrameNumber, error) {
+	code, serial, err := split(frame)
+
+	if err != nil {
+		return FrameNumber{}, err
+	}
+
+	num, err := strconv.Atoi(serial)
+
+	if err != nil {
+		return FrameNumber{}, err
+	}
+
+	result := FrameNumber{
+		Full:      code + "-" + serial,
+		ModelCode: code,
+		Serial:    num,
+	}
+
+	if m, ok := findModel(code); ok {
+		result.Manufacturer = m.Manufacturer
+		result.Model = m.Model
+	}
+
+	return result, nil
+}
+
+//split returns the model code and serial of the frame number
+func split(frame string) (string, string, error) {
+	frame = strings.ToUpper(strings.TrimSpace(frame))
+	idx := strings.LastIndexAny(frame, "- ")
+
+	if idx == -1 {
+		return "", "", errors.New("frame number needs a model code and serial, separated by '-'")
+	}
+
+	code, serial := frame[:idx], frame[idx+1:]
+
+	//Emission prefixes like "GF-" are sometimes included with the model code
+	if p := strings.LastIndexAny(code, "- "); p != -1 {
+		code = code[p+1:]
+	}
+
+	if len(code) < 2 || len(code) > 8 {
+		return "", "", errors.New("model code must be 2 to 8 characters")
+	}
+
+	hasLetter := false
+
+	for _, c := range code {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			hasLetter = true
+		case c >= '0' && c <= '9':
+		default:
+			return "", "", errors.New("model code contains illegal characters")
+		}
+	}
+
+	if !hasLetter {
+		return "", "", errors.New("model code must contain a letter")
+	}
+
+	if len(serial) < 5 || len(serial) > 7 {
+		return "", "", errors.New("serial must be 5 to 7 digits")
+	}
+
+	for _, c := range serial {
+		if c < '0' || c > '9' {
+			return "", "", errors.New("serial must only contain digits")
+		}
+	}
+
+	return code, serial, nil
+}
@@ -0,0 +1,59 @@
+package framecode
+
+import "testing"
+
+func TestParse_KnownModel(t *testing.T) {
+	obj, err := Parse("jza80-0012345")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.ModelCode != "JZA80" || obj.Serial != 12345 {
+		t.Errorf("unexpected %+v", obj)
+	}
+
+	if obj.Manufacturer != "Toyota" || obj.Model != "Supra" {
+		t.Errorf("expected Toyota Supra, got %s %s", obj.Manufacturer, obj.Model)
+	}
+}
+
+func TestParse_EmissionPrefix(t *testing.T) {
+	obj, err := Parse("GF-BNR34-005678")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Full != "BNR34-005678" {
+		t.Errorf("expected BNR34-005678, got %s", obj.Full)
+	}
+}
+
+func TestParse_UnknownModel(t *testing.T) {
+	obj, err := Parse("XYZ12-123456")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(obj.Manufacturer) != 0 {
+		t.Errorf("expected no manufacturer, got %s", obj.Manufacturer)
+	}
+}
+
+func TestValidate_Invalid(t *testing.T) {
+	frames := []string{
+		"JZA800012345",
+		"12345-0012345",
+		"JZA80-12",
+		"JZA80-00A2345",
+		"JZ*80-0012345",
+	}
+
+	for _, v := range frames {
+		if Validate(v) == nil {
+			t.Errorf("%s should not be valid", v)
+		}
+	}
+}
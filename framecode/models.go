@@ -0,0 +1,96 @@
+package framecode
+
+type model struct {
+	Manufacturer string
+	Model        string
+}
+
+//findModel returns the known model for the code
+func findModel(code string) (model, bool) {
+	m, ok := models[code]
+
+	return m, ok
+}
+
+var models = map[string]model{
+	//Toyota
+	"AE86":   {"Toyota", "Corolla Levin / Sprinter Trueno"},
+	"AE101":  {"Toyota", "Corolla Levin / Sprinter Trueno"},
+	"AE111":  {"Toyota", "Corolla Levin / Sprinter Trueno"},
+	"AW11":   {"Toyota", "MR2"},
+	"SW20":   {"Toyota", "MR2"},
+	"ZZW30":  {"Toyota", "MR-S"},
+	"JZA70":  {"Toyota", "Supra"},
+	"JZA80":  {"Toyota", "Supra"},
+	"JZX90":  {"Toyota", "Mark II / Chaser / Cresta"},
+	"JZX100": {"Toyota", "Mark II / Chaser / Cresta"},
+	"JZX110": {"Toyota", "Mark II Blit / Verossa"},
+	"JZS161": {"Toyota", "Aristo"},
+	"JZZ30":  {"Toyota", "Soarer"},
+	"ST205":  {"Toyota", "Celica GT-Four"},
+	"ZN6":    {"Toyota", "86"},
+	"HZJ76":  {"Toyota", "Land Cruiser 70"},
+	"UZJ100": {"Toyota", "Land Cruiser 100"},
+	"KZN185": {"Toyota", "Hilux Surf"},
+	"NHW20":  {"Toyota", "Prius"},
+	//Nissan
+	"BNR32":  {"Nissan", "Skyline GT-R"},
+	"BCNR33": {"Nissan", "Skyline GT-R"},
+	"BNR34":  {"Nissan", "Skyline GT-R"},
+	"ER34":   {"Nissan", "Skyline"},
+	"HCR32":  {"Nissan", "Skyline"},
+	"S13":    {"Nissan", "Silvia"},
+	"PS13":   {"Nissan", "Silvia"},
+	"S14":    {"Nissan", "Silvia"},
+	"S15":    {"Nissan", "Silvia"},
+	"RPS13":  {"Nissan", "180SX"},
+	"GZ32":   {"Nissan", "Fairlady Z"},
+	"CZ32":   {"Nissan", "Fairlady Z"},
+	"Z33":    {"Nissan", "Fairlady Z"},
+	"WGY61":  {"Nissan", "Safari"},
+	"R35":    {"Nissan", "GT-R"},
+	//Honda
+	"EF9": {"Honda", "Civic SiR"},
+	"EG6": {"Honda", "Civic SiR"},
+	"EK9": {"Honda", "Civic Type R"},
+	"EP3": {"Honda", "Civic Type R"},
+	"FD2": {"Honda", "Civic Type R"},
+	"DC2": {"Honda", "Integra Type R"},
+	"DC5": {"Honda", "Integra Type R"},
+	"NA1": {"Honda", "NSX"},
+	"NA2": {"Honda", "NSX"},
+	"AP1": {"Honda", "S2000"},
+	"AP2": {"Honda", "S2000"},
+	"PP1": {"Honda", "Beat"},
+	"CL1": {"Honda", "Accord Euro R"},
+	//Mazda
+	"FC3S":  {"Mazda", "RX-7"},
+	"FD3S":  {"Mazda", "RX-7"},
+	"SE3P":  {"Mazda", "RX-8"},
+	"NA6CE": {"Mazda", "Roadster"},
+	"NA8C":  {"Mazda", "Roadster"},
+	"NB8C":  {"Mazda", "Roadster"},
+	"PG6SA": {"Mazda", "Autozam AZ-1"},
+	//Subaru
+	"GC8": {"Subaru", "Impreza WRX"},
+	"GDB": {"Subaru", "Impreza WRX STI"},
+	"GRB": {"Subaru", "Impreza WRX STI"},
+	"BG5": {"Subaru", "Legacy"},
+	"BH5": {"Subaru", "Legacy"},
+	"KK3": {"Subaru", "Vivio"},
+	//Mitsubishi
+	"CD9A": {"Mitsubishi", "Lancer Evolution"},
+	"CE9A": {"Mitsubishi", "Lancer Evolution"},
+	"CN9A": {"Mitsubishi", "Lancer Evolution"},
+	"CP9A": {"Mitsubishi", "Lancer Evolution"},
+	"CT9A": {"Mitsubishi", "Lancer Evolution"},
+	"Z16A": {"Mitsubishi", "GTO"},
+	"V46W": {"Mitsubishi", "Pajero"},
+	"H58A": {"Mitsubishi", "Pajero Mini"},
+	//Suzuki
+	"EA11R": {"Suzuki", "Cappuccino"},
+	"EA21R": {"Suzuki", "Cappuccino"},
+	"JA11":  {"Suzuki", "Jimny"},
+	"JB23W": {"Suzuki", "Jimny"},
+	"HA36S": {"Suzuki", "Alto"},
+}
@@ -0,0 +1,256 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+//Selection is a single field requested by a query, with its arguments and sub-fields
+type Selection struct {
+	Alias      string
+	Name       string
+	Args       map[string]string
+	Selections []Selection
+}
+
+//Key returns the name the field will be returned as
+func (s Selection) Key() string {
+	if len(s.Alias) > 0 {
+		return s.Alias
+	}
+
+	return s.Name
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+//Parse reads the selection set of a query document.
+//Only the subset needed by our front-ends is supported: an optional 'query' operation name,
+//aliases, scalar arguments and nested selections. Fragments, variables and directives are not.
+func Parse(query string) ([]Selection, error) {
+	tokens, err := tokenize(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	if p.peek() == "query" {
+		p.next()
+
+		if p.peek() != "{" {
+			p.next()
+		}
+	}
+
+	result, err := p.selectionSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s after query", p.peek())
+	}
+
+	return result, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+
+	return tok
+}
+
+func (p *parser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		return fmt.Errorf("expected %s, got %q", tok, got)
+	}
+
+	return nil
+}
+
+func (p *parser) selectionSet() ([]Selection, error) {
+	err := p.expect("{")
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Selection
+
+	for p.peek() != "}" {
+		if p.peek() == "" {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+
+		field, err := p.field()
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, field)
+	}
+
+	p.next()
+
+	return result, nil
+}
+
+func (p *parser) field() (Selection, error) {
+	result := Selection{}
+	name := p.next()
+
+	if !isName(name) {
+		return result, fmt.Errorf("expected field name, got %q", name)
+	}
+
+	if p.peek() == ":" {
+		p.next()
+		result.Alias = name
+		name = p.next()
+
+		if !isName(name) {
+			return result, fmt.Errorf("expected field name, got %q", name)
+		}
+	}
+
+	result.Name = name
+
+	if p.peek() == "(" {
+		p.next()
+		result.Args = make(map[string]string)
+
+		for p.peek() != ")" {
+			arg := p.next()
+
+			if !isName(arg) {
+				return result, fmt.Errorf("expected argument name, got %q", arg)
+			}
+
+			err := p.expect(":")
+
+			if err != nil {
+				return result, err
+			}
+
+			val := p.next()
+
+			if val == "" {
+				return result, fmt.Errorf("missing value for %s", arg)
+			}
+
+			if strings.HasPrefix(val, `"`) {
+				val, err = strconv.Unquote(val)
+
+				if err != nil {
+					return result, fmt.Errorf("invalid string for %s", arg)
+				}
+			}
+
+			result.Args[arg] = val
+
+			if p.peek() == "," {
+				p.next()
+			}
+		}
+
+		p.next()
+	}
+
+	if p.peek() == "{" {
+		sels, err := p.selectionSet()
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Selections = sels
+	}
+
+	if p.peek() == "," {
+		p.next()
+	}
+
+	return result, nil
+}
+
+func tokenize(query string) ([]string, error) {
+	var result []string
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case strings.ContainsRune("{}():,", r):
+			result = append(result, string(r))
+		case r == '"':
+			start := i
+			i++
+
+			for i < len(runes) && runes[i] != '"' {
+				//Escaped quotes don't end the string
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+
+				i++
+			}
+
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+
+			result = append(result, string(runes[start:i+1]))
+		case isNameRune(r) || r == '-':
+			start := i
+
+			for i+1 < len(runes) && (isNameRune(runes[i+1]) || runes[i+1] == '.') {
+				i++
+			}
+
+			result = append(result, string(runes[start:i+1]))
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", r, i)
+		}
+	}
+
+	return result, nil
+}
+
+func isNameRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isName(tok string) bool {
+	if len(tok) == 0 {
+		return false
+	}
+
+	r := []rune(tok)[0]
+
+	return r == '_' || unicode.IsLetter(r)
+}
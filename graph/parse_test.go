@@ -0,0 +1,56 @@
+package graph
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	sels, err := Parse(`query Lookup { car: lookup(vin: "WAUZZZ8E88A025765") { full, wmi { country } } # comment
+	}`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sels) != 1 {
+		t.Fatalf("expected one selection, got %d", len(sels))
+	}
+
+	car := sels[0]
+
+	if car.Key() != "car" || car.Name != "lookup" || car.Args["vin"] != "WAUZZZ8E88A025765" {
+		t.Errorf("expected the aliased lookup with its argument, got %+v", car)
+	}
+
+	if len(car.Selections) != 2 || car.Selections[1].Name != "wmi" || car.Selections[1].Selections[0].Name != "country" {
+		t.Errorf("expected the nested selections, got %+v", car.Selections)
+	}
+}
+
+func TestParse_EscapedString(t *testing.T) {
+	sels, err := Parse(`{ manufacturer(wmi: "1M\"8") { name } }`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if wmi := sels[0].Args["wmi"]; wmi != `1M"8` {
+		t.Errorf("expected the escaped quote to be part of the value, got %q", wmi)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	queries := []string{
+		``,
+		`{ lookup(vin: "WAUZZZ8E88A025765") { full }`,
+		`{ lookup(vin: "WAUZZZ8E88A025765 }`,
+		`{ lookup(vin:) { full } }`,
+		`{ full } }`,
+		`{ $full }`,
+		`{ lookup(vin: "\q") { full } }`,
+	}
+
+	for _, q := range queries {
+		if _, err := Parse(q); err == nil {
+			t.Errorf("expected %q to be rejected", q)
+		}
+	}
+}
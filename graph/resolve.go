@@ -0,0 +1,405 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//resolver returns the value of a field, and the name of its object type when it isn't a scalar.
+type resolver func(src interface{}, sel Selection) (interface{}, string, error)
+
+var resolvers = map[string]resolver{
+	"Query":         resolveQuery,
+	"VIN":           resolveVIN,
+	"WMInfo":        resolveWMInfo,
+	"VDSInfo":       resolveVDSInfo,
+	"History":       resolveHistory,
+	"Manufacturer":  resolveManufacturer,
+	"AssemblyPlant": resolveAssemblyPlant,
+	"Series":        resolveSeries,
+	"Platform":      resolvePlatform,
+	"Body":          resolveBody,
+	"Engine":        resolveEngine,
+	"Gearbox":       resolveGearbox,
+}
+
+//Response is the result of a query. A field which fails is null in the Data, and its error is added to the Errors,
+//so the other fields are still returned.
+type Response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []Error                `json:"errors,omitempty"`
+}
+
+//Error is a query which can't be parsed, or a field which failed. The Path is the keys of the field in the Data.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+//Execute runs the query against the vehicle graph. Queries which can't be parsed have no Data.
+//charge is called before every lookup decodes its VIN, the lookup fails with the error it returns.
+func Execute(query string, charge func() error) Response {
+	sels, err := Parse(query)
+
+	if err != nil {
+		return Response{Errors: []Error{{Message: err.Error()}}}
+	}
+
+	result := Response{}
+	result.Data = resolveObject("Query", root{charge: charge}, sels, nil, &result.Errors)
+
+	return result
+}
+
+//root is the source of the Query fields
+type root struct {
+	charge func() error
+}
+
+func resolveObject(typeName string, src interface{}, sels []Selection, path []interface{}, errs *[]Error) map[string]interface{} {
+	resolve := resolvers[typeName]
+	result := make(map[string]interface{})
+
+	for _, sel := range sels {
+		if sel.Name == "__typename" {
+			result[sel.Key()] = typeName
+			continue
+		}
+
+		fieldPath := append(append([]interface{}{}, path...), sel.Key())
+		val, childType, err := resolve(src, sel)
+
+		if err != nil {
+			result[sel.Key()] = nil
+			*errs = append(*errs, Error{Message: err.Error(), Path: fieldPath})
+			continue
+		}
+
+		result[sel.Key()] = complete(childType, val, sel, fieldPath, errs)
+	}
+
+	return result
+}
+
+//complete applies the sub-selections of a field to its value
+func complete(typeName string, val interface{}, sel Selection, path []interface{}, errs *[]Error) interface{} {
+	if len(typeName) == 0 {
+		if len(sel.Selections) > 0 {
+			*errs = append(*errs, Error{Message: fmt.Sprintf("%s is a scalar and can't have selections", sel.Name), Path: path})
+			return nil
+		}
+
+		return val
+	}
+
+	if len(sel.Selections) == 0 {
+		*errs = append(*errs, Error{Message: fmt.Sprintf("%s of type %s must have a selection", sel.Name, typeName), Path: path})
+		return nil
+	}
+
+	rv := reflect.ValueOf(val)
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+	case reflect.Slice:
+		list := make([]interface{}, rv.Len())
+
+		for i := 0; i < rv.Len(); i++ {
+			itemPath := append(append([]interface{}{}, path...), i)
+			list[i] = complete(typeName, rv.Index(i).Interface(), sel, itemPath, errs)
+		}
+
+		return list
+	}
+
+	return resolveObject(typeName, val, sel.Selections, path, errs)
+}
+
+func unknownField(typeName string, sel Selection) error {
+	return fmt.Errorf("%s has no field %s", typeName, sel.Name)
+}
+
+func resolveQuery(src interface{}, sel Selection) (interface{}, string, error) {
+	q := src.(root)
+
+	switch sel.Name {
+	case "lookup":
+		vin := sel.Args["vin"]
+		err := core.ValidateVIN(vin)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		err = q.charge()
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		obj, err := core.BuildInfo(vin)
+
+		return obj, "VIN", err
+	case "vin":
+		key, err := core.ParseKey(sel.Args["key"])
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		obj, err := core.GetVIN(key)
+
+		return obj, "VIN", err
+	case "manufacturer":
+		wmi := sel.Args["wmi"]
+
+		if len(wmi) < 3 {
+			return nil, "", fmt.Errorf("wmi must be 3 characters")
+		}
+
+		obj, err := core.FindManufacturer(wmi)
+
+		return obj, "Manufacturer", err
+	}
+
+	return nil, "", unknownField("Query", sel)
+}
+
+func resolveVIN(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(*core.VIN)
+
+	switch sel.Name {
+	case "full":
+		return v.Full, "", nil
+	case "unique":
+		return v.Unique, "", nil
+	case "serial":
+		return v.Serial, "", nil
+	case "wmi":
+		return v.WMInfo, "WMInfo", nil
+	case "vds":
+		return vdsNode{info: v.VDSInfo, series: v.Series}, "VDSInfo", nil
+	case "series":
+		return v.Series, "Series", nil
+	case "history":
+		return core.GetHistory(v.Full), "History", nil
+	case "manufacturer":
+		//VINs without a known manufacturer resolve to null
+		obj, _ := core.FindManufacturer(v.Unique)
+
+		return obj, "Manufacturer", nil
+	}
+
+	return nil, "", unknownField("VIN", sel)
+}
+
+func resolveWMInfo(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.WMInfo)
+
+	switch sel.Name {
+	case "region":
+		return v.Region, "", nil
+	case "country":
+		return v.Country, "", nil
+	case "manufacturer":
+		return v.Manufacturer, "", nil
+	case "vehicleType":
+		return v.VehicleType, "", nil
+	}
+
+	return nil, "", unknownField("WMInfo", sel)
+}
+
+//vdsNode is the VDSInfo of a VIN, with the Series that was decoded from it
+type vdsNode struct {
+	info   vds.VDSInfo
+	series core.Series
+}
+
+func resolveVDSInfo(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(vdsNode)
+
+	switch sel.Name {
+	case "code":
+		return v.info.Code, "", nil
+	case "bodyStyle":
+		return v.info.BodyStyle, "", nil
+	case "chassis":
+		return v.info.Chassis, "", nil
+	case "plant":
+		return v.info.Plant, "", nil
+	case "series":
+		return v.series, "Series", nil
+	case "body":
+		return v.series.Platform.Body, "Body", nil
+	case "engine":
+		return v.series.Platform.Engine, "Engine", nil
+	case "gearbox":
+		return v.series.Platform.Gearbox, "Gearbox", nil
+	}
+
+	return nil, "", unknownField("VDSInfo", sel)
+}
+
+func resolveManufacturer(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(*core.Manufacturer)
+
+	switch sel.Name {
+	case "wmiCode":
+		return v.WMICode, "", nil
+	case "name":
+		return v.Name, "", nil
+	case "description":
+		return v.Description, "", nil
+	case "vehicleType":
+		return v.VehicleType.String(), "", nil
+	case "assemblyPlants":
+		return v.AssemblyPlants, "AssemblyPlant", nil
+	}
+
+	return nil, "", unknownField("Manufacturer", sel)
+}
+
+func resolveAssemblyPlant(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.AssemblyPlant)
+
+	switch sel.Name {
+	case "code":
+		return v.Code, "", nil
+	case "name":
+		return v.Name, "", nil
+	case "country":
+		return v.Country, "", nil
+	case "startYear":
+		return v.StartYear, "", nil
+	case "endYear":
+		return v.EndYear, "", nil
+	case "series":
+		return v.Series, "Series", nil
+	}
+
+	return nil, "", unknownField("AssemblyPlant", sel)
+}
+
+func resolveSeries(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.Series)
+
+	switch sel.Name {
+	case "spec":
+		return v.Spec, "", nil
+	case "startYear":
+		return v.StartYear, "", nil
+	case "endYear":
+		return v.EndYear, "", nil
+	case "platform":
+		return v.Platform, "Platform", nil
+	}
+
+	return nil, "", unknownField("Series", sel)
+}
+
+func resolvePlatform(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.Platform)
+
+	switch sel.Name {
+	case "code":
+		return v.Code, "", nil
+	case "driveLayout":
+		return v.DriveLayout, "", nil
+	case "startYear":
+		return v.StartYear, "", nil
+	case "endYear":
+		return v.EndYear, "", nil
+	case "body":
+		return v.Body, "Body", nil
+	case "engine":
+		return v.Engine, "Engine", nil
+	case "gearbox":
+		return v.Gearbox, "Gearbox", nil
+	}
+
+	return nil, "", unknownField("Platform", sel)
+}
+
+func resolveBody(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.Body)
+
+	switch sel.Name {
+	case "code":
+		return v.Code, "", nil
+	case "layout":
+		return v.Layout, "", nil
+	case "doors":
+		return v.Doors, "", nil
+	}
+
+	return nil, "", unknownField("Body", sel)
+}
+
+func resolveEngine(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.Engine)
+
+	switch sel.Name {
+	case "code":
+		return v.Code, "", nil
+	case "family":
+		return v.Family, "", nil
+	case "series":
+		return v.Series, "", nil
+	case "displacement":
+		return v.Displacement, "", nil
+	case "fuelType":
+		return v.FuelType, "", nil
+	case "layout":
+		return v.Layout, "", nil
+	case "cylinders":
+		return v.Cylinders, "", nil
+	case "induction":
+		return v.Induction, "", nil
+	case "powerKW":
+		return v.PowerKW, "", nil
+	case "torqueNm":
+		return v.TorqueNm, "", nil
+	}
+
+	return nil, "", unknownField("Engine", sel)
+}
+
+func resolveGearbox(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.Gearbox)
+
+	switch sel.Name {
+	case "code":
+		return v.Code, "", nil
+	case "gears":
+		return v.Gears, "", nil
+	case "type":
+		return v.Type, "", nil
+	}
+
+	return nil, "", unknownField("Gearbox", sel)
+}
+
+func resolveHistory(src interface{}, sel Selection) (interface{}, string, error) {
+	v := src.(core.HistoryRecord)
+
+	switch sel.Name {
+	case "at":
+		return v.At.Format(time.RFC3339), "", nil
+	case "kind":
+		return v.Kind, "", nil
+	case "detail":
+		return v.Detail, "", nil
+	}
+
+	return nil, "", unknownField("History", sel)
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestResolve_VDSEdges(t *testing.T) {
+	v := &core.VIN{
+		Full:    "WBA3A5C50CF256651",
+		VDSInfo: vds.VDSInfo{Code: "3A5C50", Chassis: "F30"},
+		Series: core.Series{
+			Spec:     "328i",
+			Platform: core.Platform{Code: "F30", Engine: core.Engine{Code: "N20B20"}},
+		},
+	}
+
+	sels, err := Parse(`{ full vds { code chassis series { spec } engine { code } } series { platform { code } } }`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []Error
+	result := resolveObject("VIN", v, sels, nil, &errs)
+
+	if len(errs) > 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+
+	expected := map[string]interface{}{
+		"full": "WBA3A5C50CF256651",
+		"vds": map[string]interface{}{
+			"code":    "3A5C50",
+			"chassis": "F30",
+			"series":  map[string]interface{}{"spec": "328i"},
+			"engine":  map[string]interface{}{"code": "N20B20"},
+		},
+		"series": map[string]interface{}{
+			"platform": map[string]interface{}{"code": "F30"},
+		},
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestResolve_PartialErrors(t *testing.T) {
+	v := &core.VIN{Full: "WBA3A5C50CF256651"}
+	sels, err := Parse(`{ full colour vds { code body } }`)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []Error
+	result := resolveObject("VIN", v, sels, nil, &errs)
+
+	if result["full"] != "WBA3A5C50CF256651" {
+		t.Errorf("expected the fields which resolved to be returned, got %v", result)
+	}
+
+	if val, ok := result["colour"]; !ok || val != nil {
+		t.Errorf("expected the unknown field to be null, got %v", val)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected two errors, got %v", errs)
+	}
+
+	if !reflect.DeepEqual(errs[0].Path, []interface{}{"colour"}) || !reflect.DeepEqual(errs[1].Path, []interface{}{"vds", "body"}) {
+		t.Errorf("expected the paths of the fields, got %v and %v", errs[0].Path, errs[1].Path)
+	}
+}
+
+func TestExecute_Errors(t *testing.T) {
+	result := Execute(`{ lookup(vin: "TOO SHORT") { full } }`, free)
+
+	if result.Data == nil || result.Data["lookup"] != nil {
+		t.Errorf("expected a null lookup, got %v", result.Data)
+	}
+
+	if len(result.Errors) != 1 || !reflect.DeepEqual(result.Errors[0].Path, []interface{}{"lookup"}) {
+		t.Errorf("expected the error of the lookup, got %v", result.Errors)
+	}
+
+	result = Execute(`{ lookup`, free)
+
+	if result.Data != nil || len(result.Errors) != 1 {
+		t.Errorf("expected a query which can't be parsed to have no data, got %+v", result)
+	}
+}
+
+func TestExecute_ChargesLookups(t *testing.T) {
+	charged := 0
+	charge := func() error {
+		charged++
+		return errors.New("quota exceeded")
+	}
+
+	result := Execute(`{ a: lookup(vin: "1M8GDM9AXKP042788") { full } b: lookup(vin: "1M8GDM9AXKP042788") { full } c: lookup(vin: "TOO SHORT") { full } }`, charge)
+
+	if charged != 2 {
+		t.Errorf("expected both valid lookups to be charged, got %d", charged)
+	}
+
+	if len(result.Errors) != 3 || result.Errors[0].Message != "quota exceeded" {
+		t.Errorf("expected the lookups which weren't charged to fail, got %v", result.Errors)
+	}
+}
+
+//free is the charge of queries which aren't counted
+func free() error {
+	return nil
+}
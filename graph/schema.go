@@ -0,0 +1,104 @@
+package graph
+
+//Schema describes the vehicle graph that can be queried
+const Schema = `
+type Query {
+	lookup(vin: String!): VIN
+	vin(key: ID!): VIN
+	manufacturer(wmi: String!): Manufacturer
+}
+
+type VIN {
+	full: String!
+	unique: String!
+	serial: Int!
+	wmi: WMInfo!
+	vds: VDSInfo!
+	series: Series
+	manufacturer: Manufacturer
+	history: [History]
+}
+
+type WMInfo {
+	region: String
+	country: String
+	manufacturer: String
+	vehicleType: String
+}
+
+type VDSInfo {
+	code: String!
+	bodyStyle: String
+	chassis: String
+	plant: String
+	series: Series
+	body: Body
+	engine: Engine
+	gearbox: Gearbox
+}
+
+type History {
+	at: String!
+	kind: String!
+	detail: String
+}
+
+type Manufacturer {
+	wmiCode: String!
+	name: String!
+	description: String
+	vehicleType: String
+	assemblyPlants: [AssemblyPlant]
+}
+
+type AssemblyPlant {
+	code: String
+	name: String
+	country: String
+	startYear: Int
+	endYear: Int
+	series: [Series]
+}
+
+type Series {
+	spec: String
+	startYear: Int
+	endYear: Int
+	platform: Platform
+}
+
+type Platform {
+	code: String
+	driveLayout: String
+	startYear: Int
+	endYear: Int
+	body: Body
+	engine: Engine
+	gearbox: Gearbox
+}
+
+type Body {
+	code: String
+	layout: String
+	doors: Int
+}
+
+type Engine {
+	code: String
+	family: String
+	series: String
+	displacement: Int
+	fuelType: String
+	layout: String
+	cylinders: Int
+	induction: String
+	powerKW: Int
+	torqueNm: Int
+}
+
+type Gearbox {
+	code: String
+	gears: Int
+	type: String
+}
+`
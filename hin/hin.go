@@ -0,0 +1,106 @@
+//Package hin handles the 12 character Hull Identification Numbers of boats, as assigned under 33 CFR 181.
+//ie. ABC12345D404 is hull 12345 of manufacturer ABC, certified in April 2004 as a 2004 model.
+package hin
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//HIN is the parsed detail of a hull identification number
+type HIN struct {
+	Full         string
+	Manufacturer string //Manufacturer Identification Code assigned by the US Coast Guard
+	Serial       string
+	Month        time.Month //Month of certification or production
+	Year         int        //Year of certification or production
+	ModelYear    int
+}
+
+//Canonical returns the HIN in upper case, without the separators it's often written with. ie. ABC-12345-D4-04
+func Canonical(hin string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(hin)))
+}
+
+//Validate checks the format of the HIN
+func Validate(hin string) error {
+	_, err := Parse(hin)
+
+	return err
+}
+
+//Parse validates the HIN and decodes its sections.
+//Only the format used since August 1984 is supported, the month is a letter A-L in position 9.
+func Parse(hin string) (HIN, error) {
+	hin = Canonical(hin)
+
+	if len(hin) != 12 {
+		return HIN{}, errors.New("not correct length")
+	}
+
+	for _, c := range hin[:3] {
+		if c < 'A' || c > 'Z' {
+			return HIN{}, errors.New("manufacturer code must be letters")
+		}
+	}
+
+	for _, c := range hin[3:8] {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z' || c == 'I' || c == 'O' || c == 'Q') {
+			return HIN{}, errors.New("found illegal characters in the serial")
+		}
+	}
+
+	month := hin[8]
+
+	if month < 'A' || month > 'L' {
+		return HIN{}, fmt.Errorf("%c is not a month A-L", month)
+	}
+
+	digit, err := strconv.Atoi(hin[9:10])
+
+	if err != nil {
+		return HIN{}, errors.New("year of certification must be a digit")
+	}
+
+	model, err := strconv.Atoi(hin[10:])
+
+	if err != nil {
+		return HIN{}, errors.New("model year must be two digits")
+	}
+
+	modelYear := century(model, time.Now().Year())
+
+	return HIN{
+		Full:         hin,
+		Manufacturer: hin[:3],
+		Serial:       hin[3:8],
+		Month:        time.Month(month-'A') + time.January,
+		Year:         certified(digit, modelYear),
+		ModelYear:    modelYear,
+	}, nil
+}
+
+//century returns the model year of the two digits, model years are at most one year ahead of now
+func century(yy, now int) int {
+	result := now - now%100 + yy
+
+	if result > now+1 {
+		result -= 100
+	}
+
+	return result
+}
+
+//certified returns the year of certification, the last year with the digit which isn't after the model year
+func certified(digit, modelYear int) int {
+	result := modelYear - modelYear%10 + digit
+
+	if result > modelYear {
+		result -= 10
+	}
+
+	return result
+}
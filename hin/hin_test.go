@@ -0,0 +1,61 @@
+package hin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_Current(t *testing.T) {
+	h, err := Parse("abc-12345-d4-04")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Full != "ABC12345D404" || h.Manufacturer != "ABC" || h.Serial != "12345" {
+		t.Errorf("unexpected %+v", h)
+	}
+
+	if h.Month != time.April || h.Year != 2004 || h.ModelYear != 2004 {
+		t.Errorf("expected April 2004 for 2004, got %s %d for %d", h.Month, h.Year, h.ModelYear)
+	}
+}
+
+func TestParse_CertifiedTheYearBefore(t *testing.T) {
+	h, err := Parse("XYZ0001AK910")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if h.Month != time.November || h.Year != 2009 || h.ModelYear != 2010 {
+		t.Errorf("expected November 2009 for 2010, got %s %d for %d", h.Month, h.Year, h.ModelYear)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := []string{
+		"ABC12345D40",  //length
+		"A1C12345D404", //manufacturer
+		"ABC1234OD404", //serial
+		"ABC12345M404", //month
+		"ABC12345DX04", //year
+		"ABC12345D4X4", //model year
+	}
+
+	for _, c := range cases {
+		if Validate(c) == nil {
+			t.Errorf("expected %s to be invalid", c)
+		}
+	}
+}
+
+func TestCentury(t *testing.T) {
+	if y := century(98, 2026); y != 1998 {
+		t.Errorf("expected 1998, got %d", y)
+	}
+
+	if y := century(27, 2026); y != 2027 {
+		t.Errorf("expected next year's models, got %d", y)
+	}
+}
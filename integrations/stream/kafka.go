@@ -0,0 +1,73 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//Kafka publishes messages to a topic through a Kafka REST Proxy, keyed by the full VIN so events for a vehicle stay in order.
+type Kafka struct {
+	ProxyURL string
+	Topic    string
+	client   *http.Client
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string  `json:"key"`
+	Value Message `json:"value"`
+}
+
+//NewKafka returns a publisher for the REST Proxy at proxyURL, ie. http://localhost:8082
+func NewKafka(proxyURL, topic string) *Kafka {
+	return &Kafka{
+		ProxyURL: strings.TrimSuffix(proxyURL, "/"),
+		Topic:    topic,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+//Publish sends the message to the topic
+func (k *Kafka) Publish(msg Message) error {
+	body, err := json.Marshal(kafkaRecords{
+		Records: []kafkaRecord{{Key: msg.VIN.Full, Value: msg}},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, k.ProxyURL+"/topics/"+url.PathEscape(k.Topic), bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	resp, err := k.client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kafka proxy returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+//Close does nothing, every publish is a separate request
+func (k *Kafka) Close() error {
+	return nil
+}
@@ -0,0 +1,160 @@
+package stream
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+//NATS publishes messages to a subject with the NATS text protocol
+type NATS struct {
+	Address string
+	Subject string
+
+	mu     sync.Mutex
+	conn   net.Conn
+	failed error
+}
+
+//NewNATS returns a publisher for the server at address, ie. localhost:4222
+func NewNATS(address, subject string) *NATS {
+	return &NATS{
+		Address: strings.TrimPrefix(address, "nats://"),
+		Subject: subject,
+	}
+}
+
+//Publish sends the message, the connection is made on the first publish and remade after a failure.
+func (n *NATS) Publish(msg Message) error {
+	data, err := json.Marshal(msg)
+
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.failed != nil {
+		n.reset()
+	}
+
+	if n.conn == nil {
+		err = n.connect()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(n.conn, "PUB %s %d\r\n%s\r\n", n.Subject, len(data), data)
+
+	if err != nil {
+		n.reset()
+		return err
+	}
+
+	return nil
+}
+
+//Close closes the connection
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close()
+	n.conn = nil
+
+	return err
+}
+
+func (n *NATS) connect() error {
+	conn, err := net.DialTimeout("tcp", n.Address, 10*time.Second)
+
+	if err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(conn)
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	info, err := r.ReadString('\n')
+
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	if !strings.HasPrefix(info, "INFO") {
+		conn.Close()
+		return fmt.Errorf("unexpected greeting %q", strings.TrimSpace(info))
+	}
+
+	conn.SetReadDeadline(time.Time{})
+	_, err = fmt.Fprint(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"vin\"}\r\n")
+
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	n.conn = conn
+	n.failed = nil
+
+	go n.read(conn, r)
+
+	return nil
+}
+
+//read answers the server's PINGs, and records errors so the next Publish reconnects.
+func (n *NATS) read(conn net.Conn, r *bufio.Reader) {
+	for {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			n.fail(conn, err)
+			return
+		}
+
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			n.mu.Lock()
+			_, err = fmt.Fprint(conn, "PONG\r\n")
+			n.mu.Unlock()
+
+			if err != nil {
+				n.fail(conn, err)
+				return
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			n.fail(conn, errors.New(strings.TrimSpace(line)))
+			return
+		}
+	}
+}
+
+func (n *NATS) fail(conn net.Conn, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == conn {
+		n.failed = err
+	}
+}
+
+func (n *NATS) reset() {
+	if n.conn != nil {
+		n.conn.Close()
+	}
+
+	n.conn = nil
+	n.failed = nil
+}
@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNATS_Publish(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer ln.Close()
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := ln.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		conn.Write([]byte("INFO {}\r\n"))
+		r := bufio.NewReader(conn)
+
+		for {
+			line, err := r.ReadString('\n')
+
+			if err != nil {
+				return
+			}
+
+			if strings.HasPrefix(line, "PUB") {
+				payload, _ := r.ReadString('\n')
+				received <- strings.TrimSpace(line) + "|" + strings.TrimSpace(payload)
+				return
+			}
+		}
+	}()
+
+	pub := NewNATS("nats://"+ln.Addr().String(), "vin.events")
+	defer pub.Close()
+
+	msg := Message{Schema: SchemaName, Type: "created", VIN: VehicleInfo{Full: "5NPEU46F77H259112"}}
+	err = pub.Publish(msg)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-received:
+		parts := strings.SplitN(got, "|", 2)
+
+		if !strings.HasPrefix(parts[0], "PUB vin.events ") {
+			t.Errorf("unexpected %s", parts[0])
+		}
+
+		result := Message{}
+		err = json.Unmarshal([]byte(parts[1]), &result)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.VIN.Full != msg.VIN.Full {
+			t.Errorf("expected %s, got %s", msg.VIN.Full, result.VIN.Full)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("nothing was published")
+	}
+}
@@ -0,0 +1,83 @@
+package stream
+
+//Schema is the JSON Schema of a Message
+const Schema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"$id": "vin.event.v1",
+	"type": "object",
+	"required": ["schema", "type", "time", "vin"],
+	"properties": {
+		"schema": {"const": "vin.event.v1"},
+		"type": {"enum": ["decoded", "created", "possible-clone"]},
+		"time": {"type": "string", "format": "date-time"},
+		"vin": {
+			"type": "object",
+			"required": ["full", "wmi", "vds", "serial", "modelYear"],
+			"properties": {
+				"full": {"type": "string", "minLength": 17, "maxLength": 17},
+				"wmi": {"type": "string", "minLength": 3, "maxLength": 3},
+				"vds": {"type": "string"},
+				"serial": {"type": "integer"},
+				"modelYear": {"type": "integer"},
+				"region": {"type": "string"},
+				"country": {"type": "string"},
+				"countryAlpha2": {"type": "string", "description": "ISO 3166-1 alpha-2 code of the country"},
+				"countryAlpha3": {"type": "string", "description": "ISO 3166-1 alpha-3 code of the country"},
+				"manufacturer": {"type": "string"},
+				"vehicleType": {"type": "string"},
+				"bodyStyle": {"type": "string"},
+				"chassis": {"type": "string"},
+				"source": {"type": "string"}
+			}
+		},
+		"clone": {
+			"type": "object",
+			"description": "Only set on possible-clone events, the source which sent the VIN first",
+			"properties": {
+				"firstSource": {"type": "string"},
+				"firstSeen": {"type": "string", "format": "date-time"}
+			}
+		}
+	}
+}`
+
+//AvroSchema describes a Message for consumers using a schema registry.
+//Messages are still published as JSON, the fields and names are the same.
+const AvroSchema = `{
+	"type": "record",
+	"name": "Event",
+	"namespace": "vin.event.v1",
+	"fields": [
+		{"name": "schema", "type": "string"},
+		{"name": "type", "type": "string", "doc": "decoded, created or possible-clone"},
+		{"name": "time", "type": "string"},
+		{"name": "vin", "type": {
+			"type": "record",
+			"name": "VehicleInfo",
+			"fields": [
+				{"name": "full", "type": "string"},
+				{"name": "wmi", "type": "string"},
+				{"name": "vds", "type": "string"},
+				{"name": "serial", "type": "int"},
+				{"name": "modelYear", "type": "int"},
+				{"name": "region", "type": "string"},
+				{"name": "country", "type": "string"},
+				{"name": "countryAlpha2", "type": "string", "default": ""},
+				{"name": "countryAlpha3", "type": "string", "default": ""},
+				{"name": "manufacturer", "type": "string"},
+				{"name": "vehicleType", "type": "string"},
+				{"name": "bodyStyle", "type": "string"},
+				{"name": "chassis", "type": "string"},
+				{"name": "source", "type": "string"}
+			]
+		}},
+		{"name": "clone", "default": null, "type": ["null", {
+			"type": "record",
+			"name": "CloneInfo",
+			"fields": [
+				{"name": "firstSource", "type": "string"},
+				{"name": "firstSeen", "type": "string"}
+			]
+		}]}
+	]
+}`
@@ -0,0 +1,140 @@
+//Package stream publishes VIN decode and create events to Kafka and NATS, so analytics pipelines receive vehicles as they arrive.
+//Messages use the JSON schema in schema.go, which doesn't change when the core structs are refactored.
+package stream
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+//SchemaName identifies the version of the message schema
+const SchemaName = "vin.event.v1"
+
+//Publisher sends messages to a stream
+type Publisher interface {
+	Publish(msg Message) error
+	Close() error
+}
+
+//Message is the payload of every event, documented by Schema
+type Message struct {
+	Schema string      `json:"schema"`
+	Type   string      `json:"type"`
+	Time   time.Time   `json:"time"`
+	VIN    VehicleInfo `json:"vin"`
+	Clone  *CloneInfo  `json:"clone,omitempty"`
+}
+
+//CloneInfo is the first source of a VIN, on possible-clone events
+type CloneInfo struct {
+	FirstSource string    `json:"firstSource"`
+	FirstSeen   time.Time `json:"firstSeen"`
+}
+
+//VehicleInfo is the decoded VIN in the message
+type VehicleInfo struct {
+	Full          string `json:"full"`
+	WMI           string `json:"wmi"`
+	VDS           string `json:"vds"`
+	Serial        int    `json:"serial"`
+	ModelYear     int    `json:"modelYear"`
+	Region        string `json:"region"`
+	Country       string `json:"country"`
+	CountryAlpha2 string `json:"countryAlpha2,omitempty"`
+	CountryAlpha3 string `json:"countryAlpha3,omitempty"`
+	Manufacturer  string `json:"manufacturer"`
+	VehicleType   string `json:"vehicleType"`
+	BodyStyle     string `json:"bodyStyle"`
+	Chassis       string `json:"chassis"`
+	Source        string `json:"source"`
+}
+
+//NewMessage converts the core Event to a Message
+func NewMessage(e core.Event) Message {
+	v := e.VIN
+	result := Message{
+		Schema: SchemaName,
+		Type:   e.Type,
+		Time:   e.Time.UTC(),
+		VIN: VehicleInfo{
+			Full:          v.Full,
+			WMI:           v.Full[:3],
+			VDS:           v.VDSInfo.Code,
+			Serial:        v.Serial,
+			ModelYear:     v.ModelYear(),
+			Region:        v.WMInfo.Region,
+			Country:       v.WMInfo.Country,
+			CountryAlpha2: v.WMInfo.CountryAlpha2,
+			CountryAlpha3: v.WMInfo.CountryAlpha3,
+			Manufacturer:  v.WMInfo.Manufacturer,
+			VehicleType:   v.WMInfo.VehicleType,
+			BodyStyle:     v.VDSInfo.BodyStyle,
+			Chassis:       v.VDSInfo.Chassis,
+			Source:        v.Source,
+		},
+	}
+
+	if e.Clone != nil {
+		result.Clone = &CloneInfo{
+			FirstSource: e.Clone.FirstSource,
+			FirstSeen:   e.Clone.FirstSeen.UTC(),
+		}
+		result.VIN.Source = e.Clone.Source
+	}
+
+	return result
+}
+
+//Attach publishes every core Event with p. Events are queued, so decoding isn't slowed down by the stream,
+//when the queue is full events are dropped and logged. The returned func flushes the queue and closes p.
+func Attach(p Publisher, queueSize int) func() {
+	queue := make(chan Message, queueSize)
+	done := make(chan struct{})
+	var mu sync.RWMutex
+	closed := false
+
+	core.Subscribe(func(e core.Event) {
+		mu.RLock()
+		defer mu.RUnlock()
+
+		if closed {
+			return
+		}
+
+		select {
+		case queue <- NewMessage(e):
+		default:
+			log.Println("stream queue is full, dropped", e.Type, e.VIN.Full)
+		}
+	})
+
+	go func() {
+		defer close(done)
+
+		for msg := range queue {
+			err := p.Publish(msg)
+
+			if err != nil {
+				log.Println("stream publish", err)
+			}
+		}
+	}()
+
+	return func() {
+		mu.Lock()
+		closed = true
+		close(queue)
+		mu.Unlock()
+
+		<-done
+
+		err := p.Close()
+
+		if err != nil {
+			log.Println("stream close", err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+func TestNewMessage_Clone(t *testing.T) {
+	first := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := core.Event{
+		Type:  core.EventPossibleClone,
+		Time:  time.Now(),
+		VIN:   core.VIN{Full: "WDD2050041F000001", Source: "auction"},
+		Clone: &core.PossibleCloneAlert{VIN: "WDD2050041F000001", FirstSource: "auction", FirstSeen: first, Source: "dealer"},
+	}
+
+	msg := NewMessage(e)
+
+	if msg.Clone == nil || msg.Clone.FirstSource != "auction" || !msg.Clone.FirstSeen.Equal(first) {
+		t.Fatalf("expected the first source, got %+v", msg.Clone)
+	}
+
+	if msg.VIN.Source != "dealer" {
+		t.Errorf("expected the source which sent it again, got %s", msg.VIN.Source)
+	}
+
+	if NewMessage(core.Event{Type: core.EventCreated, VIN: e.VIN}).Clone != nil {
+		t.Error("expected other events not to have a clone")
+	}
+}
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"log"
 	"os"
+	"os/signal"
 	"path"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/louisevanderlith/droxolite"
 	"github.com/louisevanderlith/droxolite/bodies"
@@ -10,7 +15,12 @@ import (
 	"github.com/louisevanderlith/droxolite/element"
 	"github.com/louisevanderlith/droxolite/resins"
 	"github.com/louisevanderlith/droxolite/servicetype"
+	"github.com/louisevanderlith/vin/config"
+	"github.com/louisevanderlith/vin/controllers"
+	"github.com/louisevanderlith/vin/integrations/stream"
+	"github.com/louisevanderlith/vin/refdata"
 	"github.com/louisevanderlith/vin/routers"
+	"github.com/louisevanderlith/vin/vdskit"
 
 	"github.com/louisevanderlith/vin/core"
 )
@@ -44,11 +54,85 @@ func main() {
 	}
 
 	poxy := resins.NewMonoEpoxy(srv, element.GetNoTheme(host, srv.ID, profile))
+	controllers.SetPublicKey(pubPath)
 	routers.Setup(poxy)
 	poxy.EnableCORS(host)
 
-	core.CreateContext()
-	defer core.Shutdown()
+	//VINCONFIG is the JSON file with the settings of the service, the environment variables in config override it
+	cfg, err := config.Load(os.Getenv("VINCONFIG"))
+
+	if err != nil {
+		panic(err)
+	}
+
+	err = core.NewService(cfg)
+
+	if err != nil {
+		panic(err)
+	}
+
+	defer core.Close()
+
+	//Save everything when the container is stopped, deferred calls don't run on signals.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigs
+		core.Close()
+		os.Exit(0)
+	}()
+
+	//Decoder tables are reloaded on SIGHUP
+	if len(cfg.Decoders.Tables) > 0 {
+		_, err = vdskit.LoadTables(cfg.Decoders.Tables...)
+
+		if err != nil {
+			panic(err)
+		}
+
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+
+		go func() {
+			for range hup {
+				makes, err := vdskit.Reload()
+
+				if err != nil {
+					log.Println("reload decoder tables", err)
+					continue
+				}
+
+				log.Printf("reloaded decoder tables of %s\n", strings.Join(makes, ", "))
+			}
+		}()
+	}
+
+	if len(cfg.Reference.Sync) > 0 {
+		job := refdata.NewSync(24*time.Hour, cfg.Reference.Sync...)
+		job.Start()
+		core.OnClose(job.Stop)
+
+		core.RegisterHealthEndpoint("vpic", "https://vpic.nhtsa.dot.gov/api/")
+	}
+
+	if len(cfg.Reference.Dump) > 0 {
+		report, err := refdata.ImportDump(cfg.Reference.Dump, core.ImportOptions{})
+
+		if err != nil {
+			panic(err)
+		}
+
+		log.Printf("vPIC dump: %d WMI, %d VDS, %d patterns skipped\n", len(report.WMI.Adds)+len(report.WMI.Updates), len(report.VDS.Adds)+len(report.VDS.Updates), report.Skipped)
+	}
+
+	if len(cfg.Stream.NATS) > 0 {
+		core.OnClose(stream.Attach(stream.NewNATS(cfg.Stream.NATS, cfg.Stream.Subject), 1000))
+	}
+
+	if len(cfg.Stream.Kafka) > 0 {
+		core.OnClose(stream.Attach(stream.NewKafka(cfg.Stream.Kafka, cfg.Stream.Subject), 1000))
+	}
 
 	err = droxolite.Boot(poxy)
 
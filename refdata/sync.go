@@ -0,0 +1,196 @@
+package refdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+const vpicURL = "https://vpic.nhtsa.dot.gov/api/vehicles/GetWMIsForManufacturer/%s?format=json"
+
+//WMIRecord is a single WMI assignment as published by NHTSA vPIC
+type WMIRecord struct {
+	WMI         string
+	Name        string
+	Country     string
+	VehicleType string
+}
+
+type vpicResponse struct {
+	Count   int
+	Message string
+	Results []WMIRecord
+}
+
+//Sync periodically fetches the public WMI lists of manufacturers, and stages the WMIs we don't know about yet.
+type Sync struct {
+	Manufacturers []string
+	Interval      time.Duration
+	client        *http.Client
+	stop          chan struct{}
+}
+
+//NewSync returns a job which will check the given manufacturers every interval
+func NewSync(interval time.Duration, manufacturers ...string) *Sync {
+	return &Sync{
+		Manufacturers: manufacturers,
+		Interval:      interval,
+		client:        &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+//Start runs the sync immediately, and then on every interval until Stop is called.
+func (s *Sync) Start() {
+	stop := make(chan struct{})
+	s.stop = stop
+
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			staged, err := s.Run()
+
+			if err != nil {
+				log.Println("wmi sync", err)
+			}
+
+			log.Printf("wmi sync staged %d manufacturers\n", staged)
+
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+//Stop ends the scheduled sync
+func (s *Sync) Stop() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+//Run does a single sync of every manufacturer, and returns the number of WMIs staged for review.
+//A failing manufacturer doesn't stop the others from being synced.
+func (s *Sync) Run() (int, error) {
+	staged := 0
+	var failed []string
+
+	for _, name := range s.Manufacturers {
+		records, err := s.Fetch(name)
+
+		if err != nil {
+			log.Println("fetch", name, err)
+			failed = append(failed, name)
+			continue
+		}
+
+		for _, rec := range Missing(records) {
+			_, err := core.StageManufacturer(rec.staged())
+
+			if err != nil {
+				return staged, err
+			}
+
+			staged++
+		}
+	}
+
+	if len(failed) > 0 {
+		return staged, fmt.Errorf("unable to fetch %s", strings.Join(failed, ", "))
+	}
+
+	return staged, nil
+}
+
+//Fetch returns the WMIs vPIC has assigned to the manufacturer
+func (s *Sync) Fetch(manufacturer string) ([]WMIRecord, error) {
+	resp, err := s.client.Get(fmt.Sprintf(vpicURL, url.PathEscape(manufacturer)))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vpic returned %s", resp.Status)
+	}
+
+	result := vpicResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Results, nil
+}
+
+//Missing returns the records which don't have a manufacturer in the local reference data
+func Missing(records []WMIRecord) []WMIRecord {
+	var result []WMIRecord
+
+	for _, rec := range records {
+		if len(rec.WMI) != 3 {
+			continue
+		}
+
+		_, err := core.FindManufacturer(strings.ToUpper(rec.WMI))
+
+		if err != nil {
+			result = append(result, rec)
+		}
+	}
+
+	return result
+}
+
+func (r WMIRecord) staged() core.StagedManufacturer {
+	return core.StagedManufacturer{
+		Manufacturer: core.Manufacturer{
+			WMICode:     strings.ToUpper(r.WMI),
+			Name:        r.Name,
+			Description: strings.TrimSpace(r.Name + " " + r.Country),
+			VehicleType: vehicleType(r.VehicleType),
+		},
+		Country: r.Country,
+		Source:  "vPIC",
+	}
+}
+
+//vehicleType maps the vPIC vehicle type descriptions to our VehicleType
+func vehicleType(desc string) core.VehicleType {
+	desc = strings.ToLower(desc)
+
+	switch {
+	case strings.Contains(desc, "motorcycle"):
+		return core.Motorcycle
+	case strings.Contains(desc, "bus"):
+		return core.Bus
+	case strings.Contains(desc, "truck"):
+		return core.Truck
+	case strings.Contains(desc, "mpv"):
+		return core.MPV
+	case strings.Contains(desc, "trailer"):
+		return core.Trailer
+	case strings.Contains(desc, "lsv"):
+		return core.LSV
+	case strings.Contains(desc, "off road"):
+		return core.ATV
+	case strings.Contains(desc, "incomplete"):
+		return core.Incomplete
+	}
+
+	return core.PassengerCar
+}
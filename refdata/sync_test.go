@@ -0,0 +1,95 @@
+package refdata
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+//serverTransport sends every request to the test server
+type serverTransport struct {
+	server *url.URL
+}
+
+func (t serverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = t.server.Scheme
+	req.URL.Host = t.server.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+//testSync returns a Sync which fetches from the handler, the server has to be closed
+func testSync(t *testing.T, handler http.HandlerFunc) (*Sync, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	u, err := url.Parse(server.URL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewSync(time.Hour)
+	result.client = &http.Client{Transport: serverTransport{server: u}}
+
+	return result, server
+}
+
+func TestSync_Fetch(t *testing.T) {
+	s, server := testSync(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/vehicles/GetWMIsForManufacturer/rivian automotive" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		fmt.Fprint(w, `{"Count":1,"Results":[{"WMI":"7FC","Name":"RIVIAN AUTOMOTIVE","Country":"UNITED STATES (USA)","VehicleType":"Truck"}]}`)
+	})
+	defer server.Close()
+
+	records, err := s.Fetch("rivian automotive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 1 || records[0].WMI != "7FC" || records[0].VehicleType != "Truck" {
+		t.Errorf("expected the WMI of the manufacturer, got %+v", records)
+	}
+}
+
+func TestSync_Fetch_Status(t *testing.T) {
+	s, server := testSync(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	if _, err := s.Fetch("rivian automotive"); err == nil {
+		t.Error("expected an error when vPIC is unavailable")
+	}
+}
+
+func TestWMIRecord_Staged(t *testing.T) {
+	staged := WMIRecord{WMI: "7fc", Name: "RIVIAN AUTOMOTIVE", Country: "UNITED STATES (USA)", VehicleType: "Truck "}.staged()
+
+	if staged.WMICode != "7FC" || staged.VehicleType != core.Truck || staged.Source != "vPIC" {
+		t.Errorf("unexpected staged manufacturer %+v", staged)
+	}
+}
+
+func TestVehicleType(t *testing.T) {
+	types := map[string]core.VehicleType{
+		"Motorcycle":                           core.Motorcycle,
+		"Multipurpose Passenger Vehicle (MPV)": core.MPV,
+		"Incomplete Vehicle":                   core.Incomplete,
+		"Low Speed Vehicle (LSV)":              core.LSV,
+		"Passenger Car":                        core.PassengerCar,
+	}
+
+	for desc, expected := range types {
+		if actual := vehicleType(desc); actual != expected {
+			t.Errorf("%s: expected %s, got %s", desc, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package refdata
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+//DumpReport is the result of importing a vPIC dump
+type DumpReport struct {
+	WMI     core.ImportDiff
+	VDS     core.ImportDiff
+	Skipped int //Patterns with wildcards or ranges, which can't be stored as a VDS record
+}
+
+//dumpTables are the vPIC tables the importer reads, exported as <Table>.csv with a header row.
+var dumpTables = []string{"Wmi", "Manufacturer", "VehicleType", "Country", "Wmi_VinSchema", "Pattern", "Element", "Model"}
+
+//table is a CSV export, rows are keyed by their column names
+type table []map[string]string
+
+//vpicDump holds the tables of a vPIC database export
+type vpicDump map[string]table
+
+//ImportDump converts the CSV exports of the vPIC standalone database into WMI and VDS records.
+//Restore the vPIC backup and export the tables in dumpTables to dir, eg. with bcp or SSMS.
+//Series and bodies aren't imported, vPIC doesn't group models by platform, they're matched to VDS records by their series name instead.
+func ImportDump(dir string, opts core.ImportOptions) (DumpReport, error) {
+	result := DumpReport{}
+	dump, err := readDump(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	result.WMI, err = core.ImportWMI(dump.manufacturers(), opts)
+
+	if err != nil {
+		return result, err
+	}
+
+	records, skipped := dump.vdsRecords()
+	result.Skipped = skipped
+	result.VDS, err = core.BulkUpsertVDS(records, opts)
+
+	return result, err
+}
+
+func readDump(dir string) (vpicDump, error) {
+	result := make(vpicDump)
+
+	for _, name := range dumpTables {
+		f, err := os.Open(filepath.Join(dir, name+".csv"))
+
+		if err != nil {
+			//Model is only needed when the dump has no free text Series element
+			if name == "Model" && os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		t, err := readTable(f)
+		f.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", name, err)
+		}
+
+		result[name] = t
+	}
+
+	return result, nil
+}
+
+func readTable(r io.Reader) (table, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result table
+
+	for {
+		row, err := reader.Read()
+
+		if err == io.EOF {
+			return result, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		rec := make(map[string]string)
+
+		for i, col := range header {
+			if i < len(row) {
+				rec[strings.TrimSpace(col)] = strings.TrimSpace(row[i])
+			}
+		}
+
+		result = append(result, rec)
+	}
+}
+
+//names maps the Id to the Name of every row
+func (t table) names() map[string]string {
+	result := make(map[string]string)
+
+	for _, row := range t {
+		result[row["Id"]] = row["Name"]
+	}
+
+	return result
+}
+
+//manufacturers returns a staged manufacturer for every WMI
+func (d vpicDump) manufacturers() []core.StagedManufacturer {
+	makers := d["Manufacturer"].names()
+	types := d["VehicleType"].names()
+	countries := d["Country"].names()
+
+	var result []core.StagedManufacturer
+
+	for _, row := range d["Wmi"] {
+		rec := WMIRecord{
+			WMI:         row["Wmi"],
+			Name:        makers[row["ManufacturerId"]],
+			Country:     countries[row["CountryId"]],
+			VehicleType: types[row["VehicleTypeId"]],
+		}
+
+		if len(rec.WMI) != 3 || len(rec.Name) == 0 {
+			continue
+		}
+
+		result = append(result, rec.staged())
+	}
+
+	return result
+}
+
+//vdsRecords returns a VDS record for every Series (or Model) pattern with a complete VDS.
+//Patterns with wildcards are counted as skipped.
+func (d vpicDump) vdsRecords() ([]core.VDSRecord, int) {
+	elements := make(map[string]string)
+
+	for _, row := range d["Element"] {
+		elements[row["Name"]] = row["Id"]
+	}
+
+	wmis := make(map[string]string)
+
+	for _, row := range d["Wmi"] {
+		wmis[row["Id"]] = strings.ToUpper(row["Wmi"])
+	}
+
+	type schemaWMI struct {
+		wmi      string
+		from, to int
+	}
+
+	schemas := make(map[string][]schemaWMI)
+
+	for _, row := range d["Wmi_VinSchema"] {
+		from, _ := strconv.Atoi(row["YearFrom"])
+		to, err := strconv.Atoi(row["YearTo"])
+
+		//An open ended schema is still in production
+		if err != nil || to == 0 {
+			to = 9999
+		}
+
+		schemas[row["VinSchemaId"]] = append(schemas[row["VinSchemaId"]], schemaWMI{wmi: wmis[row["WmiId"]], from: from, to: to})
+	}
+
+	element, names := elements["Series"], map[string]string(nil)
+
+	if len(element) == 0 {
+		element, names = elements["Model"], d["Model"].names()
+	}
+
+	var result []core.VDSRecord
+	skipped := 0
+	seen := make(map[string]bool)
+
+	for _, row := range d["Pattern"] {
+		if row["ElementId"] != element {
+			continue
+		}
+
+		keys := strings.ToUpper(strings.SplitN(row["Keys"], "|", 2)[0])
+
+		if len(keys) != 5 || strings.ContainsAny(keys, "*[]?-") {
+			skipped++
+			continue
+		}
+
+		spec := row["AttributeId"]
+
+		if names != nil {
+			spec = names[spec]
+		}
+
+		if len(spec) == 0 {
+			skipped++
+			continue
+		}
+
+		for _, s := range schemas[row["VinSchemaId"]] {
+			id := fmt.Sprintf("%s%s%d", s.wmi, keys, s.from)
+
+			if len(s.wmi) != 3 || seen[id] {
+				continue
+			}
+
+			seen[id] = true
+			result = append(result, core.VDSRecord{
+				WMICode:    s.wmi,
+				Code:       keys,
+				SeriesSpec: spec,
+				Market:     "US",
+				StartYear:  s.from,
+				EndYear:    s.to,
+			})
+		}
+	}
+
+	return result, skipped
+}
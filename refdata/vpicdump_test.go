@@ -0,0 +1,53 @@
+package refdata
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustTable(t *testing.T, csv string) table {
+	result, err := readTable(strings.NewReader(csv))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestVPICDump_Records(t *testing.T) {
+	dump := vpicDump{
+		"Wmi":           mustTable(t, "Id,Wmi,ManufacturerId,VehicleTypeId,CountryId\n1,5YJ,10,2,6\n2,XX,10,2,6\n"),
+		"Manufacturer":  mustTable(t, "Id,Name\n10,\"TESLA, INC.\"\n"),
+		"VehicleType":   mustTable(t, "Id,Name\n2,Passenger Car\n"),
+		"Country":       mustTable(t, "Id,Name\n6,United States (USA)\n"),
+		"Wmi_VinSchema": mustTable(t, "Id,WmiId,VinSchemaId,YearFrom,YearTo\n1,1,100,2017,\n"),
+		"Element":       mustTable(t, "Id,Name\n34,Series\n5,Body Class\n"),
+		"Pattern": mustTable(t, "Id,VinSchemaId,Keys,ElementId,AttributeId\n"+
+			"1,100,3E1EA|,34,Model 3\n"+
+			"2,100,3E*EA,34,Model 3\n"+
+			"3,100,3E1EA,5,13\n"),
+	}
+
+	makers := dump.manufacturers()
+
+	if len(makers) != 1 || makers[0].WMICode != "5YJ" || makers[0].Name != "TESLA, INC." {
+		t.Fatalf("expected only 5YJ, got %+v", makers)
+	}
+
+	records, skipped := dump.vdsRecords()
+
+	if skipped != 1 {
+		t.Errorf("expected the wildcard pattern to be skipped, got %d", skipped)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %+v", records)
+	}
+
+	rec := records[0]
+
+	if rec.WMICode != "5YJ" || rec.Code != "3E1EA" || rec.SeriesSpec != "Model 3" || rec.StartYear != 2017 || rec.EndYear != 9999 {
+		t.Errorf("unexpected record %+v", rec)
+	}
+}
@@ -0,0 +1,178 @@
+//Package regdoc maps a decoded VIN to the fields of registration documents, so forms can be filled in from a decode.
+//Fields which can't be decoded from the VIN, like the registration mark or colour, are returned empty.
+package regdoc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//Field is a field of a document, Code is the printed field code where the document has one
+type Field struct {
+	Code  string `json:",omitempty"`
+	Label string
+	Value string
+}
+
+//Document is the field layout of a registration document
+type Document struct {
+	Name   string
+	Fields []Field
+}
+
+//Mapper returns the fields of a document for the decoded VIN, in the order they're printed
+type Mapper func(v core.VIN) []Field
+
+var mappers = map[string]Mapper{
+	"eu":       EU,
+	"uk-v5c":   V5C,
+	"za-natis": NaTIS,
+}
+
+//Documents returns the names of the supported documents
+func Documents() []string {
+	var result []string
+
+	for name := range mappers {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+//Map returns the named document, filled in from the decoded VIN
+func Map(name string, v core.VIN) (Document, error) {
+	mapper, ok := mappers[strings.ToLower(name)]
+
+	if !ok {
+		return Document{}, fmt.Errorf("document %s is not supported", name)
+	}
+
+	return Document{Name: strings.ToLower(name), Fields: mapper(v)}, nil
+}
+
+//EU returns the harmonised fields of registration certificates, Directive 1999/37/EC.
+func EU(v core.VIN) []Field {
+	engine := v.Series.Platform.Engine
+
+	return []Field{
+		{"A", "Registration number", ""},
+		{"B", "Date of first registration", v.Attributes["firstRegistration"]},
+		{"D.1", "Make", v.WMInfo.Manufacturer},
+		{"D.2", "Type", v.Series.Platform.Code},
+		{"D.3", "Commercial description", v.Series.Spec},
+		{"E", "Vehicle identification number", v.Full},
+		{"J", "Vehicle category", category(v)},
+		{"K", "Type-approval number", ""},
+		{"P.1", "Capacity (cm3)", number(engine.Displacement)},
+		{"P.2", "Maximum net power (kW)", number(engine.PowerKW)},
+		{"P.3", "Type of fuel", fuel(v)},
+		{"P.5", "Engine identification number", ""},
+		{"R", "Colour", ""},
+	}
+}
+
+//V5C returns the fields of the UK registration certificate, it uses the EU codes.
+func V5C(v core.VIN) []Field {
+	result := []Field{}
+
+	for _, f := range EU(v) {
+		switch f.Code {
+		case "A":
+			f.Label = "Registration mark"
+		case "E":
+			f.Label = "VIN/Chassis/Frame No"
+		case "D.2":
+			continue
+		}
+
+		result = append(result, f)
+	}
+
+	return append(result, Field{"D.5", "Body type", bodyType(v)})
+}
+
+//NaTIS returns the fields of the South African registration certificate, which has no field codes.
+func NaTIS(v core.VIN) []Field {
+	return []Field{
+		{"", "Vehicle register number", ""},
+		{"", "Licence number", ""},
+		{"", "VIN", v.Full},
+		{"", "Engine number", ""},
+		{"", "Make", v.WMInfo.Manufacturer},
+		{"", "Series name", v.Series.Spec},
+		{"", "Vehicle category", natisCategory(v)},
+		{"", "Driven", "Self-propelled"},
+		{"", "Vehicle description", bodyType(v)},
+		{"", "Colour", ""},
+		{"", "Model year", number(v.ModelYear())},
+	}
+}
+
+//category returns the EU vehicle category of the vehicle type, heavier categories need the mass which isn't in the VIN.
+func category(v core.VIN) string {
+	switch v.WMInfo.Class() {
+	case core.PassengerCar.String(), core.MPV.String():
+		return "M1"
+	case core.Truck.String():
+		return "N"
+	case core.Motorcycle.String():
+		return "L"
+	case core.Trailer.String():
+		return "O"
+	case core.Bus.String():
+		return "M"
+	}
+
+	return ""
+}
+
+func natisCategory(v core.VIN) string {
+	switch v.WMInfo.Class() {
+	case core.PassengerCar.String(), core.MPV.String():
+		return "Light passenger mv"
+	case core.Truck.String():
+		return "Light load vehicle"
+	case core.Motorcycle.String():
+		return "Motor cycle"
+	case core.Trailer.String():
+		return "Trailer"
+	case core.Bus.String():
+		return "Bus"
+	}
+
+	return ""
+}
+
+func fuel(v core.VIN) string {
+	result := v.Series.Platform.Engine.FuelType
+
+	if len(result) == 0 && v.VDSInfo.EV != nil && v.VDSInfo.EV.Powertrain == vds.BEV {
+		return "Electricity"
+	}
+
+	return result
+}
+
+func bodyType(v core.VIN) string {
+	if len(v.VDSInfo.BodyStyle) > 0 {
+		return v.VDSInfo.BodyStyle
+	}
+
+	return v.Series.Platform.Body.Layout
+}
+
+func number(n int) string {
+	if n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,39 @@
+package regdoc
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+func TestMap(t *testing.T) {
+	v := core.VIN{Full: "WBA8E9G50GNT00001", Years: []int{2016}, WMInfo: core.WMInfo{Manufacturer: "BMW AG", VehicleType: "PassengerCar"}}
+	v.Series.Spec = "F30"
+	v.Series.Platform.Engine.Displacement = 1998
+
+	doc, err := Map("UK-V5C", v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]string)
+
+	for _, f := range doc.Fields {
+		values[f.Code] = f.Value
+	}
+
+	if values["E"] != v.Full || values["D.1"] != "BMW AG" || values["D.3"] != "F30" || values["J"] != "M1" || values["P.1"] != "1998" {
+		t.Errorf("unexpected fields %+v", doc.Fields)
+	}
+
+	if _, ok := values["D.2"]; ok {
+		t.Error("V5C doesn't print D.2")
+	}
+
+	_, err = Map("xx", v)
+
+	if err == nil {
+		t.Error("expected an error for an unknown document")
+	}
+}
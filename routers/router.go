@@ -14,7 +14,83 @@ import (
 func Setup(e resins.Epoxi) {
 	admCtrl := &controllers.Admin{}
 	regnCtrl := &controllers.Regions{}
-	e.JoinBundle("/", roletype.Admin, mix.JSON, admCtrl, regnCtrl)
+	stgCtrl := &controllers.Staging{}
+	qrnCtrl := &controllers.Quarantine{}
+	e.JoinBundle("/", roletype.Admin, mix.JSON, admCtrl, regnCtrl, stgCtrl, qrnCtrl)
 	e.JoinPath(e.Router().(*mux.Router), "/lookup/{vin}", "Find VIN", http.MethodGet, roletype.User, mix.JSON, controllers.Lookup)
+	e.JoinPath(e.Router().(*mux.Router), "/result/{vin}", "Parsed and Decoded VIN", http.MethodGet, roletype.User, mix.JSON, controllers.Result)
 	e.JoinPath(e.Router().(*mux.Router), "/validate/{vin}", "Find VIN", http.MethodGet, roletype.User, mix.JSON, controllers.Validate)
+	e.JoinPath(e.Router().(*mux.Router), "/validate", "Validate Batch", http.MethodPost, roletype.User, mix.JSON, controllers.ValidateBatch)
+	e.JoinPath(e.Router().(*mux.Router), "/decode", "Decode Batch", http.MethodPost, roletype.User, mix.JSON, controllers.DecodeBatch)
+	e.JoinPath(e.Router().(*mux.Router), "/spec", "VIN Specification", http.MethodGet, roletype.Nobody, mix.JSON, controllers.Spec)
+	e.JoinPath(e.Router().(*mux.Router), "/scan", "From Scan", http.MethodPost, roletype.User, mix.JSON, controllers.FromScan)
+	e.JoinPath(e.Router().(*mux.Router), "/rank", "Rank OCR Candidates", http.MethodPost, roletype.User, mix.JSON, controllers.RankCandidates)
+	e.JoinPath(e.Router().(*mux.Router), "/plate/{region}/{plate}", "Plate Lookup", http.MethodGet, roletype.User, mix.JSON, controllers.PlateLookup)
+	e.JoinPath(e.Router().(*mux.Router), "/value/{vin}", "Estimate Value", http.MethodGet, roletype.User, mix.JSON, controllers.EstimateValue)
+	e.JoinPath(e.Router().(*mux.Router), "/typeapproval/{vin}", "Find Type-Approvals", http.MethodGet, roletype.User, mix.JSON, controllers.TypeApprovals)
+	e.JoinPath(e.Router().(*mux.Router), "/built/{vin}", "Estimate Build Date", http.MethodGet, roletype.User, mix.JSON, controllers.BuildDate)
+	e.JoinPath(e.Router().(*mux.Router), "/warranty/{vin}", "Estimate Warranty", http.MethodGet, roletype.User, mix.JSON, controllers.Warranty)
+	e.JoinPath(e.Router().(*mux.Router), "/service/{vin}", "Service Schedule", http.MethodGet, roletype.User, mix.JSON, controllers.ServiceSchedule)
+	e.JoinPath(e.Router().(*mux.Router), "/fitment/{vin}", "Fitment Key", http.MethodGet, roletype.User, mix.JSON, controllers.Fitment)
+	e.JoinPath(e.Router().(*mux.Router), "/wheels/{vin}", "Wheel Specs", http.MethodGet, roletype.User, mix.JSON, controllers.Wheels)
+	e.JoinPath(e.Router().(*mux.Router), "/document/{doc}/{vin}", "Registration Document", http.MethodGet, roletype.User, mix.JSON, controllers.RegistrationDocument)
+	e.JoinPath(e.Router().(*mux.Router), "/recall/{vin}", "Find Recalls", http.MethodGet, roletype.User, mix.JSON, controllers.Recalls)
+	e.JoinPath(e.Router().(*mux.Router), "/compare/{vinA}/{vinB}", "Compare VINs", http.MethodGet, roletype.User, mix.JSON, controllers.Compare)
+	e.JoinPath(e.Router().(*mux.Router), "/compliance/{zone}/{vin}", "Check Emission Zone", http.MethodGet, roletype.User, mix.JSON, controllers.CheckZone)
+	e.JoinPath(e.Router().(*mux.Router), "/colour/{make}", "Paint Colours", http.MethodGet, roletype.User, mix.JSON, controllers.Colours)
+	e.JoinPath(e.Router().(*mux.Router), "/colour/{make}/{code}", "Paint Colour", http.MethodGet, roletype.User, mix.JSON, controllers.Colour)
+	e.JoinPath(e.Router().(*mux.Router), "/framecode/{frame}", "Find Frame Number", http.MethodGet, roletype.User, mix.JSON, controllers.FrameCode)
+	e.JoinPath(e.Router().(*mux.Router), "/hin/{hin}", "Hull Identification Number", http.MethodGet, roletype.User, mix.JSON, controllers.Hull)
+	e.JoinPath(e.Router().(*mux.Router), "/import/wmi", "Import WMI", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportWMI)
+	e.JoinPath(e.Router().(*mux.Router), "/import/vds", "Import VDS", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportVDS)
+	e.JoinPath(e.Router().(*mux.Router), "/import/serials", "Import Serial Ranges", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportSerials)
+	e.JoinPath(e.Router().(*mux.Router), "/import/typeapprovals", "Import Type-Approvals", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportTypeApprovals)
+	e.JoinPath(e.Router().(*mux.Router), "/import/warranty", "Import Warranty Policies", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportWarranty)
+	e.JoinPath(e.Router().(*mux.Router), "/import/service", "Import Service Intervals", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportService)
+	e.JoinPath(e.Router().(*mux.Router), "/import/wheels", "Import Wheel Specs", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportWheels)
+	e.JoinPath(e.Router().(*mux.Router), "/import/recalls", "Import Recall Ranges", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportRecalls)
+	e.JoinPath(e.Router().(*mux.Router), "/upsert/vins", "Upsert VINs", http.MethodPost, roletype.Admin, mix.JSON, controllers.UpsertVINs)
+	e.JoinPath(e.Router().(*mux.Router), "/import/vins", "Import VINs", http.MethodPost, roletype.Admin, mix.JSON, controllers.ImportVINs)
+	e.JoinPath(e.Router().(*mux.Router), "/delete", "Delete Where", http.MethodPost, roletype.Admin, mix.JSON, controllers.DeleteWhere)
+	e.JoinPath(e.Router().(*mux.Router), "/quarantine/{key}/retry", "Retry Quarantine", http.MethodPost, roletype.Admin, mix.JSON, controllers.RetryQuarantine)
+	e.JoinPath(e.Router().(*mux.Router), "/quarantine/{key}/resolve", "Resolve Quarantine", http.MethodPost, roletype.Admin, mix.JSON, controllers.ResolveQuarantine)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/manufacturers", "List Manufacturers", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListManufacturers)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/manufacturers/{wmi}", "Update Manufacturer", http.MethodPut, roletype.Admin, mix.JSON, controllers.UpdateManufacturer)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/series", "List Series", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListSeries)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/series/{wmi}/{plant}/{spec}", "Update Series", http.MethodPut, roletype.Admin, mix.JSON, controllers.UpdateSeries)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/platforms", "List Platforms", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListPlatforms)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/platforms/{wmi}/{code}", "Update Platform", http.MethodPut, roletype.Admin, mix.JSON, controllers.UpdatePlatform)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/bodies", "List Bodies", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListBodies)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/bodies/{wmi}/{platform}", "Update Body", http.MethodPut, roletype.Admin, mix.JSON, controllers.UpdateBody)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/vds", "List VDS", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListVDS)
+	e.JoinPath(e.Router().(*mux.Router), "/reference/vds/{key}", "Update VDS", http.MethodPut, roletype.Admin, mix.JSON, controllers.UpdateVDS)
+	e.JoinPath(e.Router().(*mux.Router), "/overrides", "List Overrides", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListOverrides)
+	e.JoinPath(e.Router().(*mux.Router), "/overrides/{vin}", "Get Override", http.MethodGet, roletype.Admin, mix.JSON, controllers.GetOverride)
+	e.JoinPath(e.Router().(*mux.Router), "/overrides/{vin}", "Set Override", http.MethodPut, roletype.Admin, mix.JSON, controllers.SetOverride)
+	e.JoinPath(e.Router().(*mux.Router), "/overrides/{vin}", "Remove Override", http.MethodDelete, roletype.Admin, mix.JSON, controllers.RemoveOverride)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes", "File Dispute", http.MethodPost, roletype.User, mix.JSON, controllers.FileDispute)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes", "List Disputes", http.MethodGet, roletype.Admin, mix.JSON, controllers.ListDisputes)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes/metrics", "Dispute Metrics", http.MethodGet, roletype.Admin, mix.JSON, controllers.DisputeMetrics)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes/{key}/assign", "Assign Dispute", http.MethodPost, roletype.Admin, mix.JSON, controllers.AssignDispute)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes/{key}/resolve", "Resolve Dispute", http.MethodPost, roletype.Admin, mix.JSON, controllers.ResolveDispute)
+	e.JoinPath(e.Router().(*mux.Router), "/disputes/{key}/reject", "Reject Dispute", http.MethodPost, roletype.Admin, mix.JSON, controllers.RejectDispute)
+	e.JoinPath(e.Router().(*mux.Router), "/decoders/reload", "Reload Decoder Tables", http.MethodPost, roletype.Admin, mix.JSON, controllers.ReloadDecoders)
+	e.JoinPath(e.Router().(*mux.Router), "/preflight", "Preflight Report", http.MethodGet, roletype.Admin, mix.JSON, controllers.Preflight)
+	e.JoinPath(e.Router().(*mux.Router), "/integrity", "Check Reference Integrity", http.MethodGet, roletype.Admin, mix.JSON, controllers.Integrity)
+	e.JoinPath(e.Router().(*mux.Router), "/integrity/records", "Verify Record Integrity", http.MethodGet, roletype.Admin, mix.JSON, controllers.RecordIntegrity)
+	e.JoinPath(e.Router().(*mux.Router), "/coverage", "Manufacturer Coverage", http.MethodGet, roletype.Admin, mix.JSON, controllers.Coverage)
+	e.JoinPath(e.Router().(*mux.Router), "/sync", "Sync From", http.MethodPost, roletype.Admin, mix.JSON, controllers.SyncFrom)
+	e.JoinPath(e.Router().(*mux.Router), "/sync/vins", "Sync VINs", http.MethodGet, roletype.Admin, mix.JSON, controllers.SyncVINs)
+	e.JoinPath(e.Router().(*mux.Router), "/sync/regions", "Sync Regions", http.MethodGet, roletype.Admin, mix.JSON, controllers.SyncRegions)
+	e.JoinPath(e.Router().(*mux.Router), "/sync/vds", "Sync VDS", http.MethodGet, roletype.Admin, mix.JSON, controllers.SyncVDS)
+	e.JoinPath(e.Router().(*mux.Router), "/stats", "VIN Statistics", http.MethodGet, roletype.Admin, mix.JSON, controllers.Stats)
+	e.JoinPath(e.Router().(*mux.Router), "/aggregate/{dimension}", "Aggregate VINs", http.MethodGet, roletype.Admin, mix.JSON, controllers.Aggregate)
+	e.JoinPath(e.Router().(*mux.Router), "/ingestion", "Ingestion Statistics", http.MethodGet, roletype.Admin, mix.JSON, controllers.Ingestion)
+	e.JoinPath(e.Router().(*mux.Router), "/attribute/{name}/{value}", "Find by Attribute", http.MethodGet, roletype.Admin, mix.JSON, controllers.FindByAttribute)
+	e.JoinPath(e.Router().(*mux.Router), "/stored/{vin}", "Find by VIN", http.MethodGet, roletype.Admin, mix.JSON, controllers.FindByVIN)
+	e.JoinPath(e.Router().(*mux.Router), "/usage", "Usage", http.MethodGet, roletype.User, mix.JSON, controllers.Usage)
+	e.JoinPath(e.Router().(*mux.Router), "/usage/{caller}", "Caller Usage", http.MethodGet, roletype.Admin, mix.JSON, controllers.CallerUsage)
+	e.JoinPath(e.Router().(*mux.Router), "/quota/{caller}", "Set Quota", http.MethodPost, roletype.Admin, mix.JSON, controllers.SetQuota)
+	e.JoinPath(e.Router().(*mux.Router), "/health", "Health", http.MethodGet, roletype.Nobody, mix.JSON, controllers.Health)
+	e.JoinPath(e.Router().(*mux.Router), "/graph", "Query Vehicle Graph", http.MethodPost, roletype.User, mix.JSON, controllers.Graph)
 }
@@ -0,0 +1,120 @@
+package storetest
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+//ErrUnavailable is the failure Chaos injects when it has no Err
+var ErrUnavailable = errors.New("store is unavailable")
+
+//Chaos wraps a Store and slows down or fails its lookups, to test how decodes behave when the store is degraded.
+//Creates aren't changed. Set the fields before the store is used.
+type Chaos struct {
+	core.Store
+	Latency     time.Duration //Added to every lookup
+	Jitter      time.Duration //Random latency up to Jitter, on top of Latency
+	FailureRate float64       //Share of lookups which fail, from 0 to 1
+	Err         error         //Returned by failed lookups, ErrUnavailable when it's nil
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+//NewChaos wraps the store, the seed makes the failures and jitter repeatable
+func NewChaos(s core.Store, seed int64) *Chaos {
+	return &Chaos{Store: s, rand: rand.New(rand.NewSource(seed))}
+}
+
+//lookup waits for the latency, and returns the error when the lookup has to fail
+func (c *Chaos) lookup() error {
+	c.mu.Lock()
+	delay := c.Latency
+
+	if c.Jitter > 0 {
+		delay += time.Duration(c.rand.Int63n(int64(c.Jitter)))
+	}
+
+	fail := c.rand.Float64() < c.FailureRate
+	c.mu.Unlock()
+
+	time.Sleep(delay)
+
+	if !fail {
+		return nil
+	}
+
+	if c.Err != nil {
+		return c.Err
+	}
+
+	return ErrUnavailable
+}
+
+func (c *Chaos) VINsSince(since time.Time) ([]core.VIN, error) {
+	if err := c.lookup(); err != nil {
+		return nil, err
+	}
+
+	return c.Store.VINsSince(since)
+}
+
+func (c *Chaos) Regions() ([]core.Region, error) {
+	if err := c.lookup(); err != nil {
+		return nil, err
+	}
+
+	return c.Store.Regions()
+}
+
+func (c *Chaos) VDSRecords() ([]core.VDSRecord, error) {
+	if err := c.lookup(); err != nil {
+		return nil, err
+	}
+
+	return c.Store.VDSRecords()
+}
+
+func (c *Chaos) FindVIN(fullvin string) (core.VIN, error) {
+	if err := c.lookup(); err != nil {
+		return core.VIN{}, err
+	}
+
+	return c.Store.FindVIN(fullvin)
+}
+
+func (c *Chaos) FindVINs(filter func(v core.VIN) bool, page, size int) ([]core.VIN, error) {
+	if err := c.lookup(); err != nil {
+		return nil, err
+	}
+
+	return c.Store.FindVINs(filter, page, size)
+}
+
+func (c *Chaos) FindRegion(uniquevin string) (core.Region, error) {
+	if err := c.lookup(); err != nil {
+		return core.Region{}, err
+	}
+
+	return c.Store.FindRegion(uniquevin)
+}
+
+func (c *Chaos) FindVDS(wmi, code string, years []int) (core.VDSRecord, error) {
+	if err := c.lookup(); err != nil {
+		return core.VDSRecord{}, err
+	}
+
+	return c.Store.FindVDS(wmi, code, years)
+}
+
+func (c *Chaos) FindVDSRecords(wmi, code string, years []int) ([]core.VDSRecord, error) {
+	if err := c.lookup(); err != nil {
+		return nil, err
+	}
+
+	return c.Store.FindVDSRecords(wmi, code, years)
+}
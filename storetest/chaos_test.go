@@ -0,0 +1,88 @@
+package storetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+func newReference() *Memory {
+	m := NewMemory()
+	m.AddRegion(core.Region{Name: "North America", StartChar: "1", EndChar: "5"})
+	m.AddVDS(core.VDSRecord{WMICode: "5NP", Code: "EU46F", SeriesSpec: "NF", StartYear: 2005, EndYear: 2010})
+
+	return m
+}
+
+func TestChaos_Failures(t *testing.T) {
+	c := NewChaos(newReference(), 1)
+	c.FailureRate = 1
+
+	if _, err := c.FindRegion("5NPEU46F77H"); err != ErrUnavailable {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+
+	c.Err = errors.New("disk full")
+
+	if _, err := c.FindVDS("5NP", "EU46F", []int{2007}); err != c.Err {
+		t.Errorf("expected the configured error, got %v", err)
+	}
+
+	c.FailureRate = 0
+
+	if r, err := c.FindVDS("5NP", "EU46F", []int{2007}); err != nil || r.SeriesSpec != "NF" {
+		t.Errorf("expected the record, got %v %v", r, err)
+	}
+}
+
+func TestChaos_FailureRate(t *testing.T) {
+	c := NewChaos(newReference(), 1)
+	c.FailureRate = 0.3
+	failed := 0
+
+	for i := 0; i < 1000; i++ {
+		if _, err := c.FindRegion("5NPEU46F77H"); err != nil {
+			failed++
+		}
+	}
+
+	if failed < 200 || failed > 400 {
+		t.Errorf("expected about 300 failures, got %d", failed)
+	}
+}
+
+func TestChaos_Latency(t *testing.T) {
+	c := NewChaos(newReference(), 1)
+	c.Latency = 20 * time.Millisecond
+
+	start := time.Now()
+	c.FindRegion("5NPEU46F77H")
+
+	if d := time.Since(start); d < c.Latency {
+		t.Errorf("expected the lookup to take %s, took %s", c.Latency, d)
+	}
+}
+
+func TestMemory_Lookups(t *testing.T) {
+	m := newReference()
+
+	if r, err := m.FindRegion("5NPEU46F77H"); err != nil || r.Name != "North America" {
+		t.Errorf("expected North America, got %v %v", r, err)
+	}
+
+	if _, err := m.FindRegion("ZNPEU46F77H"); err != core.ErrReferenceNotFound {
+		t.Errorf("expected ErrReferenceNotFound, got %v", err)
+	}
+
+	if _, err := m.FindVDS("5NP", "EU46F", []int{2015}); err != core.ErrReferenceNotFound {
+		t.Errorf("expected ErrReferenceNotFound, got %v", err)
+	}
+
+	m.AddVDS(core.VDSRecord{WMICode: "5NP", Code: "EU46F", SeriesSpec: "NF-US", Market: "United States", StartYear: 2006, EndYear: 2008})
+
+	if recs, err := m.FindVDSRecords("5NP", "EU46F", []int{2007}); err != nil || len(recs) != 2 {
+		t.Errorf("expected both records, got %v %v", recs, err)
+	}
+}
@@ -0,0 +1,271 @@
+package storetest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//RunConformance verifies the Store has the same create, dedupe, filter and pagination semantics as the husk store,
+//so backends stay interchangeable. newStore is called for every test, the VINs it creates are tagged with their own
+//Source so a store which already has VINs can be tested.
+func RunConformance(t *testing.T, newStore func() core.Store) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, s core.Store, source string)
+	}{
+		{"CreateAndFind", conformCreate},
+		{"Dedupe", conformDedupe},
+		{"ConcurrentCreate", conformConcurrentCreate},
+		{"NotFound", conformNotFound},
+		{"Filter", conformFilter},
+		{"Pagination", conformPagination},
+		{"Since", conformSince},
+	}
+
+	for _, tc := range tests {
+		source := fmt.Sprintf("conformance-%s-%d", tc.name, time.Now().UnixNano())
+		test := tc.test
+
+		t.Run(tc.name, func(t *testing.T) {
+			test(t, newStore(), source)
+		})
+	}
+}
+
+//conformVIN returns a valid VIN with the serial, from the source
+func conformVIN(source string, serial int) core.VIN {
+	full := fmt.Sprintf("5NPEU46F_7H%06d", serial)
+	full = full[:8] + vinmath.CheckDigit(full) + full[9:]
+	unique, s := vinmath.UniqueSerial(full)
+
+	return core.VIN{Full: full, Unique: unique, Serial: s, Source: source}
+}
+
+//serialBase keeps the VINs of different runs apart, in stores which aren't empty
+func serialBase(source string) int {
+	h := fnv.New32a()
+	h.Write([]byte(source))
+
+	return int(h.Sum32() % 900000)
+}
+
+func create(t *testing.T, s core.Store, vins ...core.VIN) {
+	for _, v := range vins {
+		if _, err := s.CreateVIN(v); err != nil {
+			t.Fatalf("create %s: %s", v.Full, err)
+		}
+	}
+}
+
+func bySource(source string) func(v core.VIN) bool {
+	return func(v core.VIN) bool {
+		return v.Source == source
+	}
+}
+
+func conformCreate(t *testing.T, s core.Store, source string) {
+	v := conformVIN(source, serialBase(source))
+	created, err := s.CreateVIN(v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created.Full != v.Full || created.CreatedAt.IsZero() {
+		t.Errorf("expected the created VIN with its CreatedAt, got %+v", created)
+	}
+
+	found, err := s.FindVIN(" " + v.Full + " ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if found.Full != v.Full || found.Source != source || found.Serial != v.Serial {
+		t.Errorf("expected %s from %s, got %+v", v.Full, source, found)
+	}
+
+	if _, err := s.CreateVIN(core.VIN{Full: "TOO SHORT", Source: source}); err == nil {
+		t.Error("expected an invalid VIN to be rejected")
+	}
+}
+
+func conformDedupe(t *testing.T, s core.Store, source string) {
+	v := conformVIN(source, serialBase(source))
+	first, err := s.CreateVIN(v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v.Attributes = map[string]string{"colour": "red"}
+	second, err := s.CreateVIN(v)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !second.CreatedAt.Equal(first.CreatedAt) || len(second.Attributes) > 0 {
+		t.Errorf("expected the stored VIN to be returned, got %+v", second)
+	}
+
+	vins, err := s.FindVINs(func(o core.VIN) bool { return o.Full == v.Full }, 1, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vins) != 1 {
+		t.Errorf("expected the VIN to be stored once, got %d", len(vins))
+	}
+}
+
+func conformConcurrentCreate(t *testing.T, s core.Store, source string) {
+	v := conformVIN(source, serialBase(source))
+	created := make([]core.VIN, 8)
+	errs := make([]error, len(created))
+	var wg sync.WaitGroup
+
+	for i := range created {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			created[i], errs[i] = s.CreateVIN(v)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !created[i].CreatedAt.Equal(created[0].CreatedAt) {
+			t.Errorf("expected every worker to get the same VIN, got %s and %s", created[i].CreatedAt, created[0].CreatedAt)
+		}
+	}
+
+	vins, err := s.FindVINs(func(o core.VIN) bool { return o.Full == v.Full }, 1, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vins) != 1 {
+		t.Errorf("expected the VIN to be stored once, got %d", len(vins))
+	}
+}
+
+func conformNotFound(t *testing.T, s core.Store, source string) {
+	if _, err := s.FindVIN(conformVIN(source, serialBase(source)).Full); err != core.ErrVINNotFound {
+		t.Errorf("expected ErrVINNotFound, got %v", err)
+	}
+}
+
+func conformFilter(t *testing.T, s core.Store, source string) {
+	base := serialBase(source)
+	other := source + "-other"
+	create(t, s, conformVIN(source, base), conformVIN(other, base+1), conformVIN(source, base+2))
+
+	vins, err := s.FindVINs(bySource(source), 1, 10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vins) != 2 {
+		t.Fatalf("expected 2 VINs from %s, got %d", source, len(vins))
+	}
+
+	for _, v := range vins {
+		if v.Source != source {
+			t.Errorf("filter returned %s from %s", v.Full, v.Source)
+		}
+	}
+}
+
+func conformPagination(t *testing.T, s core.Store, source string) {
+	base := serialBase(source)
+	var expected []string
+
+	for i := 0; i < 5; i++ {
+		v := conformVIN(source, base+i)
+		create(t, s, v)
+		expected = append(expected, v.Full)
+	}
+
+	var actual []string
+
+	for page, size := range []int{2, 2, 1, 0} {
+		vins, err := s.FindVINs(bySource(source), page+1, 2)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(vins) != size {
+			t.Fatalf("page %d: expected %d VINs, got %d", page+1, size, len(vins))
+		}
+
+		for _, v := range vins {
+			actual = append(actual, v.Full)
+		}
+	}
+
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("expected the VINs in the order they were stored, %v, got %v", expected, actual)
+			break
+		}
+	}
+
+	for _, page := range [][2]int{{0, 2}, {1, 0}} {
+		vins, err := s.FindVINs(bySource(source), page[0], page[1])
+
+		if err != nil || len(vins) != 0 {
+			t.Errorf("page %d of %d: expected nothing, got %d %v", page[0], page[1], len(vins), err)
+		}
+	}
+}
+
+func conformSince(t *testing.T, s core.Store, source string) {
+	base := serialBase(source)
+	create(t, s, conformVIN(source, base))
+
+	since := time.Now()
+	time.Sleep(5 * time.Millisecond)
+	later := conformVIN(source, base+1)
+	create(t, s, later)
+
+	vins, err := s.VINsSince(since)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := 0
+
+	for _, v := range vins {
+		if v.Source != source {
+			continue
+		}
+
+		found++
+
+		if v.Full != later.Full {
+			t.Errorf("expected only VINs created after %s, got %s", since, v.Full)
+		}
+	}
+
+	if found != 1 {
+		t.Errorf("expected 1 VIN since %s, got %d", since, found)
+	}
+}
@@ -0,0 +1,16 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+func TestMemory_Conformance(t *testing.T) {
+	RunConformance(t, func() core.Store { return NewMemory() })
+}
+
+func TestChaos_Conformance(t *testing.T) {
+	//Chaos without failures has to behave like the store it wraps
+	RunConformance(t, func() core.Store { return NewChaos(NewMemory(), 1) })
+}
@@ -0,0 +1,199 @@
+//Package storetest has Stores for tests, an in-memory fake and a Chaos wrapper which degrades another Store,
+//and RunConformance to verify a Store implementation.
+package storetest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+//Memory is a Store which keeps everything in memory, it's empty until regions and VDS records are added.
+type Memory struct {
+	mu      sync.RWMutex
+	vins    []core.VIN
+	regions []core.Region
+	vds     []core.VDSRecord
+}
+
+//NewMemory returns an empty Memory store
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+//AddRegion adds reference data to the store
+func (m *Memory) AddRegion(r core.Region) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.regions = append(m.regions, r)
+}
+
+//AddVDS adds reference data to the store
+func (m *Memory) AddVDS(r core.VDSRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.vds = append(m.vds, r)
+}
+
+func (m *Memory) VINsSince(since time.Time) ([]core.VIN, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []core.VIN
+
+	for _, v := range m.vins {
+		if v.CreatedAt.After(since) || v.UpdatedAt.After(since) {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Memory) Regions() ([]core.Region, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]core.Region(nil), m.regions...), nil
+}
+
+func (m *Memory) VDSRecords() ([]core.VDSRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]core.VDSRecord(nil), m.vds...), nil
+}
+
+func (m *Memory) FindVIN(fullvin string) (core.VIN, error) {
+	fullvin = vinmath.Canonical(fullvin)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, v := range m.vins {
+		if v.Full == fullvin {
+			return v, nil
+		}
+	}
+
+	return core.VIN{}, core.ErrVINNotFound
+}
+
+func (m *Memory) CreateVIN(v core.VIN) (core.VIN, error) {
+	if len(v.Full) != 17 {
+		return core.VIN{}, fmt.Errorf("%s is not 17 characters", v.Full)
+	}
+
+	if ok, err := v.Valid(); !ok {
+		return core.VIN{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, stored := range m.vins {
+		if stored.Full == v.Full {
+			return stored, nil
+		}
+	}
+
+	v.CreatedAt = time.Now()
+	v.UpdatedAt = v.CreatedAt
+	m.vins = append(m.vins, v)
+
+	return v, nil
+}
+
+func (m *Memory) FindVINs(filter func(v core.VIN) bool, page, size int) ([]core.VIN, error) {
+	if page < 1 || size < 1 {
+		return nil, nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []core.VIN
+	skip := (page - 1) * size
+
+	for _, v := range m.vins {
+		if filter != nil && !filter(v) {
+			continue
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		result = append(result, v)
+
+		if len(result) == size {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Memory) FindRegion(uniquevin string) (core.Region, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.regions {
+		if len(uniquevin) > 0 && r.HasCode(uniquevin[:1]) {
+			return r, nil
+		}
+	}
+
+	return core.Region{}, core.ErrReferenceNotFound
+}
+
+func (m *Memory) FindVDS(wmi, code string, years []int) (core.VDSRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, r := range m.vds {
+		if r.WMICode != wmi || r.Code != code {
+			continue
+		}
+
+		for _, y := range years {
+			if r.StartYear <= y && y <= r.EndYear {
+				return r, nil
+			}
+		}
+	}
+
+	return core.VDSRecord{}, core.ErrReferenceNotFound
+}
+
+func (m *Memory) FindVDSRecords(wmi, code string, years []int) ([]core.VDSRecord, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []core.VDSRecord
+
+	for _, r := range m.vds {
+		if r.WMICode != wmi || r.Code != code {
+			continue
+		}
+
+		for _, y := range years {
+			if r.StartYear <= y && y <= r.EndYear {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, core.ErrReferenceNotFound
+	}
+
+	return result, nil
+}
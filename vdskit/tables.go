@@ -0,0 +1,122 @@
+package vdskit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+var (
+	tableFiles []string
+	tableLock  sync.Mutex
+)
+
+//LoadTables registers the LayoutDecoders in the JSON files, every file is a list of them.
+//The files are remembered, so corrected tables can be loaded with Reload without a redeploy.
+func LoadTables(files ...string) ([]string, error) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	makes, err := loadTables(files)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tableFiles = append(tableFiles, files...)
+
+	return makes, nil
+}
+
+//Reload loads the files of LoadTables again, and returns the makes which were registered.
+//Nothing changes when a file can't be read, makes removed from a file keep their decoder until a restart.
+func Reload() ([]string, error) {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+
+	return loadTables(tableFiles)
+}
+
+//loadTables reads every file before the decoders are registered, so a broken file doesn't leave half the tables loaded
+func loadTables(files []string) ([]string, error) {
+	var decoders []LayoutDecoder
+
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var items []LayoutDecoder
+		err = json.Unmarshal(data, &items)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f, err)
+		}
+
+		for _, d := range items {
+			err = d.check()
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", f, err)
+			}
+		}
+
+		decoders = append(decoders, items...)
+	}
+
+	var makes []string
+
+	for _, d := range decoders {
+		err := Register(d)
+
+		if err != nil {
+			return makes, err
+		}
+
+		makes = append(makes, d.Manufacturer)
+	}
+
+	return makes, nil
+}
+
+//check returns an error when a field of the layout isn't in the VDS
+func (d LayoutDecoder) check() error {
+	if len(d.Manufacturer) == 0 {
+		return errors.New("decoder has no make")
+	}
+
+	for _, f := range d.Layout {
+		if _, err := f.code("AAAAA"); err != nil {
+			return fmt.Errorf("%s: %s", d.Manufacturer, err)
+		}
+
+		for code, v := range f.Codes {
+			switch f.Name {
+			case FieldSteering:
+				if v != vds.LHD && v != vds.RHD {
+					return fmt.Errorf("%s: steering %s of code %s is not %s or %s", d.Manufacturer, v, code, vds.LHD, vds.RHD)
+				}
+			case FieldGVWRClass:
+				if _, err := vds.ParseTruckInfo(v, ""); err != nil {
+					return fmt.Errorf("%s: code %s: %s", d.Manufacturer, code, err)
+				}
+			case FieldBrakes:
+				if _, err := vds.ParseTruckInfo("1A", v); err != nil {
+					return fmt.Errorf("%s: code %s: %s", d.Manufacturer, code, err)
+				}
+			case FieldDeck:
+				if _, err := vds.ParseBusInfo("", v); err != nil {
+					return fmt.Errorf("%s: code %s: %s", d.Manufacturer, code, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
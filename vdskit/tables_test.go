@@ -0,0 +1,70 @@
+package vdskit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestLoadTables_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vdskit")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "tables.json")
+	write := func(body string) {
+		err := ioutil.WriteFile(file, []byte(`[{"Manufacturer": "Reloaded Motors", "Layout": [{"Name": "BodyStyle", "Position": 4, "Codes": {"A": "`+body+`"}}]}]`), 0644)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("Sedan")
+	makes, err := LoadTables(file)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(makes) != 1 || makes[0] != "Reloaded Motors" {
+		t.Fatalf("unexpected makes %v", makes)
+	}
+
+	info, err := vds.FindVDSInfo("Reloaded Motors", "XXXAAAAAAAA", nil)
+
+	if err != nil || info.BodyStyle != "Sedan" {
+		t.Fatalf("expected a sedan, got %v %v", info, err)
+	}
+
+	write("Hatchback")
+
+	if _, err := Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	info, _ = vds.FindVDSInfo("Reloaded Motors", "XXXAAAAAAAA", nil)
+
+	if info.BodyStyle != "Hatchback" {
+		t.Errorf("expected the reloaded table, got %s", info.BodyStyle)
+	}
+
+	ioutil.WriteFile(file, []byte(`[{"Manufacturer": "Reloaded Motors", "Layout": [{"Name": "Plant", "Position": 11}]}]`), 0644)
+
+	if _, err := Reload(); err == nil {
+		t.Error("expected a field outside the VDS to fail")
+	}
+
+	info, _ = vds.FindVDSInfo("Reloaded Motors", "XXXAAAAAAAA", nil)
+
+	if info.BodyStyle != "Hatchback" {
+		t.Errorf("expected the broken table to be ignored, got %s", info.BodyStyle)
+	}
+}
@@ -0,0 +1,140 @@
+//Package vdskit is for writing manufacturer decoders outside of core.
+//A Decoder decodes the VDS of a manufacturer, it's registered with Register and used by every decode after that.
+//Most decoders only look up codes at fixed positions, which a Layout does without any code.
+package vdskit
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//Decoder decodes the Vehicle Descriptor Section (positions 4-8) of a manufacturer's VINs
+type Decoder interface {
+	//Make is the manufacturer, names which start with it use the decoder as well, ie. 'Toyota' for 'Toyota Canada'
+	Make() string
+	//Decode fills in the VDSInfo and returns the manufacturer's own details of the VDS
+	Decode(code string, obj *vds.VDSInfo) (interface{}, error)
+}
+
+//Register adds the decoder, it replaces the decoder already registered for the manufacturer.
+func Register(d Decoder) error {
+	if len(d.Make()) == 0 {
+		return errors.New("decoder has no make")
+	}
+
+	vds.Register(d.Make(), d.Decode)
+
+	return nil
+}
+
+//Field is a code at a fixed position of the VIN
+type Field struct {
+	Name     string
+	Position int //1-based position in the VIN, 4-8 for the VDS
+	Length   int //Characters in the code, one when it's zero
+	Codes    map[string]string
+}
+
+//code returns the characters of the field in the VDS
+func (f Field) code(vdsCode string) (string, error) {
+	length := f.Length
+
+	if length == 0 {
+		length = 1
+	}
+
+	start := f.Position - 4
+
+	if start < 0 || start+length > len(vdsCode) {
+		return "", fmt.Errorf("%s at position %d isn't part of the VDS", f.Name, f.Position)
+	}
+
+	return vdsCode[start : start+length], nil
+}
+
+//Layout is the fields of a manufacturer's VDS
+type Layout []Field
+
+//Parse returns the meaning of every field, by name. Fields with an unknown code are left out.
+func (l Layout) Parse(vdsCode string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, f := range l {
+		code, err := f.code(vdsCode)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if v, ok := f.Codes[code]; ok {
+			result[f.Name] = v
+		}
+	}
+
+	return result, nil
+}
+
+//Field names which are copied to the VDSInfo by a LayoutDecoder
+const (
+	FieldBodyStyle = "BodyStyle"
+	FieldChassis   = "Chassis"
+	FieldPlant     = "Plant"
+	FieldSteering  = "SteeringPosition" //Codes must decode to LHD or RHD
+	FieldGVWRClass = "GVWRClass"        //Codes must decode to a class of 49 CFR 565.15, ie. 2G
+	FieldBrakes    = "Brakes"           //Codes must decode to Hydraulic, Air or Electric
+	FieldSeating   = "Seating"          //Seating capacity class of buses, ie. 23-45 seats
+	FieldDeck      = "Deck"             //Codes must decode to Single or Double
+)
+
+//LayoutDecoder is a Decoder of a manufacturer which only uses fixed position codes.
+//The result is the parsed fields, BodyStyle, Chassis, Plant and SteeringPosition are set on the VDSInfo as well.
+//A GVWRClass sets the Truck with the Brakes, Seating or Deck set the Bus.
+type LayoutDecoder struct {
+	Manufacturer string
+	Layout       Layout
+	Plants       map[string]string //Plants by the code in position 11, optional
+}
+
+func (d LayoutDecoder) Make() string {
+	return d.Manufacturer
+}
+
+func (d LayoutDecoder) Decode(code string, obj *vds.VDSInfo) (interface{}, error) {
+	fields, err := d.Layout.Parse(code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if plant, ok := d.Plants[obj.PlantCode]; ok {
+		fields[FieldPlant] = plant
+	}
+
+	obj.BodyStyle = fields[FieldBodyStyle]
+	obj.Chassis = fields[FieldChassis]
+	obj.Plant = fields[FieldPlant]
+	obj.SteeringPosition = fields[FieldSteering]
+
+	if class, ok := fields[FieldGVWRClass]; ok {
+		obj.Truck, err = vds.ParseTruckInfo(class, fields[FieldBrakes])
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seating, sok := fields[FieldSeating]
+	deck, dok := fields[FieldDeck]
+
+	if sok || dok {
+		obj.Bus, err = vds.ParseBusInfo(seating, deck)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
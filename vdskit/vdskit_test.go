@@ -0,0 +1,120 @@
+package vdskit
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestLayout_Parse(t *testing.T) {
+	l := Layout{
+		{Name: FieldBodyStyle, Position: 4, Codes: map[string]string{"A": "Sedan"}},
+		{Name: FieldChassis, Position: 5, Length: 2, Codes: map[string]string{"W2": "W205"}},
+		{Name: "Engine", Position: 8, Codes: map[string]string{"Z": "2.0 Turbo"}},
+	}
+
+	fields, err := l.Parse("AW2BC")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != 2 || fields[FieldBodyStyle] != "Sedan" || fields[FieldChassis] != "W205" {
+		t.Errorf("unexpected fields %v", fields)
+	}
+
+	if _, err := (Layout{{Name: "Plant", Position: 11}}).Parse("AW2BC"); err == nil {
+		t.Error("expected position 11 to be outside the VDS")
+	}
+}
+
+func TestLayoutDecoder_Decode(t *testing.T) {
+	d := LayoutDecoder{
+		Manufacturer: "Example",
+		Layout: Layout{
+			{Name: FieldBodyStyle, Position: 4, Codes: map[string]string{"A": "Sedan"}},
+			{Name: FieldSteering, Position: 5, Codes: map[string]string{"1": vds.LHD, "2": vds.RHD}},
+		},
+		Plants: map[string]string{"1": "Rosslyn"},
+	}
+
+	info := vds.VDSInfo{Code: "A1234", PlantCode: "1"}
+	_, err := d.Decode(info.Code, &info)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.BodyStyle != "Sedan" || info.Plant != "Rosslyn" || info.SteeringPosition != vds.LHD {
+		t.Errorf("unexpected info %+v", info)
+	}
+}
+
+func TestLayoutDecoder_CheckSteering(t *testing.T) {
+	d := LayoutDecoder{
+		Manufacturer: "Example",
+		Layout:       Layout{{Name: FieldSteering, Position: 5, Codes: map[string]string{"1": "Left"}}},
+	}
+
+	if err := d.check(); err == nil {
+		t.Error("expected steering which isn't LHD or RHD to fail")
+	}
+}
+
+func TestLayoutDecoder_Truck(t *testing.T) {
+	d := LayoutDecoder{
+		Manufacturer: "Example Trucks",
+		Layout: Layout{
+			{Name: FieldGVWRClass, Position: 4, Codes: map[string]string{"G": "2G", "8": "8"}},
+			{Name: FieldBrakes, Position: 5, Codes: map[string]string{"A": vds.AirBrakes, "H": vds.HydraulicBrakes}},
+		},
+	}
+
+	if err := d.check(); err != nil {
+		t.Fatal(err)
+	}
+
+	info := vds.VDSInfo{Code: "8A123"}
+	_, err := d.Decode(info.Code, &info)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Truck == nil || info.Truck.GVWRClass != "8" || info.Truck.Brakes != vds.AirBrakes {
+		t.Errorf("unexpected truck %+v", info.Truck)
+	}
+
+	d.Layout[0].Codes["X"] = "9"
+
+	if err := d.check(); err == nil {
+		t.Error("expected an unknown GVWR class to fail")
+	}
+}
+
+func TestLayoutDecoder_Bus(t *testing.T) {
+	d := LayoutDecoder{
+		Manufacturer: "Example Coaches",
+		Layout: Layout{
+			{Name: FieldSeating, Position: 4, Codes: map[string]string{"3": "23-45 seats"}},
+			{Name: FieldDeck, Position: 5, Codes: map[string]string{"S": vds.SingleDeck, "D": vds.DoubleDeck}},
+		},
+	}
+
+	info := vds.VDSInfo{Code: "3D123"}
+	_, err := d.Decode(info.Code, &info)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Bus == nil || info.Bus.Seating != "23-45 seats" || info.Bus.BodyStyle() != "Double Deck Bus" {
+		t.Errorf("unexpected bus %+v", info.Bus)
+	}
+
+	d.Layout[1].Codes["T"] = "Triple"
+
+	if err := d.check(); err == nil {
+		t.Error("expected an unknown deck to fail")
+	}
+}
@@ -0,0 +1,146 @@
+//Package vdskittest checks decoders written with vdskit, against golden files of decoded VINs.
+package vdskittest
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/louisevanderlith/vin/core/vds"
+	"github.com/louisevanderlith/vin/vdskit"
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the current results")
+
+//Case is a decoded VIN in a golden file
+type Case struct {
+	VIN    string
+	Info   vds.VDSInfo
+	Result json.RawMessage
+}
+
+//Run decodes every VIN in the golden file, a JSON list of Cases, and fails when a result changed.
+//Run the tests with -update to write the current results to the file, after checking them.
+//The decoder also has to pass Conformance.
+func Run(t *testing.T, d vdskit.Decoder, golden string) {
+	Conformance(t, d)
+
+	data, err := ioutil.ReadFile(golden)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []Case
+	err = json.Unmarshal(data, &cases)
+
+	if err != nil {
+		t.Fatalf("%s: %s", golden, err)
+	}
+
+	for i, c := range cases {
+		actual, err := decode(d, c.VIN)
+
+		if err != nil {
+			t.Errorf("%s: %s", c.VIN, err)
+			continue
+		}
+
+		if *update {
+			cases[i] = actual
+			continue
+		}
+
+		if !reflect.DeepEqual(actual.Info, c.Info) {
+			t.Errorf("%s: expected info %+v, got %+v", c.VIN, c.Info, actual.Info)
+		}
+
+		if !jsonEqual(actual.Result, c.Result) {
+			t.Errorf("%s: expected result %s, got %s", c.VIN, c.Result, actual.Result)
+		}
+	}
+
+	if !*update {
+		return
+	}
+
+	data, err = json.MarshalIndent(cases, "", "  ")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(golden, append(data, '\n'), 0644)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+//Conformance checks the decoder has a make and doesn't panic or change the VDS code, for any legal character in any position.
+func Conformance(t *testing.T, d vdskit.Decoder) {
+	if len(d.Make()) == 0 {
+		t.Fatal("decoder has no make")
+	}
+
+	for k := 0; k < 5; k++ {
+		for _, c := range vinmath.LegalChars {
+			code := []byte("AAAAA")
+			code[k] = byte(c)
+
+			info := vds.VDSInfo{Code: string(code), PlantCode: "A"}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("%s panics on %s: %v", d.Make(), code, r)
+					}
+				}()
+
+				d.Decode(info.Code, &info)
+			}()
+
+			if info.Code != string(code) {
+				t.Errorf("%s changed the VDS code %s to %s", d.Make(), code, info.Code)
+			}
+		}
+	}
+}
+
+//decode runs the decoder the way core does
+func decode(d vdskit.Decoder, fullvin string) (Case, error) {
+	fullvin = vinmath.Canonical(fullvin)
+	err := vinmath.Validate(fullvin)
+
+	if err != nil {
+		return Case{}, err
+	}
+
+	info := vds.VDSInfo{Code: fullvin[3:8], PlantCode: fullvin[10:11]}
+	result, err := d.Decode(info.Code, &info)
+
+	if err != nil {
+		return Case{}, err
+	}
+
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		return Case{}, err
+	}
+
+	return Case{VIN: fullvin, Info: info, Result: data}, nil
+}
+
+//jsonEqual compares the JSON values, regardless of formatting
+func jsonEqual(a, b json.RawMessage) bool {
+	var va, vb interface{}
+
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,27 @@
+package vdskittest
+
+import (
+	"testing"
+
+	"github.com/louisevanderlith/vin/vdskit"
+)
+
+var volvo = vdskit.LayoutDecoder{
+	Manufacturer: "Volvo",
+	Layout: vdskit.Layout{
+		{Name: "Model", Position: 4, Codes: map[string]string{
+			"G": "S70, V70 BI-Fuel",
+			"L": "S70, V70, V70XC",
+			"N": "C70",
+			"S": "V70, V70XC, CX70",
+		}},
+	},
+	Plants: map[string]string{
+		"1": "Torslanda",
+		"2": "Ghent",
+	},
+}
+
+func TestRun_Golden(t *testing.T) {
+	Run(t, volvo, "testdata/volvo.json")
+}
@@ -0,0 +1,129 @@
+package vinmath
+
+//Rules a VIN can fail
+const (
+	RuleLength     = "length"
+	RuleCharacter  = "character"
+	RuleYearCode   = "yearcode"
+	RuleCheckDigit = "checkdigit"
+)
+
+//ValidationResult is the diagnosis of a single VIN
+type ValidationResult struct {
+	VIN        string
+	Valid      bool
+	Rule       string `json:",omitempty"` //The rule which failed
+	Position   int    `json:",omitempty"` //1-based position of the character which failed the rule
+	Suggestion string `json:",omitempty"` //A corrected VIN which is valid, when one could be found
+}
+
+//ValidateBatch diagnoses every VIN. The results are in the same order as the VINs.
+func ValidateBatch(vins []string) []ValidationResult {
+	result := make([]ValidationResult, len(vins))
+	buf := make([]byte, 0, 17)
+
+	for i, v := range vins {
+		result[i] = diagnose(v, buf)
+	}
+
+	return result
+}
+
+//diagnose finds the first rule the VIN fails. buf is reused between calls to build suggestions.
+func diagnose(fullvin string, buf []byte) ValidationResult {
+	result := ValidationResult{VIN: fullvin}
+	fullvin = Canonical(fullvin)
+
+	if len(fullvin) != 17 {
+		result.Rule = RuleLength
+		result.Suggestion = normalize(fullvin, buf)
+
+		return result
+	}
+
+	for k := 0; k < len(fullvin); k++ {
+		if !isVINChar(fullvin[k]) {
+			result.Rule = RuleCharacter
+			result.Position = k + 1
+			result.Suggestion = normalize(fullvin, buf)
+
+			return result
+		}
+	}
+
+	if !isYearChar(fullvin[9]) {
+		result.Rule = RuleYearCode
+		result.Position = 10
+
+		return result
+	}
+
+	check := CheckDigit(fullvin)
+
+	if fullvin[8:9] != check {
+		result.Rule = RuleCheckDigit
+		result.Position = 9
+		result.Suggestion = fullvin[:8] + check + fullvin[9:]
+
+		return result
+	}
+
+	result.Valid = true
+
+	return result
+}
+
+//isVINChar returns true for the characters allowed in a VIN
+func isVINChar(c byte) bool {
+	if c >= '0' && c <= '9' {
+		return true
+	}
+
+	return c >= 'A' && c <= 'Z' && c != 'I' && c != 'O' && c != 'Q'
+}
+
+//isYearChar returns true for the characters allowed in position 10, see YearChars
+func isYearChar(c byte) bool {
+	return isVINChar(c) && c != 'U' && c != 'Z' && c != '0'
+}
+
+//normalize returns the VIN in upper case, without separators and with I, O and Q replaced by the digits they're mistaken for.
+//Nothing is returned when the result still isn't valid.
+func normalize(fullvin string, buf []byte) string {
+	buf = buf[:0]
+
+	for k := 0; k < len(fullvin); k++ {
+		c := fullvin[k]
+
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+
+		switch c {
+		case ' ', '-', '.':
+			continue
+		case 'I':
+			c = '1'
+		case 'O', 'Q':
+			c = '0'
+		}
+
+		if !isVINChar(c) || len(buf) == 17 {
+			return ""
+		}
+
+		buf = append(buf, c)
+	}
+
+	if len(buf) != 17 {
+		return ""
+	}
+
+	result := string(buf)
+
+	if !isYearChar(result[9]) || result[8:9] != CheckDigit(result) {
+		return ""
+	}
+
+	return result
+}
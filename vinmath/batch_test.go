@@ -0,0 +1,58 @@
+package vinmath
+
+import "testing"
+
+func TestValidateBatch_Diagnostics(t *testing.T) {
+	in := []string{
+		"5NPEU46F77H259112",
+		"5NBEU46F77H259112",
+		"5npeu46f77h259112",
+		"5NPEU46F77H2591",
+		"5NPEU46F77H259II2",
+		"1M8GDM9A30P042788",
+	}
+
+	out := ValidateBatch(in)
+
+	if len(out) != len(in) {
+		t.Fatalf("expected %d results, got %d", len(in), len(out))
+	}
+
+	if !out[0].Valid {
+		t.Errorf("expected %s to be valid, got %+v", in[0], out[0])
+	}
+
+	if out[1].Rule != RuleCheckDigit || out[1].Position != 9 {
+		t.Errorf("expected check digit at 9, got %+v", out[1])
+	}
+
+	if !out[2].Valid {
+		t.Errorf("expected lower case %s to be valid, got %+v", in[2], out[2])
+	}
+
+	if out[3].Rule != RuleLength {
+		t.Errorf("expected length, got %+v", out[3])
+	}
+
+	if out[4].Rule != RuleCharacter || out[4].Position != 15 || out[4].Suggestion != "5NPEU46F77H259112" {
+		t.Errorf("expected I at 15, got %+v", out[4])
+	}
+
+	if out[5].Rule != RuleYearCode || out[5].Position != 10 {
+		t.Errorf("expected the year code at 10, got %+v", out[5])
+	}
+}
+
+func BenchmarkValidateBatch(b *testing.B) {
+	in := []string{
+		"5NPEU46F77H259112",
+		"5NBEU46F77H259112",
+		"WAUZZZ8E88A025765",
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ValidateBatch(in)
+	}
+}
@@ -0,0 +1,71 @@
+package vinmath
+
+import (
+	"fmt"
+	"strings"
+)
+
+//CharPolicy is what validation does with I, O and Q, which aren't allowed in a VIN
+type CharPolicy int
+
+const (
+	//RejectIllegal fails the validation
+	RejectIllegal CharPolicy = iota
+	//SubstituteIllegal replaces I with 1, and O and Q with 0, before the VIN is validated
+	SubstituteIllegal
+	//AcceptIllegal allows them in legacy identifiers, which are only checked for their length and characters
+	AcceptIllegal
+)
+
+var charPolicyNames = [...]string{
+	"reject",
+	"substitute",
+	"accept"}
+
+func (p CharPolicy) String() string {
+	return charPolicyNames[p]
+}
+
+//ParseCharPolicy returns the CharPolicy for the name, an empty name is RejectIllegal.
+func ParseCharPolicy(name string) (CharPolicy, error) {
+	if len(name) == 0 {
+		return RejectIllegal, nil
+	}
+
+	for i, v := range charPolicyNames {
+		if strings.EqualFold(v, name) {
+			return CharPolicy(i), nil
+		}
+	}
+
+	return RejectIllegal, fmt.Errorf("no character policy %s", name)
+}
+
+//HasIllegal returns true when the VIN has an I, O or Q
+func HasIllegal(fullvin string) bool {
+	return strings.ContainsAny(Canonical(fullvin), "IOQ")
+}
+
+//Substitute returns the VIN with I replaced by 1, and O and Q by 0, the characters they're mistaken for.
+func Substitute(fullvin string) string {
+	return strings.NewReplacer("I", "1", "O", "0", "Q", "0").Replace(Canonical(fullvin))
+}
+
+//ValidateLegacy checks the length and characters of identifiers which predate ISO 3779, I, O and Q are allowed and there's no check digit.
+func ValidateLegacy(fullvin string) error {
+	fullvin = Canonical(fullvin)
+
+	if len(fullvin) != 17 {
+		return errLength
+	}
+
+	for i := 0; i < len(fullvin); i++ {
+		c := fullvin[i]
+
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+			return errIllegal
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package vinmath_test
+
+import (
+	"fmt"
+
+	"github.com/louisevanderlith/vin/vinmath"
+)
+
+func ExampleCheckDigit() {
+	//Position 9 is ignored, so the digit can be calculated before it's known
+	fmt.Println(vinmath.CheckDigit("1M8GDM9A_KP042788"))
+	fmt.Println(vinmath.CheckDigit("5npeu46f_7h259112"))
+	// Output:
+	// X
+	// 7
+}
+
+func ExampleValidate() {
+	err := vinmath.Validate("1M8GDM9A1KP042788")
+
+	if cErr, ok := err.(vinmath.CheckDigitError); ok {
+		fmt.Println("position 9 should be", cErr.Expected)
+	}
+
+	fmt.Println(vinmath.Validate("1M8GDM9AXKP042788"))
+	// Output:
+	// position 9 should be X
+	// <nil>
+}
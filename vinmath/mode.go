@@ -0,0 +1,92 @@
+package vinmath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Mode selects the rules a VIN is validated against
+type Mode int
+
+const (
+	//Strict applies ISO 3779 with a mandatory check digit
+	Strict Mode = iota
+	//SouthAfrica tolerates the locally assigned VINs accepted by NaTIS
+	SouthAfrica
+	//Agricultural validates ISO 10261 PINs of agricultural and off-road equipment, see ValidatePIN
+	Agricultural
+)
+
+var modeNames = [...]string{
+	"strict",
+	"za",
+	"pin"}
+
+func (m Mode) String() string {
+	return modeNames[m]
+}
+
+//ParseMode returns the Mode for the name, an empty name is Strict.
+func ParseMode(name string) (Mode, error) {
+	if len(name) == 0 {
+		return Strict, nil
+	}
+
+	for i, v := range modeNames {
+		if strings.EqualFold(v, name) {
+			return Mode(i), nil
+		}
+	}
+
+	return Strict, fmt.Errorf("no validation mode %s", name)
+}
+
+//policePrefix starts the VINs allocated by the SAPS when a vehicle is cleared without a readable VIN
+const policePrefix = "SAPS"
+
+//IsPoliceClearance returns true when the VIN was allocated during a SAPS police clearance.
+//These VINs don't describe the vehicle, so they can't be decoded.
+func IsPoliceClearance(fullvin string) bool {
+	fullvin = Canonical(fullvin)
+
+	return len(fullvin) == 17 && strings.HasPrefix(fullvin, policePrefix)
+}
+
+//ValidateMode checks the VIN against the rules of the Mode
+func ValidateMode(fullvin string, mode Mode) error {
+	fullvin = Canonical(fullvin)
+
+	if mode == Strict {
+		return Validate(fullvin)
+	}
+
+	if mode == Agricultural {
+		return ValidatePIN(fullvin)
+	}
+
+	if len(fullvin) != 17 {
+		return errors.New("not correct length")
+	}
+
+	if IsPoliceClearance(fullvin) {
+		for _, c := range fullvin[len(policePrefix):] {
+			if (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+				return errors.New("found illegal characters")
+			}
+		}
+
+		return nil
+	}
+
+	if strings.ContainsAny(fullvin, "IOQ") {
+		return errors.New("found illegal characters")
+	}
+
+	//Vehicles built-up or assembled locally get a VIN from the AA-AH range, these don't always carry a check digit.
+	if fullvin[0] == 'A' && fullvin[1] >= 'A' && fullvin[1] <= 'H' {
+		return nil
+	}
+
+	return Validate(fullvin)
+}
@@ -0,0 +1,37 @@
+package vinmath
+
+//PINSegments are the sections of a 17 character Product Identification Number of ISO 10261,
+//used by agricultural and earth-moving machinery instead of a VIN.
+type PINSegments struct {
+	WMI       string //1-3 World Manufacturer Identifier
+	MDS       string //4-8 Machine Descriptor Section
+	CheckChar string //9 Manufacturer defined, it's not calculated like a VIN's check digit
+	MIS       string //10-17 Machine Indicator Section, the serial
+}
+
+//SegmentPIN splits the PIN into its sections, the PIN is expected to be 17 characters long.
+func SegmentPIN(fullpin string) PINSegments {
+	return PINSegments{
+		WMI:       fullpin[:3],
+		MDS:       fullpin[3:8],
+		CheckChar: fullpin[8:9],
+		MIS:       fullpin[9:],
+	}
+}
+
+//ValidatePIN checks the length and characters of the PIN, it has no check digit and no year code.
+func ValidatePIN(fullpin string) error {
+	fullpin = Canonical(fullpin)
+
+	if len(fullpin) != 17 {
+		return errLength
+	}
+
+	for i := 0; i < len(fullpin); i++ {
+		if !isVINChar(fullpin[i]) {
+			return errIllegal
+		}
+	}
+
+	return nil
+}
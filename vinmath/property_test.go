@@ -0,0 +1,119 @@
+package vinmath
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+//legalVIN is a random VIN of legal characters, with a year code in position 10. The check digit isn't set
+type legalVIN [17]byte
+
+func (legalVIN) Generate(r *rand.Rand, size int) reflect.Value {
+	var v legalVIN
+
+	for k := range v {
+		v[k] = LegalChars[r.Intn(len(LegalChars))]
+	}
+
+	v[9] = YearChars[r.Intn(len(YearChars))]
+
+	return reflect.ValueOf(v)
+}
+
+//withCheckDigit returns the VIN with the given character in position 9
+func (v legalVIN) withCheckDigit(c byte) string {
+	v[8] = c
+
+	return string(v[:])
+}
+
+//sign returns the VIN with its correct check digit
+func (v legalVIN) sign() string {
+	return v.withCheckDigit(CheckDigit(string(v[:]))[0])
+}
+
+func TestProperty_OneCheckDigit(t *testing.T) {
+	prop := func(v legalVIN) bool {
+		valid := 0
+
+		for k := 0; k < len(CheckDigitChars); k++ {
+			if Validate(v.withCheckDigit(CheckDigitChars[k])) == nil {
+				valid++
+			}
+		}
+
+		return valid == 1
+	}
+
+	if err := quick.Check(prop, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_CheckDigitIgnoresPosition9(t *testing.T) {
+	prop := func(v legalVIN, c uint8) bool {
+		return CheckDigit(string(v[:])) == CheckDigit(v.withCheckDigit(LegalChars[int(c)%len(LegalChars)]))
+	}
+
+	if err := quick.Check(prop, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProperty_TranspositionsDetected(t *testing.T) {
+	r := rand.New(rand.NewSource(3779))
+	swaps, detected := 0, 0
+
+	for swaps < 20000 {
+		v := []byte(legalVIN{}.Generate(r, 0).Interface().(legalVIN).sign())
+		k := r.Intn(len(v) - 1)
+
+		if v[k] == v[k+1] {
+			continue
+		}
+
+		v[k], v[k+1] = v[k+1], v[k]
+		swaps++
+
+		if Validate(string(v)) != nil {
+			detected++
+		}
+	}
+
+	//Letters share values and the check digit has no weight, so some transpositions can't be detected
+	if rate := float64(detected) / float64(swaps); rate < 0.85 {
+		t.Errorf("expected most transpositions to be detected, only %.2f were", rate)
+	}
+}
+
+func TestProperty_TransliterationTotal(t *testing.T) {
+	for k := 0; k < len(LegalChars); k++ {
+		c := LegalChars[k]
+		v := int(transliteration[c])
+
+		if c >= '0' && c <= '9' {
+			if v != int(c-'0') {
+				t.Errorf("%c should count as %d, got %d", c, c-'0', v)
+			}
+
+			continue
+		}
+
+		if v < 1 || v > 9 {
+			t.Errorf("%c should have a value from 1 to 9, got %d", c, v)
+		}
+	}
+
+	prop := func(v legalVIN) bool {
+		d := CheckDigit(string(v[:]))
+
+		return len(d) == 1 && strings.Contains(CheckDigitChars, d)
+	}
+
+	if err := quick.Check(prop, nil); err != nil {
+		t.Error(err)
+	}
+}
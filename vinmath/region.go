@@ -0,0 +1,30 @@
+package vinmath
+
+type regionRange struct {
+	Name      string
+	StartChar string
+	EndChar   string
+}
+
+//regions are the ISO 3779 regions assigned to the first character of the WMI.
+var regions = []regionRange{
+	{Name: "Africa", StartChar: "A", EndChar: "H"},
+	{Name: "Asia", StartChar: "J", EndChar: "R"},
+	{Name: "Europe", StartChar: "S", EndChar: "Z"},
+	{Name: "North America", StartChar: "1", EndChar: "5"},
+	{Name: "Oceania", StartChar: "6", EndChar: "7"},
+	{Name: "South America", StartChar: "8", EndChar: "0"},
+}
+
+//Region returns the name of the region the first character of the WMI belongs to
+func Region(char string) string {
+	v := CharOrder(char)
+
+	for _, r := range regions {
+		if CharOrder(r.StartChar) <= v && v <= CharOrder(r.EndChar) {
+			return r.Name
+		}
+	}
+
+	return ""
+}
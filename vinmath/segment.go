@@ -0,0 +1,74 @@
+package vinmath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//Segments are the sections of a 17 character VIN
+type Segments struct {
+	WMI        string //1-3 World Manufacturer Identifier
+	VDS        string //4-8 Vehicle Descriptor Section
+	CheckDigit string //9
+	YearCode   string //10
+	PlantCode  string //11
+	Sequence   string //12-17
+}
+
+//Segment splits the VIN into its sections, the VIN is expected to be 17 characters long.
+func Segment(fullvin string) Segments {
+	return Segments{
+		WMI:        fullvin[:3],
+		VDS:        fullvin[3:8],
+		CheckDigit: fullvin[8:9],
+		YearCode:   fullvin[9:10],
+		PlantCode:  fullvin[10:11],
+		Sequence:   fullvin[11:],
+	}
+}
+
+//UniqueSerial returns the first 11 characters which identify the vehicle, and the serial number which follows.
+func UniqueSerial(fullvin string) (string, int) {
+	serial, _ := strconv.Atoi(fullvin[11:])
+	return fullvin[:11], serial
+}
+
+//Years returns the possible model years of the year code in position 10.
+//The codes repeat every 30 years, A-Y are 1980-2000 and 2010-2030, 1-9 are 2001-2009 and 2031-2039, see YearChars.
+//Model years run ahead of the calendar, years after next year aren't returned.
+func Years(digit string) ([]int, error) {
+	if len(digit) != 1 || !isYearChar(digit[0]) {
+		return nil, fmt.Errorf("%s isn't a year code", digit)
+	}
+
+	offset := strings.IndexByte(YearChars, digit[0])
+	maxYear := time.Now().Year() + 1
+
+	var result []int
+
+	for _, v := range []int{1980, 2010} {
+		year := v + offset
+
+		if year <= maxYear {
+			result = append(result, year)
+		}
+	}
+
+	return result, nil
+}
+
+//CharOrder returns the position of the character in the sequence A-Z followed by 1-9 and 0
+func CharOrder(char string) int {
+	if val, err := strconv.Atoi(char); err == nil {
+		if val == 0 {
+			return 36
+		}
+
+		return val + 26
+	}
+
+	//Alpha chars will return their index in the alphabet
+	return int(char[0] % 32)
+}
@@ -0,0 +1,67 @@
+package vinmath
+
+//Character sets which are legal in a VIN
+const (
+	//LegalChars are allowed in every position, I, O and Q are left out as they're mistaken for 1 and 0
+	LegalChars = "0123456789ABCDEFGHJKLMNPRSTUVWXYZ"
+	//CheckDigitChars are allowed in position 9
+	CheckDigitChars = "0123456789X"
+	//YearChars are allowed in position 10, U, Z and 0 are never used as year codes
+	YearChars = "ABCDEFGHJKLMNPRSTVWXY123456789"
+)
+
+//PositionSpec describes a single position of the VIN
+type PositionSpec struct {
+	Position int //1-based
+	Section  string
+	Weight   int
+	Legal    string
+}
+
+//Specification is everything a validator needs to check a VIN, so other validators can be generated from it.
+type Specification struct {
+	Length             int
+	CheckDigitPosition int
+	Transliteration    map[string]int
+	Positions          []PositionSpec
+}
+
+//sections names the Segments each position belongs to
+var sections = [...]string{"WMI", "WMI", "WMI", "VDS", "VDS", "VDS", "VDS", "VDS", "CheckDigit", "YearCode", "PlantCode",
+	"Sequence", "Sequence", "Sequence", "Sequence", "Sequence", "Sequence"}
+
+//Spec returns the weights, transliteration and legal characters used by Validate.
+//The result is a copy, changing it has no effect on validation.
+func Spec() Specification {
+	result := Specification{
+		Length:             len(weights),
+		CheckDigitPosition: 9,
+		Transliteration:    make(map[string]int),
+	}
+
+	for _, c := range LegalChars {
+		if c >= 'A' {
+			result.Transliteration[string(c)] = int(transliteration[c])
+		}
+	}
+
+	for i, w := range weights {
+		legal := LegalChars
+
+		switch i + 1 {
+		case result.CheckDigitPosition:
+			legal = CheckDigitChars
+		case 10:
+			legal = YearChars
+		}
+
+		result.Positions = append(result.Positions, PositionSpec{
+			Position: i + 1,
+			Section:  sections[i],
+			Weight:   w,
+			Legal:    legal,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,28 @@
+package vinmath
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpec_MatchesValidation(t *testing.T) {
+	spec := Spec()
+
+	if len(spec.Positions) != spec.Length {
+		t.Fatalf("expected %d positions, got %d", spec.Length, len(spec.Positions))
+	}
+
+	for _, c := range LegalChars {
+		if !isVINChar(byte(c)) {
+			t.Errorf("%c is legal in the spec, but not in validation", c)
+		}
+	}
+
+	if strings.ContainsAny(LegalChars, "IOQ") {
+		t.Error("I, O and Q are never legal")
+	}
+
+	if spec.Positions[8].Weight != 0 || spec.Positions[8].Legal != CheckDigitChars {
+		t.Errorf("position 9 is the check digit, got %+v", spec.Positions[8])
+	}
+}
@@ -0,0 +1,121 @@
+//Package vinmath contains the parts of VIN handling which only depend on the characters of the VIN.
+//It has no dependencies, so it can be used where only validation is required.
+package vinmath
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//Canonical returns the VIN in upper case without surrounding whitespace, the form VINs are validated and stored in.
+func Canonical(fullvin string) string {
+	return strings.ToUpper(strings.TrimSpace(fullvin))
+}
+
+//Errors returned by validation, they're shared so a failed validation doesn't allocate
+var (
+	errLength   = errors.New("not correct length")
+	errIllegal  = errors.New("found illegal characters")
+	errYearCode = errors.New("found illegal year code")
+)
+
+//Validate checks the length, characters and check digit of the VIN, it's not case sensitive.
+func Validate(fullvin string) error {
+	fullvin = strings.TrimSpace(fullvin)
+
+	if len(fullvin) != 17 {
+		return errLength
+	}
+
+	var vin [17]byte
+	copy(vin[:], fullvin)
+
+	return validate(&vin)
+}
+
+//ValidateVINBytes is Validate for VINs which are read as bytes, ie. from a packet.
+//Valid VINs are checked without allocating, the bytes aren't changed.
+func ValidateVINBytes(fullvin []byte) error {
+	fullvin = bytes.TrimSpace(fullvin)
+
+	if len(fullvin) != 17 {
+		return errLength
+	}
+
+	var vin [17]byte
+	copy(vin[:], fullvin)
+
+	return validate(&vin)
+}
+
+//validate checks the characters, year code and check digit of the VIN, it's changed to upper case.
+func validate(vin *[17]byte) error {
+	for k, c := range vin {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+			vin[k] = c
+		}
+
+		if !isVINChar(c) {
+			return errIllegal
+		}
+	}
+
+	if !isYearChar(vin[9]) {
+		return errYearCode
+	}
+
+	score := checkDigit(vin[:])
+
+	if vin[8] != CheckDigitChars[score] {
+		return CheckDigitError{Found: string(vin[8:9]), Expected: CheckDigitChars[score : score+1]}
+	}
+
+	return nil
+}
+
+//CheckDigitError is returned when only the check digit is wrong, so callers can choose to tolerate it
+type CheckDigitError struct {
+	Found    string
+	Expected string
+}
+
+func (e CheckDigitError) Error() string {
+	return fmt.Sprintf("check digit %s is invalid for %s", e.Found, e.Expected)
+}
+
+//CheckDigit calculates the check digit the VIN should have in position 9.
+//Characters which aren't legal count as zero, Validate rejects them before the check digit is compared.
+func CheckDigit(fullvin string) string {
+	var vin [17]byte
+	n := copy(vin[:], Canonical(fullvin))
+	score := checkDigit(vin[:n])
+
+	return CheckDigitChars[score : score+1]
+}
+
+//checkDigit returns the index of the check digit in CheckDigitChars, the VIN must be in upper case.
+func checkDigit(vin []byte) int {
+	result := 0
+
+	for k, c := range vin {
+		result += int(transliteration[c]) * weights[k]
+	}
+
+	return result % 11
+}
+
+//weights are multiplied with the value of the character in the same position
+var weights = []int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+//transliteration is the value of every byte, digits count as themselves and letters as assigned by ISO 3779 and 49 CFR 565.
+//The letters are numbered 1-9 in three runs, A-I, J-R and S-Z, where S starts at 2.
+//I, O and Q are never used, so like every other byte they have no value.
+var transliteration = [256]uint8{
+	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
+	'A': 1, 'B': 2, 'C': 3, 'D': 4, 'E': 5, 'F': 6, 'G': 7, 'H': 8,
+	'J': 1, 'K': 2, 'L': 3, 'M': 4, 'N': 5, 'P': 7, 'R': 9,
+	'S': 2, 'T': 3, 'U': 4, 'V': 5, 'W': 6, 'X': 7, 'Y': 8, 'Z': 9,
+}
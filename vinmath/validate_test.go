@@ -0,0 +1,306 @@
+package vinmath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate_IsValid(t *testing.T) {
+	in := "5NPEU46F77H259112"
+	err := Validate(in)
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidate_NotValid(t *testing.T) {
+	in := "5NBEU46F77H259112"
+	err := Validate(in)
+
+	if err == nil {
+		t.Error("Expecting error")
+	}
+}
+
+func TestValidate_NoIOQ(t *testing.T) {
+	in := "5NBEU46F77H259Q12"
+	err := Validate(in)
+
+	if err == nil {
+		t.Error("Expecting error")
+	}
+}
+
+func TestSegment_Sections(t *testing.T) {
+	seg := Segment("5NPEU46F77H259112")
+
+	if seg.WMI != "5NP" || seg.VDS != "EU46F" || seg.CheckDigit != "7" || seg.YearCode != "7" || seg.PlantCode != "H" || seg.Sequence != "259112" {
+		t.Errorf("unexpected segments %+v", seg)
+	}
+}
+
+func TestRegion_SouthAmerica(t *testing.T) {
+	if r := Region("0"); r != "South America" {
+		t.Errorf("expected South America, got %s", r)
+	}
+}
+
+func TestValidateMode_SouthAfricaLocalVIN(t *testing.T) {
+	in := "AHTFR22G806012345"
+
+	if Validate(in) == nil {
+		t.Fatal("expecting the check digit to fail in strict mode")
+	}
+
+	err := ValidateMode(in, SouthAfrica)
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateMode_SouthAfricaPoliceClearance(t *testing.T) {
+	err := ValidateMode("SAPS1234567890123", SouthAfrica)
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateMode_SouthAfricaImportsChecked(t *testing.T) {
+	err := ValidateMode("5NBEU46F77H259112", SouthAfrica)
+
+	if err == nil {
+		t.Error("Expecting error")
+	}
+}
+
+func TestValidateMode_AgriculturalPIN(t *testing.T) {
+	in := "1RW8320RCJD012345"
+
+	if Validate(in) == nil {
+		t.Fatal("expecting the check digit to fail for a PIN in strict mode")
+	}
+
+	if err := ValidateMode(in, Agricultural); err != nil {
+		t.Error(err)
+	}
+
+	if ValidatePIN("1RW8320RCJD01234") == nil || ValidatePIN("1RW8320RCJDO12345") == nil {
+		t.Error("expecting the length and characters of PINs to be checked")
+	}
+
+	seg := SegmentPIN(in)
+
+	if seg.WMI != "1RW" || seg.MDS != "8320R" || seg.CheckChar != "C" || seg.MIS != "JD012345" {
+		t.Errorf("unexpected segments %+v", seg)
+	}
+}
+
+func TestValidate_CheckDigitError(t *testing.T) {
+	err := Validate("1M8GDM9A1KP042788")
+
+	if _, ok := err.(CheckDigitError); !ok {
+		t.Fatalf("expected a CheckDigitError, got %v", err)
+	}
+}
+
+func TestTransliteration_Standard(t *testing.T) {
+	//Each run of letters counts from 1, with the skipped letters I, O and Q still taking up their place.
+	runs := []string{"ABCDEFGHI", "JKLMNOPQR", "_STUVWXYZ"}
+
+	for _, run := range runs {
+		for i, c := range run {
+			if c == '_' {
+				continue
+			}
+
+			if c == 'I' || c == 'O' || c == 'Q' {
+				if transliteration[c] != 0 {
+					t.Errorf("%c must not have a value", c)
+				}
+
+				continue
+			}
+
+			if int(transliteration[c]) != i+1 {
+				t.Errorf("%c should be %d, got %d", c, i+1, transliteration[c])
+			}
+		}
+	}
+}
+
+func TestCheckDigit_PublishedExamples(t *testing.T) {
+	examples := map[string]string{
+		"1M8GDM9AXKP042788": "X",
+		"11111111111111111": "1",
+		"1HGCM82633A004352": "3",
+		"JH4KA7561PC008269": "1",
+	}
+
+	for vin, expected := range examples {
+		if actual := CheckDigit(vin); actual != expected {
+			t.Errorf("%s: expected %s, got %s", vin, expected, actual)
+		}
+	}
+}
+
+func TestValidate_MixedCase(t *testing.T) {
+	for _, in := range []string{"5npeu46f77h259112", "5NpEu46F77h259112", " 5NPEU46F77H259112\n", "\t1m8gdm9axkp042788 "} {
+		if err := Validate(in); err != nil {
+			t.Errorf("%q: %s", in, err)
+		}
+	}
+}
+
+func TestValidate_IllegalCharactersDontPanic(t *testing.T) {
+	for _, in := range []string{"5NPEU46F77H25911#", "5NPEU46F-7H259112", "5NPEU46F77H25911é"} {
+		if err := Validate(in); err == nil {
+			t.Errorf("%q: expecting error", in)
+		}
+	}
+}
+
+func TestCheckDigit_LowerCase(t *testing.T) {
+	if d := CheckDigit("1m8gdm9axkp042788"); d != "X" {
+		t.Errorf("expected X, got %s", d)
+	}
+}
+
+func TestValidateVINBytes(t *testing.T) {
+	in := []byte(" 5npeu46f77h259112\n")
+
+	if err := ValidateVINBytes(in); err != nil {
+		t.Error(err)
+	}
+
+	if string(in) != " 5npeu46f77h259112\n" {
+		t.Errorf("the VIN must not be changed, got %q", in)
+	}
+
+	if _, ok := ValidateVINBytes([]byte("1M8GDM9A1KP042788")).(CheckDigitError); !ok {
+		t.Error("expected a CheckDigitError")
+	}
+
+	if err := ValidateVINBytes([]byte("5NBEU46F77H259Q12")); err == nil {
+		t.Error("Expecting error")
+	}
+}
+
+func TestValidateVINBytes_NoAllocations(t *testing.T) {
+	in := []byte("5NPEU46F77H259112")
+	bad := []byte("5NPEU46F77H25911#")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		ValidateVINBytes(in)
+		ValidateVINBytes(bad)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkValidateVINBytes(b *testing.B) {
+	in := []byte("5NPEU46F77H259112")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ValidateVINBytes(in)
+	}
+}
+
+func TestTransliteration_Digits(t *testing.T) {
+	for c := byte('0'); c <= '9'; c++ {
+		if int(transliteration[c]) != int(c-'0') {
+			t.Errorf("%c should be %d, got %d", c, c-'0', transliteration[c])
+		}
+	}
+}
+
+//BenchmarkCheckDigit measures the score of a VIN, it's a single table lookup per position
+func BenchmarkCheckDigit(b *testing.B) {
+	in := []byte("5NPEU46F77H259112")
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		checkDigit(in)
+	}
+}
+
+func TestCharPolicy_Substitute(t *testing.T) {
+	in := "5NPEU46F77H2591I2"
+
+	if Validate(in) == nil {
+		t.Fatal("expecting I to be illegal")
+	}
+
+	if !HasIllegal(in) {
+		t.Error("expecting I to be found")
+	}
+
+	if out := Substitute(in); out != "5NPEU46F77H259112" || Validate(out) != nil {
+		t.Errorf("unexpected substitute %s", out)
+	}
+}
+
+func TestCharPolicy_Legacy(t *testing.T) {
+	if err := ValidateLegacy("OQI12345678901234"); err != nil {
+		t.Error(err)
+	}
+
+	if ValidateLegacy("OQI-2345678901234") == nil {
+		t.Error("expecting legacy identifiers to be alphanumeric")
+	}
+
+	if _, err := ParseCharPolicy("ignore"); err == nil {
+		t.Error("expecting an unknown policy to fail")
+	}
+}
+
+func TestValidate_YearCode(t *testing.T) {
+	for _, in := range []string{"1M8GDM9A6UP042788", "1M8GDM9A7ZP042788", "1M8GDM9A30P042788"} {
+		if err := Validate(in); err != errYearCode {
+			t.Errorf("expected the year code of %s to be rejected, got %v", in, err)
+		}
+	}
+
+	if err := Validate("1M8GDM9AXKP042788"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestYears(t *testing.T) {
+	tests := []struct {
+		code  string
+		years []int
+	}{
+		{"A", []int{1980, 2010}},
+		{"J", []int{1988, 2018}},
+		{"Y", []int{2000}},
+		{"1", []int{2001}},
+		{"9", []int{2009}},
+	}
+
+	for _, tc := range tests {
+		years, err := Years(tc.code)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//The second year is only returned once it's near
+		if !reflect.DeepEqual(years[:len(tc.years)], tc.years) {
+			t.Errorf("%s: expected %v, got %v", tc.code, tc.years, years)
+		}
+	}
+
+	for _, code := range []string{"U", "Z", "0", "I", ""} {
+		if _, err := Years(code); err == nil {
+			t.Errorf("expected %q to be rejected", code)
+		}
+	}
+}
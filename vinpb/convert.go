@@ -0,0 +1,253 @@
+package vinpb
+
+import (
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+//FromVIN converts the core VIN to its message
+func FromVIN(v core.VIN) *VIN {
+	result := &VIN{
+		Full:       v.Full,
+		Unique:     v.Unique,
+		Serial:     int64(v.Serial),
+		WMInfo:     FromWMInfo(v.WMInfo),
+		VDSInfo:    FromVDSInfo(v.VDSInfo),
+		Series:     FromSeries(v.Series),
+		Source:     v.Source,
+		Attributes: v.Attributes,
+		Version:    int32(v.Version),
+	}
+
+	for _, y := range v.Years {
+		result.Years = append(result.Years, int32(y))
+	}
+
+	if !v.CreatedAt.IsZero() {
+		result.CreatedAt = v.CreatedAt.UnixNano()
+	}
+
+	if !v.UpdatedAt.IsZero() {
+		result.UpdatedAt = v.UpdatedAt.UnixNano()
+	}
+
+	if !v.LastDecodedAt.IsZero() {
+		result.LastDecodedAt = v.LastDecodedAt.UnixNano()
+	}
+
+	return result
+}
+
+//ToVIN converts the message to the core VIN
+func ToVIN(m *VIN) core.VIN {
+	result := core.VIN{
+		Full:       m.Full,
+		Unique:     m.Unique,
+		Serial:     int(m.Serial),
+		Source:     m.Source,
+		Attributes: m.Attributes,
+		Version:    int(m.Version),
+	}
+
+	for _, y := range m.Years {
+		result.Years = append(result.Years, int(y))
+	}
+
+	if m.WMInfo != nil {
+		result.WMInfo = ToWMInfo(m.WMInfo)
+	}
+
+	if m.VDSInfo != nil {
+		result.VDSInfo = ToVDSInfo(m.VDSInfo)
+	}
+
+	if m.Series != nil {
+		result.Series = ToSeries(m.Series)
+	}
+
+	if m.CreatedAt != 0 {
+		result.CreatedAt = time.Unix(0, m.CreatedAt)
+	}
+
+	if m.UpdatedAt != 0 {
+		result.UpdatedAt = time.Unix(0, m.UpdatedAt)
+	}
+
+	if m.LastDecodedAt != 0 {
+		result.LastDecodedAt = time.Unix(0, m.LastDecodedAt)
+	}
+
+	return result
+}
+
+func FromWMInfo(w core.WMInfo) *WMInfo {
+	return &WMInfo{
+		Region:        w.Region,
+		Country:       w.Country,
+		Manufacturer:  w.Manufacturer,
+		VehicleType:   w.VehicleType,
+		VehicleClass:  w.VehicleClass,
+		CountryAlpha2: w.CountryAlpha2,
+		CountryAlpha3: w.CountryAlpha3,
+		Suggestions:   w.Suggestions,
+	}
+}
+
+func ToWMInfo(m *WMInfo) core.WMInfo {
+	return core.WMInfo{
+		Region:        m.Region,
+		Country:       m.Country,
+		Manufacturer:  m.Manufacturer,
+		VehicleType:   m.VehicleType,
+		VehicleClass:  m.VehicleClass,
+		CountryAlpha2: m.CountryAlpha2,
+		CountryAlpha3: m.CountryAlpha3,
+		Suggestions:   m.Suggestions,
+	}
+}
+
+func FromVDSInfo(v vds.VDSInfo) *VDSInfo {
+	result := &VDSInfo{
+		Code:      v.Code,
+		PlantCode: v.PlantCode,
+		BodyStyle: v.BodyStyle,
+		Chassis:   v.Chassis,
+		Plant:     v.Plant,
+	}
+
+	if v.EV != nil {
+		result.EV = &EVInfo{
+			Powertrain: v.EV.Powertrain,
+			Platform:   v.EV.Platform,
+			Battery:    v.EV.Battery,
+			Motors:     v.EV.Motors,
+		}
+	}
+
+	return result
+}
+
+func ToVDSInfo(m *VDSInfo) vds.VDSInfo {
+	result := vds.VDSInfo{
+		Code:      m.Code,
+		PlantCode: m.PlantCode,
+		BodyStyle: m.BodyStyle,
+		Chassis:   m.Chassis,
+		Plant:     m.Plant,
+	}
+
+	if m.EV != nil {
+		result.EV = &vds.EVInfo{
+			Powertrain: m.EV.Powertrain,
+			Platform:   m.EV.Platform,
+			Battery:    m.EV.Battery,
+			Motors:     m.EV.Motors,
+		}
+	}
+
+	return result
+}
+
+func FromBody(b core.Body) *Body {
+	return &Body{
+		Code:      b.Code,
+		Layout:    b.Layout,
+		Doors:     int32(b.Doors),
+		StartYear: int32(b.StartYear),
+		EndYear:   int32(b.EndYear),
+	}
+}
+
+func ToBody(m *Body) core.Body {
+	return core.Body{
+		Code:      m.Code,
+		Layout:    m.Layout,
+		Doors:     int(m.Doors),
+		StartYear: int(m.StartYear),
+		EndYear:   int(m.EndYear),
+	}
+}
+
+//FromSeries converts the Series and its Platform, the Engine and Gearbox of the Platform aren't part of the message.
+func FromSeries(s core.Series) *Series {
+	return &Series{
+		Platform: &Platform{
+			Code:        s.Platform.Code,
+			Body:        FromBody(s.Platform.Body),
+			DriveLayout: s.Platform.DriveLayout,
+			StartYear:   int32(s.Platform.StartYear),
+			EndYear:     int32(s.Platform.EndYear),
+		},
+		Spec:      s.Spec,
+		StartYear: int32(s.StartYear),
+		EndYear:   int32(s.EndYear),
+	}
+}
+
+func ToSeries(m *Series) core.Series {
+	result := core.Series{
+		Spec:      m.Spec,
+		StartYear: int(m.StartYear),
+		EndYear:   int(m.EndYear),
+	}
+
+	if p := m.Platform; p != nil {
+		result.Platform.Code = p.Code
+		result.Platform.DriveLayout = p.DriveLayout
+		result.Platform.StartYear = int(p.StartYear)
+		result.Platform.EndYear = int(p.EndYear)
+
+		if p.Body != nil {
+			result.Platform.Body = ToBody(p.Body)
+		}
+	}
+
+	return result
+}
+
+//FromDecodeBatch converts the results of core.DecodeBatch to the response message
+func FromDecodeBatch(results []core.DecodeResult) *DecodeBatchResponse {
+	result := &DecodeBatchResponse{}
+
+	for _, r := range results {
+		item := &DecodeResult{
+			VIN:    r.VIN,
+			Status: int32(r.Status),
+			Code:   r.Code,
+			Error:  r.Error,
+		}
+
+		if r.Decode != nil {
+			item.Decode = FromVIN(*r.Decode)
+		}
+
+		result.Results = append(result.Results, item)
+	}
+
+	return result
+}
+
+//ToDecodeBatch converts the response message to the core results
+func ToDecodeBatch(m *DecodeBatchResponse) []core.DecodeResult {
+	var result []core.DecodeResult
+
+	for _, r := range m.Results {
+		item := core.DecodeResult{
+			VIN:    r.VIN,
+			Status: int(r.Status),
+			Code:   r.Code,
+			Error:  r.Error,
+		}
+
+		if r.Decode != nil {
+			v := ToVIN(r.Decode)
+			item.Decode = &v
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,442 @@
+//Package vinpb contains the protobuf messages defined in vin.proto, and converters to and from the core structs.
+//The wire format is encoded without generated code, so the module doesn't depend on the protobuf runtime.
+package vinpb
+
+type VIN struct {
+	Full          string
+	Unique        string
+	Serial        int64
+	Years         []int32
+	WMInfo        *WMInfo
+	VDSInfo       *VDSInfo
+	Series        *Series
+	Source        string
+	Attributes    map[string]string
+	CreatedAt     int64 //Unix time in nanoseconds
+	Version       int32
+	UpdatedAt     int64 //Unix time in nanoseconds
+	LastDecodedAt int64 //Unix time in nanoseconds
+}
+
+type WMInfo struct {
+	Region        string
+	Country       string
+	Manufacturer  string
+	VehicleType   string
+	Suggestions   []string
+	VehicleClass  string
+	CountryAlpha2 string
+	CountryAlpha3 string
+}
+
+type VDSInfo struct {
+	Code      string
+	PlantCode string
+	BodyStyle string
+	Chassis   string
+	Plant     string
+	EV        *EVInfo
+}
+
+type EVInfo struct {
+	Powertrain string
+	Platform   string
+	Battery    string
+	Motors     string
+}
+
+type Body struct {
+	Code      string
+	Layout    string
+	Doors     int32
+	StartYear int32
+	EndYear   int32
+}
+
+type Platform struct {
+	Code        string
+	Body        *Body
+	DriveLayout string
+	StartYear   int32
+	EndYear     int32
+}
+
+type Series struct {
+	Platform  *Platform
+	Spec      string
+	StartYear int32
+	EndYear   int32
+}
+
+func (m *VIN) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Full)
+	e.string(2, m.Unique)
+	e.varint(3, m.Serial)
+	e.packed(4, m.Years)
+
+	if m.WMInfo != nil {
+		e.message(5, m.WMInfo)
+	}
+
+	if m.VDSInfo != nil {
+		e.message(6, m.VDSInfo)
+	}
+
+	if m.Series != nil {
+		e.message(7, m.Series)
+	}
+
+	e.string(8, m.Source)
+
+	for k, v := range m.Attributes {
+		entry := &encoder{}
+		entry.string(1, k)
+		entry.string(2, v)
+		e.bytes(9, entry.buf)
+	}
+
+	e.varint(10, m.CreatedAt)
+	e.varint(11, int64(m.Version))
+	e.varint(12, m.UpdatedAt)
+	e.varint(13, m.LastDecodedAt)
+
+	return e.buf
+}
+
+func (m *VIN) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Full = f.string()
+		case 2:
+			m.Unique = f.string()
+		case 3:
+			m.Serial = int64(f.value)
+		case 4:
+			years, err := f.int32s()
+
+			if err != nil {
+				return err
+			}
+
+			m.Years = append(m.Years, years...)
+		case 5:
+			m.WMInfo = &WMInfo{}
+			return m.WMInfo.Unmarshal(f.data)
+		case 6:
+			m.VDSInfo = &VDSInfo{}
+			return m.VDSInfo.Unmarshal(f.data)
+		case 7:
+			m.Series = &Series{}
+			return m.Series.Unmarshal(f.data)
+		case 8:
+			m.Source = f.string()
+		case 9:
+			var k, v string
+			err := decode(f.data, func(entry field) error {
+				switch entry.num {
+				case 1:
+					k = entry.string()
+				case 2:
+					v = entry.string()
+				}
+
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+
+			if m.Attributes == nil {
+				m.Attributes = make(map[string]string)
+			}
+
+			m.Attributes[k] = v
+		case 10:
+			m.CreatedAt = int64(f.value)
+		case 11:
+			m.Version = f.int32()
+		case 12:
+			m.UpdatedAt = int64(f.value)
+		case 13:
+			m.LastDecodedAt = int64(f.value)
+		}
+
+		return nil
+	})
+}
+
+func (m *WMInfo) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Region)
+	e.string(2, m.Country)
+	e.string(3, m.Manufacturer)
+	e.string(4, m.VehicleType)
+
+	for _, s := range m.Suggestions {
+		e.bytes(5, []byte(s))
+	}
+
+	e.string(6, m.VehicleClass)
+	e.string(7, m.CountryAlpha2)
+	e.string(8, m.CountryAlpha3)
+
+	return e.buf
+}
+
+func (m *WMInfo) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Region = f.string()
+		case 2:
+			m.Country = f.string()
+		case 3:
+			m.Manufacturer = f.string()
+		case 4:
+			m.VehicleType = f.string()
+		case 5:
+			m.Suggestions = append(m.Suggestions, f.string())
+		case 6:
+			m.VehicleClass = f.string()
+		case 7:
+			m.CountryAlpha2 = f.string()
+		case 8:
+			m.CountryAlpha3 = f.string()
+		}
+
+		return nil
+	})
+}
+
+func (m *VDSInfo) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Code)
+	e.string(2, m.PlantCode)
+	e.string(3, m.BodyStyle)
+	e.string(4, m.Chassis)
+	e.string(5, m.Plant)
+
+	if m.EV != nil {
+		e.message(6, m.EV)
+	}
+
+	return e.buf
+}
+
+func (m *VDSInfo) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Code = f.string()
+		case 2:
+			m.PlantCode = f.string()
+		case 3:
+			m.BodyStyle = f.string()
+		case 4:
+			m.Chassis = f.string()
+		case 5:
+			m.Plant = f.string()
+		case 6:
+			m.EV = &EVInfo{}
+			return m.EV.Unmarshal(f.data)
+		}
+
+		return nil
+	})
+}
+
+func (m *EVInfo) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Powertrain)
+	e.string(2, m.Platform)
+	e.string(3, m.Battery)
+	e.string(4, m.Motors)
+
+	return e.buf
+}
+
+func (m *EVInfo) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Powertrain = f.string()
+		case 2:
+			m.Platform = f.string()
+		case 3:
+			m.Battery = f.string()
+		case 4:
+			m.Motors = f.string()
+		}
+
+		return nil
+	})
+}
+
+func (m *Body) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Code)
+	e.string(2, m.Layout)
+	e.varint(3, int64(m.Doors))
+	e.varint(4, int64(m.StartYear))
+	e.varint(5, int64(m.EndYear))
+
+	return e.buf
+}
+
+func (m *Body) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Code = f.string()
+		case 2:
+			m.Layout = f.string()
+		case 3:
+			m.Doors = f.int32()
+		case 4:
+			m.StartYear = f.int32()
+		case 5:
+			m.EndYear = f.int32()
+		}
+
+		return nil
+	})
+}
+
+func (m *Platform) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.Code)
+
+	if m.Body != nil {
+		e.message(2, m.Body)
+	}
+
+	e.string(3, m.DriveLayout)
+	e.varint(4, int64(m.StartYear))
+	e.varint(5, int64(m.EndYear))
+
+	return e.buf
+}
+
+func (m *Platform) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Code = f.string()
+		case 2:
+			m.Body = &Body{}
+			return m.Body.Unmarshal(f.data)
+		case 3:
+			m.DriveLayout = f.string()
+		case 4:
+			m.StartYear = f.int32()
+		case 5:
+			m.EndYear = f.int32()
+		}
+
+		return nil
+	})
+}
+
+func (m *Series) Marshal() []byte {
+	e := &encoder{}
+
+	if m.Platform != nil {
+		e.message(1, m.Platform)
+	}
+
+	e.string(2, m.Spec)
+	e.varint(3, int64(m.StartYear))
+	e.varint(4, int64(m.EndYear))
+
+	return e.buf
+}
+
+func (m *Series) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.Platform = &Platform{}
+			return m.Platform.Unmarshal(f.data)
+		case 2:
+			m.Spec = f.string()
+		case 3:
+			m.StartYear = f.int32()
+		case 4:
+			m.EndYear = f.int32()
+		}
+
+		return nil
+	})
+}
+
+type DecodeResult struct {
+	VIN    string
+	Status int32 //HTTP status of the VIN, 200 when it was decoded
+	Code   string
+	Error  string
+	Decode *VIN
+}
+
+type DecodeBatchResponse struct {
+	Results []*DecodeResult
+}
+
+func (m *DecodeResult) Marshal() []byte {
+	e := &encoder{}
+	e.string(1, m.VIN)
+	e.varint(2, int64(m.Status))
+	e.string(3, m.Code)
+	e.string(4, m.Error)
+
+	if m.Decode != nil {
+		e.message(5, m.Decode)
+	}
+
+	return e.buf
+}
+
+func (m *DecodeResult) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		switch f.num {
+		case 1:
+			m.VIN = f.string()
+		case 2:
+			m.Status = f.int32()
+		case 3:
+			m.Code = f.string()
+		case 4:
+			m.Error = f.string()
+		case 5:
+			m.Decode = &VIN{}
+			return m.Decode.Unmarshal(f.data)
+		}
+
+		return nil
+	})
+}
+
+func (m *DecodeBatchResponse) Marshal() []byte {
+	e := &encoder{}
+
+	for _, r := range m.Results {
+		e.message(1, r)
+	}
+
+	return e.buf
+}
+
+func (m *DecodeBatchResponse) Unmarshal(b []byte) error {
+	return decode(b, func(f field) error {
+		if f.num != 1 {
+			return nil
+		}
+
+		r := &DecodeResult{}
+		m.Results = append(m.Results, r)
+
+		return r.Unmarshal(f.data)
+	})
+}
@@ -0,0 +1,103 @@
+package vinpb
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+	"github.com/louisevanderlith/vin/core/vds"
+)
+
+func TestBody_WireFormat(t *testing.T) {
+	b := &Body{Code: "A", Doors: 4}
+	expected := []byte{0x0a, 0x01, 'A', 0x18, 0x04}
+
+	if !bytes.Equal(b.Marshal(), expected) {
+		t.Errorf("expected %x, got %x", expected, b.Marshal())
+	}
+}
+
+func TestVIN_RoundTrip(t *testing.T) {
+	in := core.VIN{
+		Full:   "5YJ3E1EA7KF317000",
+		Unique: "5YJ3E1EA7KF",
+		Serial: 317000,
+		Years:  []int{1989, 2019},
+		WMInfo: core.WMInfo{Region: "North America", Country: "United States", CountryAlpha2: "US", CountryAlpha3: "USA", Manufacturer: "Tesla", VehicleClass: "PassengerCar"},
+		VDSInfo: vds.VDSInfo{
+			Code:    "3E1EA",
+			Chassis: "Model 3",
+			EV:      &vds.EVInfo{Powertrain: vds.BEV, Motors: "Single motor"},
+		},
+		Series: core.Series{
+			Spec:     "Standard",
+			Platform: core.Platform{Code: "E", Body: core.Body{Code: "S", Doors: 4}},
+		},
+		Source:        "api",
+		Attributes:    map[string]string{"colour": "red"},
+		CreatedAt:     time.Unix(0, 1570000000123456789),
+		UpdatedAt:     time.Unix(0, 1570000100000000000),
+		LastDecodedAt: time.Unix(0, 1570000200000000000),
+		Version:       3,
+	}
+
+	out := &VIN{}
+	err := out.Unmarshal(FromVIN(in).Marshal())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := ToVIN(out)
+
+	if !result.CreatedAt.Equal(in.CreatedAt) {
+		t.Errorf("expected %s, got %s", in.CreatedAt, result.CreatedAt)
+	}
+
+	if !result.UpdatedAt.Equal(in.UpdatedAt) || !result.LastDecodedAt.Equal(in.LastDecodedAt) {
+		t.Errorf("expected the timestamps to round trip, got %s and %s", result.UpdatedAt, result.LastDecodedAt)
+	}
+
+	result.CreatedAt = in.CreatedAt
+	result.UpdatedAt = in.UpdatedAt
+	result.LastDecodedAt = in.LastDecodedAt
+
+	if !reflect.DeepEqual(result, in) {
+		t.Errorf("expected %+v, got %+v", in, result)
+	}
+}
+
+func TestVIN_UnmarshalTruncated(t *testing.T) {
+	b := FromVIN(core.VIN{Full: "5YJ3E1EA7KF317000"}).Marshal()
+	err := (&VIN{}).Unmarshal(b[:len(b)-3])
+
+	if err == nil {
+		t.Error("expecting error")
+	}
+}
+
+func TestDecodeBatch_RoundTrip(t *testing.T) {
+	in := []core.DecodeResult{
+		{VIN: "5YJ3E1EA7KF317000", Status: 200, Decode: &core.VIN{Full: "5YJ3E1EA7KF317000", Serial: 317000}},
+		{VIN: "5YJ3E1EA8KF317000", Status: 400, Code: "checkdigit", Error: "check digit 8 is invalid for 7"},
+	}
+
+	out := &DecodeBatchResponse{}
+	err := out.Unmarshal(FromDecodeBatch(in).Marshal())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := ToDecodeBatch(out)
+
+	if len(result) != 2 || result[1] != in[1] {
+		t.Fatalf("expected %+v, got %+v", in, result)
+	}
+
+	if result[0].Decode == nil || result[0].Decode.Serial != 317000 || result[0].Status != 200 {
+		t.Errorf("expected the decode to round trip, got %+v", result[0])
+	}
+}
@@ -0,0 +1,176 @@
+package vinpb
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("vinpb: message is truncated")
+
+//encoder appends fields in the protobuf wire format, zero values are skipped like proto3 does.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) tag(field, wire int) {
+	e.buf = appendVarint(e.buf, uint64(field<<3|wire))
+}
+
+func (e *encoder) varint(field int, v int64) {
+	if v == 0 {
+		return
+	}
+
+	e.tag(field, wireVarint)
+	e.buf = appendVarint(e.buf, uint64(v))
+}
+
+func (e *encoder) bytes(field int, b []byte) {
+	e.tag(field, wireBytes)
+	e.buf = appendVarint(e.buf, uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) string(field int, s string) {
+	if len(s) == 0 {
+		return
+	}
+
+	e.bytes(field, []byte(s))
+}
+
+//message writes a nested message
+func (e *encoder) message(field int, m marshaler) {
+	e.bytes(field, m.Marshal())
+}
+
+//packed writes repeated integers in a single field, the proto3 default
+func (e *encoder) packed(field int, vals []int32) {
+	if len(vals) == 0 {
+		return
+	}
+
+	var b []byte
+
+	for _, v := range vals {
+		b = appendVarint(b, uint64(int64(v)))
+	}
+
+	e.bytes(field, b)
+}
+
+//appendVarint is binary.PutUvarint for a growing slice
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(b, byte(v))
+}
+
+type marshaler interface {
+	Marshal() []byte
+}
+
+//field is a single decoded field, value holds varints and data holds length delimited fields
+type field struct {
+	num   int
+	wire  int
+	value uint64
+	data  []byte
+}
+
+//decode calls fn for every field in b
+func decode(b []byte, fn func(f field) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+
+		if n <= 0 {
+			return errTruncated
+		}
+
+		b = b[n:]
+		f := field{num: int(key >> 3), wire: int(key & 7)}
+
+		switch f.wire {
+		case wireVarint:
+			f.value, n = binary.Uvarint(b)
+
+			if n <= 0 {
+				return errTruncated
+			}
+
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return errTruncated
+			}
+
+			b = b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return errTruncated
+			}
+
+			b = b[4:]
+		case wireBytes:
+			size, n := binary.Uvarint(b)
+
+			if n <= 0 || uint64(len(b)-n) < size {
+				return errTruncated
+			}
+
+			f.data = b[n : n+int(size)]
+			b = b[n+int(size):]
+		default:
+			return errors.New("vinpb: unsupported wire type")
+		}
+
+		err := fn(f)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f field) int32() int32 {
+	return int32(int64(f.value))
+}
+
+func (f field) string() string {
+	return string(f.data)
+}
+
+//int32s reads a repeated integer field, which may be packed or not
+func (f field) int32s() ([]int32, error) {
+	if f.wire == wireVarint {
+		return []int32{f.int32()}, nil
+	}
+
+	var result []int32
+	b := f.data
+
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+
+		if n <= 0 {
+			return nil, errTruncated
+		}
+
+		result = append(result, int32(int64(v)))
+		b = b[n:]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,82 @@
+//Package warranty calculates the remaining factory warranty of a decoded VIN.
+package warranty
+
+import (
+	"errors"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+//Window is the factory warranty of a vehicle for one coverage
+type Window struct {
+	Coverage        string
+	Starts          time.Time //Estimated build date, see core.EstimateBuildDate
+	Expires         time.Time
+	RemainingMonths int
+	Distance        int //Kilometres, 0 when the distance is unlimited
+	Expired         bool
+	Confidence      float64 //Confidence of the build date
+}
+
+//Estimate returns the remaining warranty of the policy, from the estimated build date of the VIN.
+//Vehicles are usually sold and registered after they were built, so the remaining warranty is a minimum.
+func Estimate(vin core.VIN, policy core.WarrantyPolicy) (Window, error) {
+	built, err := core.EstimateBuildDate(vin)
+
+	if err != nil {
+		return Window{}, err
+	}
+
+	return estimateAt(built, policy, time.Now()), nil
+}
+
+//EstimateAll returns a Window for every policy of the VIN's manufacturer
+func EstimateAll(vin core.VIN) ([]Window, error) {
+	policies := core.WarrantyPolicies(vin)
+
+	if len(policies) == 0 {
+		return nil, errors.New("no warranty policies for the manufacturer")
+	}
+
+	var result []Window
+
+	for _, p := range policies {
+		w, err := Estimate(vin, p)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, w)
+	}
+
+	return result, nil
+}
+
+//estimateAt starts the warranty at the first of the build month, or January of the model year when the month is unknown.
+func estimateAt(built core.BuildDate, policy core.WarrantyPolicy, now time.Time) Window {
+	month := built.Month
+
+	if month == 0 {
+		month = time.January
+	}
+
+	starts := time.Date(built.Year, month, 1, 0, 0, 0, 0, time.UTC)
+	expires := starts.AddDate(0, policy.Months, 0)
+
+	result := Window{
+		Coverage:   policy.Coverage,
+		Starts:     starts,
+		Expires:    expires,
+		Distance:   policy.Distance,
+		Expired:    !now.Before(expires),
+		Confidence: built.Confidence,
+	}
+
+	if !result.Expired {
+		result.RemainingMonths = (expires.Year()-now.Year())*12 + int(expires.Month()-now.Month())
+	}
+
+	return result
+}
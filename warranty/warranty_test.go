@@ -0,0 +1,26 @@
+package warranty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/louisevanderlith/vin/core"
+)
+
+func TestEstimateAt(t *testing.T) {
+	now := time.Date(2020, time.March, 15, 0, 0, 0, 0, time.UTC)
+	built := core.BuildDate{Year: 2018, Month: time.June, Confidence: 0.9}
+	policy := core.WarrantyPolicy{Manufacturer: "BMW", Coverage: "basic", Months: 36, Distance: 60000}
+
+	w := estimateAt(built, policy, now)
+
+	if w.Expired || w.RemainingMonths != 15 || w.Expires.Year() != 2021 || w.Expires.Month() != time.June {
+		t.Errorf("expected 15 months until June 2021, got %+v", w)
+	}
+
+	w = estimateAt(core.BuildDate{Year: 2016}, policy, now)
+
+	if !w.Expired || w.RemainingMonths != 0 {
+		t.Errorf("expected the warranty of a 2016 model to be expired, got %+v", w)
+	}
+}